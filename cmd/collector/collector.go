@@ -43,6 +43,7 @@ var (
 	IPFIXAddr      string
 	IPFIXPort      uint16
 	IPFIXTransport string
+	RegistryFiles  []string
 )
 
 func initLoggingToFile(fs *pflag.FlagSet) {
@@ -64,6 +65,7 @@ func addIPFIXFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&IPFIXAddr, "ipfix.addr", "", "IPFIX collector address")
 	fs.Uint16Var(&IPFIXPort, "ipfix.port", 4739, "IPFIX collector port")
 	fs.StringVar(&IPFIXTransport, "ipfix.transport", "tcp", "IPFIX collector transport layer")
+	fs.StringSliceVar(&RegistryFiles, "ipfix.registry", nil, "CSV or YAML files of additional information elements to load into the registry")
 }
 
 func printIPFIXMessage(msg *entities.Message) {
@@ -73,28 +75,41 @@ func printIPFIXMessage(msg *entities.Message) {
 	fmt.Fprintf(&buf, "  Exported Time: %v (%v)\n", msg.GetExportTime(), time.Unix(int64(msg.GetExportTime()), 0))
 	fmt.Fprintf(&buf, "  Sequence No.: %v,  Observation Domain ID: %v\n", msg.GetSequenceNum(), msg.GetObsDomainID())
 
-	set := msg.GetSet()
-	if set.GetSetType() == entities.Template {
-		fmt.Fprint(&buf, "TEMPLATE SET:\n")
-		for i, record := range set.GetRecords() {
-			fmt.Fprintf(&buf, "  TEMPLATE RECORD-%d:\n", i)
-			for _, ie := range record.GetOrderedElementList() {
-				fmt.Fprintf(&buf, "    %s: len=%d (enterprise ID = %d) \n", ie.Element.Name, ie.Element.Len, ie.Element.EnterpriseId)
+	for _, set := range msg.GetSets() {
+		if set.GetSetType() == entities.Template {
+			fmt.Fprint(&buf, "TEMPLATE SET:\n")
+			for i, record := range set.GetRecords() {
+				fmt.Fprintf(&buf, "  TEMPLATE RECORD-%d:\n", i)
+				for _, ie := range record.GetOrderedElementList() {
+					fmt.Fprintf(&buf, "    %s: len=%d (enterprise ID = %d) \n", ie.Element.Name, ie.Element.Len, ie.Element.EnterpriseId)
+				}
 			}
-		}
-	} else {
-		fmt.Fprint(&buf, "DATA SET:\n")
-		for i, record := range set.GetRecords() {
-			fmt.Fprintf(&buf, "  DATA RECORD-%d:\n", i)
-			for _, ie := range record.GetOrderedElementList() {
-				fmt.Fprintf(&buf, "    %s: %v \n", ie.Element.Name, ie.Value)
+		} else if set.GetSetType() == entities.OptionsTemplate {
+			fmt.Fprint(&buf, "OPTIONS TEMPLATE SET:\n")
+			for i, record := range set.GetRecords() {
+				fmt.Fprintf(&buf, "  OPTIONS TEMPLATE RECORD-%d (scope field count = %d):\n", i, record.GetScopeFieldCount())
+				for _, ie := range record.GetOrderedElementList() {
+					fmt.Fprintf(&buf, "    %s: len=%d (enterprise ID = %d) \n", ie.Element.Name, ie.Element.Len, ie.Element.EnterpriseId)
+				}
+			}
+		} else {
+			fmt.Fprint(&buf, "DATA SET:\n")
+			for i, record := range set.GetRecords() {
+				if record.GetScopeFieldCount() > 0 {
+					fmt.Fprintf(&buf, "  OPTIONS DATA RECORD-%d (scope field count = %d):\n", i, record.GetScopeFieldCount())
+				} else {
+					fmt.Fprintf(&buf, "  DATA RECORD-%d:\n", i)
+				}
+				for _, ie := range record.GetOrderedElementList() {
+					fmt.Fprintf(&buf, "    %s: %v \n", ie.Element.Name, ie.Value)
+				}
 			}
 		}
 	}
 	klog.Infof(buf.String())
 }
 
-func signalHandler(stopCh chan struct{}, messageReceived chan *entities.Message) {
+func signalHandler(stopCh chan struct{}, messageReceived chan *entities.Message, templateEvents chan *collector.TemplateEvent) {
 	signalCh := make(chan os.Signal, 1)
 	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 
@@ -102,6 +117,8 @@ func signalHandler(stopCh chan struct{}, messageReceived chan *entities.Message)
 		select {
 		case msg := <-messageReceived:
 			printIPFIXMessage(msg)
+		case event := <-templateEvents:
+			klog.Infof("Template with id %d, and obsDomainID %d from %s is %s.", event.TemplateID, event.ObsDomainID, event.ExporterAddress, event.Type)
 		case <-signalCh:
 			close(stopCh)
 			return
@@ -114,6 +131,12 @@ func run() error {
 
 	// Load the IPFIX global registry
 	registry.LoadRegistry()
+	for _, file := range RegistryFiles {
+		if err := registry.LoadFile(file); err != nil {
+			return err
+		}
+		klog.Infof("Loaded information elements from %s", file)
+	}
 
 	var netAddr net.Addr
 	var err error
@@ -155,7 +178,7 @@ func run() error {
 	}()
 
 	stopCh := make(chan struct{})
-	go signalHandler(stopCh, messageReceived)
+	go signalHandler(stopCh, messageReceived, cp.GetTemplateEventChan())
 
 	<-stopCh
 	// Stop the collector process
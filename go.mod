@@ -11,6 +11,7 @@ require (
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.6.1
 	google.golang.org/protobuf v1.25.0
+	gopkg.in/yaml.v3 v3.0.1
 	k8s.io/apimachinery v0.18.4
 	k8s.io/component-base v0.18.4
 	k8s.io/klog v1.0.0
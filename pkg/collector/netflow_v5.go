@@ -0,0 +1,173 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+	"github.com/vmware/go-ipfix/pkg/registry"
+	"github.com/vmware/go-ipfix/pkg/util"
+)
+
+// NetFlow v5 packets have a fixed format without templates. Records are
+// decoded as data records of a virtual template, whose fields are mapped onto
+// IANA Information Elements.
+const (
+	// netflowV5Version is the version number in the NetFlow v5 packet header.
+	netflowV5Version uint16 = 5
+	// netflowV5RecordLen is the length of a NetFlow v5 flow record.
+	netflowV5RecordLen = 48
+	// NetflowV5TemplateID is the ID of the virtual template used for the data
+	// sets of NetFlow v5 messages.
+	NetflowV5TemplateID uint16 = 256
+)
+
+// netflowV5Field is a field of the NetFlow v5 flow record. Padding fields do not
+// have a name.
+type netflowV5Field struct {
+	name   string
+	length uint16
+}
+
+// netflowV5RecordFields are the fields of the NetFlow v5 flow record in order.
+var netflowV5RecordFields = []netflowV5Field{
+	{"sourceIPv4Address", 4},           // srcaddr
+	{"destinationIPv4Address", 4},      // dstaddr
+	{"ipNextHopIPv4Address", 4},        // nexthop
+	{"ingressInterface", 2},            // input
+	{"egressInterface", 2},             // output
+	{"packetDeltaCount", 4},            // dPkts
+	{"octetDeltaCount", 4},             // dOctets
+	{"flowStartSysUpTime", 4},          // First
+	{"flowEndSysUpTime", 4},            // Last
+	{"sourceTransportPort", 2},         // srcport
+	{"destinationTransportPort", 2},    // dstport
+	{"", 1},                            // pad1
+	{"tcpControlBits", 1},              // tcp_flags
+	{"protocolIdentifier", 1},          // prot
+	{"ipClassOfService", 1},            // tos
+	{"bgpSourceAsNumber", 2},           // src_as
+	{"bgpDestinationAsNumber", 2},      // dst_as
+	{"sourceIPv4PrefixLength", 1},      // src_mask
+	{"destinationIPv4PrefixLength", 1}, // dst_mask
+	{"", 2},                            // pad2
+}
+
+// netflowV5HeaderFields are the fields added to every record from the packet
+// header. Flow start and end times are converted from system uptime to
+// absolute time, which is what the IPFIX consumers expect.
+var netflowV5HeaderFields = []netflowV5Field{
+	{"flowStartSeconds", 4},
+	{"flowEndSeconds", 4},
+	{"engineType", 1},
+	{"engineId", 1},
+	{"samplingAlgorithm", 1},
+	{"samplingInterval", 2},
+}
+
+// getNetflowV5Template returns the elements of the virtual template of NetFlow
+// v5 records: the record fields without padding followed by the header fields.
+func (cp *CollectingProcess) getNetflowV5Template() ([]*entities.InfoElement, error) {
+	elements := make([]*entities.InfoElement, 0)
+	for _, field := range append(netflowV5RecordFields, netflowV5HeaderFields...) {
+		if field.name == "" {
+			continue
+		}
+		element, err := cp.getRegistry().LookupByName(field.name, registry.IANAEnterpriseID)
+		if err != nil {
+			return nil, err
+		}
+		if element.Len != field.length {
+			element = element.WithLength(field.length)
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
+
+func (cp *CollectingProcess) decodeNetflowV5Message(packetBuffer *bytes.Buffer, exportAddress string) (*entities.Message, error) {
+	msgLen := packetBuffer.Len()
+	var version, count, samplingInterval uint16
+	var sysUpTime, unixSecs, unixNsecs, flowSequence uint32
+	var engineType, engineID uint8
+	err := util.Decode(packetBuffer, binary.BigEndian, &version, &count, &sysUpTime, &unixSecs, &unixNsecs, &flowSequence, &engineType, &engineID, &samplingInterval)
+	if err != nil {
+		return nil, err
+	}
+	if packetBuffer.Len() < int(count)*netflowV5RecordLen {
+		return nil, fmt.Errorf("error in decoding message: %d bytes are not enough for %d NetFlow v5 records", packetBuffer.Len(), count)
+	}
+	template, err := cp.getNetflowV5Template()
+	if err != nil {
+		return nil, err
+	}
+
+	// NetFlow v5 does not have an observation domain, so engine type and engine
+	// ID, which identify the flow switching engine, are used instead.
+	message := entities.NewMessage(true)
+	message.SetVersion(version)
+	message.SetMessageLen(uint16(msgLen))
+	message.SetExportTime(unixSecs)
+	message.SetSequenceNum(flowSequence)
+	message.SetObsDomainID(uint32(engineType)<<8 | uint32(engineID))
+	message.SetExportAddress(strings.Split(exportAddress, ":")[0])
+
+	// System boot time in milliseconds since epoch, used to convert the flow
+	// start and end times.
+	bootTime := int64(unixSecs)*1000 + int64(unixNsecs)/1000000 - int64(sysUpTime)
+
+	dataSet := entities.NewSet(entities.Data, NetflowV5TemplateID, true)
+	for i := 0; i < int(count); i++ {
+		elements := make([]*entities.InfoElementWithValue, 0, len(template))
+		recordBuffer := bytes.NewBuffer(packetBuffer.Next(netflowV5RecordLen))
+		var flowStart, flowEnd uint32
+		for _, field := range netflowV5RecordFields {
+			val := recordBuffer.Next(int(field.length))
+			if field.name == "" {
+				continue
+			}
+			switch field.name {
+			case "flowStartSysUpTime":
+				flowStart = binary.BigEndian.Uint32(val)
+			case "flowEndSysUpTime":
+				flowEnd = binary.BigEndian.Uint32(val)
+			}
+			element := template[len(elements)]
+			elements = append(elements, entities.NewInfoElementWithValue(element, bytes.NewBuffer(val)))
+		}
+		// Values of netflowV5HeaderFields in the same order. The first two bits
+		// of the sampling interval field hold the sampling mode.
+		headerBuffer := &bytes.Buffer{}
+		err = util.Encode(headerBuffer, binary.BigEndian, uint32((bootTime+int64(flowStart))/1000), uint32((bootTime+int64(flowEnd))/1000),
+			engineType, engineID, uint8(samplingInterval>>14), samplingInterval&0x3fff)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range netflowV5HeaderFields {
+			element := template[len(elements)]
+			val := headerBuffer.Next(int(field.length))
+			elements = append(elements, entities.NewInfoElementWithValue(element, bytes.NewBuffer(val)))
+		}
+		if err = dataSet.AddRecord(elements, NetflowV5TemplateID); err != nil {
+			return nil, fmt.Errorf("error in decoding message: %v", err)
+		}
+	}
+	message.AddSet(dataSet)
+	return message, nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"k8s.io/klog"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+	"github.com/vmware/go-ipfix/pkg/registry"
+	"github.com/vmware/go-ipfix/pkg/util"
+)
+
+// NetFlow v9 message format is defined in RFC3954. Messages are decoded into
+// the same message model as IPFIX messages, with field types mapped onto IANA
+// Information Elements.
+const (
+	// netflowV9Version is the version number in the NetFlow v9 packet header.
+	netflowV9Version uint16 = 9
+	// netflowV9TemplateFlowSetID is the FlowSet ID of a template FlowSet.
+	netflowV9TemplateFlowSetID uint16 = 0
+	// netflowV9OptionsTemplateFlowSetID is the FlowSet ID of an options
+	// template FlowSet.
+	netflowV9OptionsTemplateFlowSetID uint16 = 1
+	// netflowV9FieldSpecifierLen is the length of field type and field length
+	// in a template record.
+	netflowV9FieldSpecifierLen = 4
+)
+
+func (cp *CollectingProcess) decodeNetflowV9Message(packetBuffer *bytes.Buffer, exportAddress string) (*entities.Message, error) {
+	// NetFlow v9 header does not have a message length, so use the length of
+	// the packet instead.
+	msgLen := packetBuffer.Len()
+	var version, count uint16
+	var sysUpTime, unixSecs, sequenceNum, sourceID uint32
+	err := util.Decode(packetBuffer, binary.BigEndian, &version, &count, &sysUpTime, &unixSecs, &sequenceNum, &sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Source ID is the equivalent of the IPFIX observation domain ID.
+	message := entities.NewMessage(true)
+	message.SetVersion(version)
+	message.SetMessageLen(uint16(msgLen))
+	message.SetExportTime(unixSecs)
+	message.SetSequenceNum(sequenceNum)
+	message.SetObsDomainID(sourceID)
+	message.SetExportAddress(strings.Split(exportAddress, ":")[0])
+	scope := templateScope{exporterAddress: exportAddress, obsDomainID: sourceID}
+
+	for packetBuffer.Len() > 0 {
+		var flowSetID, flowSetLen uint16
+		err = util.Decode(packetBuffer, binary.BigEndian, &flowSetID, &flowSetLen)
+		if err != nil {
+			return nil, fmt.Errorf("error in decoding FlowSet header: %v", err)
+		}
+		if flowSetLen < entities.SetHeaderLen || int(flowSetLen-entities.SetHeaderLen) > packetBuffer.Len() {
+			return nil, fmt.Errorf("error in decoding message: invalid FlowSet length %d for FlowSet with ID %d", flowSetLen, flowSetID)
+		}
+		flowSetBuffer := bytes.NewBuffer(packetBuffer.Next(int(flowSetLen - entities.SetHeaderLen)))
+
+		var set entities.Set
+		if flowSetID == netflowV9TemplateFlowSetID {
+			set, err = cp.decodeNetflowV9TemplateFlowSet(flowSetBuffer, scope)
+		} else if flowSetID == netflowV9OptionsTemplateFlowSetID {
+			set, err = cp.decodeNetflowV9OptionsTemplateFlowSet(flowSetBuffer, scope)
+		} else if flowSetID >= entities.MinDataSetID {
+			set, err = cp.decodeDataSet(flowSetBuffer, scope, flowSetID)
+		} else {
+			// FlowSet IDs 2-255 are reserved (RFC3954 section 5.2), so the
+			// FlowSet is skipped by its length.
+			klog.V(4).Infof("Skipping FlowSet with reserved ID %d from %s", flowSetID, exportAddress)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error in decoding message: %v", err)
+		}
+		message.AddSet(set)
+	}
+	return message, nil
+}
+
+func (cp *CollectingProcess) decodeNetflowV9TemplateFlowSet(templateBuffer *bytes.Buffer, scope templateScope) (entities.Set, error) {
+	templateSet := entities.NewSet(entities.Template, entities.TemplateSetID, true)
+	// Any remaining bytes shorter than a template record header are considered
+	// as FlowSet padding.
+	for templateBuffer.Len() >= templateRecordHeaderLen {
+		var templateID, fieldCount uint16
+		err := util.Decode(templateBuffer, binary.BigEndian, &templateID, &fieldCount)
+		if err != nil {
+			return nil, err
+		}
+		if templateID == 0 && fieldCount == 0 { // remaining bytes are FlowSet padding
+			break
+		}
+		if templateID < entities.MinDataSetID || fieldCount == 0 {
+			return nil, fmt.Errorf("template %d with field count %d is not valid", templateID, fieldCount)
+		}
+		elementsWithValue, err := decodeNetflowV9TemplateFields(templateBuffer, int(fieldCount), cp.getNetflowV9InfoElement)
+		if err != nil {
+			return nil, err
+		}
+		templateSet.AddRecord(elementsWithValue, templateID)
+		cp.addTemplate(scope, templateID, elementsWithValue, 0)
+	}
+	return templateSet, nil
+}
+
+func (cp *CollectingProcess) decodeNetflowV9OptionsTemplateFlowSet(templateBuffer *bytes.Buffer, scope templateScope) (entities.Set, error) {
+	optionsTemplateSet := entities.NewSet(entities.OptionsTemplate, entities.OptionsTemplateSetID, true)
+	// Any remaining bytes shorter than an options template record header are
+	// considered as FlowSet padding.
+	for templateBuffer.Len() >= optionsTemplateRecordHeaderLen {
+		// Unlike IPFIX, scope and option lengths are given in bytes.
+		var templateID, scopeLen, optionLen uint16
+		err := util.Decode(templateBuffer, binary.BigEndian, &templateID, &scopeLen, &optionLen)
+		if err != nil {
+			return nil, err
+		}
+		if templateID == 0 { // remaining bytes are FlowSet padding
+			break
+		}
+		if templateID < entities.MinDataSetID {
+			return nil, fmt.Errorf("options template ID %d is not valid", templateID)
+		}
+		if scopeLen == 0 || scopeLen%netflowV9FieldSpecifierLen != 0 || optionLen%netflowV9FieldSpecifierLen != 0 {
+			return nil, fmt.Errorf("options template %d has invalid scope length %d or option length %d", templateID, scopeLen, optionLen)
+		}
+		scopeFieldCount := scopeLen / netflowV9FieldSpecifierLen
+		scopeElements, err := decodeNetflowV9TemplateFields(templateBuffer, int(scopeFieldCount), cp.getRegistry().LookupNetflowV9Scope)
+		if err != nil {
+			return nil, err
+		}
+		optionElements, err := decodeNetflowV9TemplateFields(templateBuffer, int(optionLen/netflowV9FieldSpecifierLen), cp.getNetflowV9InfoElement)
+		if err != nil {
+			return nil, err
+		}
+		elementsWithValue := append(scopeElements, optionElements...)
+		if err = optionsTemplateSet.AddOptionsRecord(elementsWithValue, scopeFieldCount, templateID); err != nil {
+			return nil, err
+		}
+		cp.addTemplate(scope, templateID, elementsWithValue, scopeFieldCount)
+	}
+	return optionsTemplateSet, nil
+}
+
+// getNetflowV9InfoElement returns the element of the NetFlow v9 field type.
+// Field types which are not known, such as vendor specific types, are
+// synthesized as octetArray elements like unknown IPFIX elements, so that the
+// records of the template can still be decoded.
+func (cp *CollectingProcess) getNetflowV9InfoElement(fieldType uint16) (*entities.InfoElement, error) {
+	element, err := cp.getRegistry().LookupNetflowV9(fieldType)
+	if err == nil {
+		return element, nil
+	}
+	klog.V(4).Infof("Decoding unknown NetFlow v9 field type %d as octetArray: %v", fieldType, err)
+	// Length of the element is given in the template.
+	return entities.NewInfoElement(getUnknownInfoElementName(fieldType, registry.IANAEnterpriseID), fieldType, entities.OctetArray, registry.IANAEnterpriseID, entities.VariableLength), nil
+}
+
+// decodeNetflowV9TemplateFields decodes fieldCount field specifiers of a
+// NetFlow v9 (options) template record. NetFlow v9 field lengths often differ
+// from the lengths of the corresponding IANA elements, so the returned elements
+// carry the field length given in the template.
+func decodeNetflowV9TemplateFields(templateBuffer *bytes.Buffer, fieldCount int, getInfoElement func(uint16) (*entities.InfoElement, error)) ([]*entities.InfoElementWithValue, error) {
+	elementsWithValue := make([]*entities.InfoElementWithValue, 0)
+	for i := 0; i < fieldCount; i++ {
+		var fieldType, fieldLength uint16
+		err := util.Decode(templateBuffer, binary.BigEndian, &fieldType, &fieldLength)
+		if err != nil {
+			return nil, err
+		}
+		element, err := getInfoElement(fieldType)
+		if err != nil {
+			return nil, err
+		}
+		// NetFlow v9 does not support variable length fields. Values shorter
+		// than the data type follow reduced-size encoding.
+		if fieldLength == 0 || fieldLength == entities.VariableLength || !entities.IsValidElementLength(element.DataType, fieldLength) {
+			return nil, fmt.Errorf("field length %d is not valid for field type %d", fieldLength, fieldType)
+		}
+		if fieldLength != element.Len {
+			element = element.WithLength(fieldLength)
+		}
+		elementsWithValue = append(elementsWithValue, entities.NewInfoElementWithValue(element, nil))
+	}
+	return elementsWithValue, nil
+}
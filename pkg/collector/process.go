@@ -19,6 +19,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -30,13 +31,83 @@ import (
 	"github.com/vmware/go-ipfix/pkg/util"
 )
 
+const (
+	// ipfixVersion is the version number in the IPFIX message header.
+	ipfixVersion uint16 = 10
+	// templateRecordHeaderLen is the length of template ID and field count in a
+	// template record header.
+	templateRecordHeaderLen = 4
+	// optionsTemplateRecordHeaderLen is the length of template ID, field count
+	// and scope field count in an options template record header.
+	optionsTemplateRecordHeaderLen = 6
+	// templateEventChanSize is the buffer size of the template event channel.
+	templateEventChanSize = 100
+)
+
+// TemplateEventType is the type of a template lifecycle event.
+type TemplateEventType uint8
+
+const (
+	// TemplateAdded is sent when a new template is received, or when an existing
+	// template ID is redefined with a different template.
+	TemplateAdded TemplateEventType = iota
+	// TemplateRefreshed is sent when an already known template is received again.
+	TemplateRefreshed
+	// TemplateWithdrawn is sent when a template is withdrawn by the exporter, or
+	// when the TCP session of the template is closed.
+	TemplateWithdrawn
+	// TemplateExpired is sent when a template received over UDP is not
+	// refreshed within the template TTL.
+	TemplateExpired
+)
+
+func (t TemplateEventType) String() string {
+	switch t {
+	case TemplateAdded:
+		return "added"
+	case TemplateRefreshed:
+		return "refreshed"
+	case TemplateWithdrawn:
+		return "withdrawn"
+	case TemplateExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// TemplateEvent describes a change in the lifecycle of a (options) template.
+type TemplateEvent struct {
+	Type TemplateEventType
+	// ExporterAddress is the address (IP and port) of the transport session.
+	ExporterAddress string
+	ObsDomainID     uint32
+	TemplateID      uint16
+}
+
+// TemplateInfo describes a (options) template known by the collecting process.
+type TemplateInfo struct {
+	// ExporterAddress is the address (IP and port) of the transport session.
+	ExporterAddress string
+	ObsDomainID     uint32
+	TemplateID      uint16
+	// ScopeFieldCount is non-zero only for options templates.
+	ScopeFieldCount uint16
+	Elements        []*entities.InfoElement
+}
+
 type CollectingProcess struct {
-	// for each obsDomainID, there is a map of templates
-	templatesMap map[uint32]map[uint16][]*entities.InfoElement
+	// for each transport session and obsDomainID, there is a map of templates
+	templatesMap map[templateScope]map[uint16]*template
 	// mutex allows multiple readers or one writer at the same time
 	mutex sync.RWMutex
 	// template lifetime
 	templateTTL uint32
+	// templateExpiry tracks the expiry of templates received over UDP
+	templateExpiry templateExpiryQueue
+	// unwithdrawnRedefinitionCount is the number of templates redefined over
+	// TCP without being withdrawn first
+	unwithdrawnRedefinitionCount uint64
 	// server information
 	address net.Addr
 	// maximum buffer size to read the record
@@ -45,6 +116,8 @@ type CollectingProcess struct {
 	stopChan chan bool
 	// messageChan is the channel to output message
 	messageChan chan *entities.Message
+	// templateEventChan is the channel to output template lifecycle events
+	templateEventChan chan *TemplateEvent
 	// maps each client to its client handler (required channels)
 	clients map[string]*clientHandler
 	// isEncrypted indicates whether to use TLS/DTLS for communication
@@ -53,6 +126,11 @@ type CollectingProcess struct {
 	caCert     []byte
 	serverCert []byte
 	serverKey  []byte
+	// registry is used to look up Information Elements of received templates
+	registry *registry.Registry
+	// sessionRegistries maps each transport session to the Information Elements
+	// described by type information records (RFC5610) in the session
+	sessionRegistries map[string]*registry.Registry
 }
 
 type CollectorInput struct {
@@ -64,6 +142,27 @@ type CollectorInput struct {
 	CACert     []byte
 	ServerCert []byte
 	ServerKey  []byte
+	// Registry is the registry of Information Elements. The default registry
+	// is used if it is not given.
+	Registry *registry.Registry
+}
+
+// templateScope identifies the scope of template IDs. Templates are unique per
+// transport session and observation domain (RFC7011 section 8), so the scope is
+// made of the exporter address (IP and port) and obsDomainID.
+type templateScope struct {
+	exporterAddress string
+	obsDomainID     uint32
+}
+
+// template stores the elements of a (options) template. scopeFieldCount is
+// non-zero only for options templates.
+type template struct {
+	elements        []*entities.InfoElement
+	scopeFieldCount uint16
+	// expiresAt is the time at which the template expires if it is not
+	// refreshed. It is only set for templates received over UDP.
+	expiresAt time.Time
 }
 
 type clientHandler struct {
@@ -73,18 +172,21 @@ type clientHandler struct {
 
 func InitCollectingProcess(input CollectorInput) (*CollectingProcess, error) {
 	collectProc := &CollectingProcess{
-		templatesMap:  make(map[uint32]map[uint16][]*entities.InfoElement),
-		mutex:         sync.RWMutex{},
-		templateTTL:   input.TemplateTTL,
-		address:       input.Address,
-		maxBufferSize: input.MaxBufferSize,
-		stopChan:      make(chan bool),
-		messageChan:   make(chan *entities.Message),
-		clients:       make(map[string]*clientHandler),
-		isEncrypted:   input.IsEncrypted,
-		caCert:        input.CACert,
-		serverCert:    input.ServerCert,
-		serverKey:     input.ServerKey,
+		templatesMap:      make(map[templateScope]map[uint16]*template),
+		mutex:             sync.RWMutex{},
+		templateTTL:       input.TemplateTTL,
+		address:           input.Address,
+		maxBufferSize:     input.MaxBufferSize,
+		stopChan:          make(chan bool),
+		messageChan:       make(chan *entities.Message),
+		templateEventChan: make(chan *TemplateEvent, templateEventChanSize),
+		clients:           make(map[string]*clientHandler),
+		isEncrypted:       input.IsEncrypted,
+		caCert:            input.CACert,
+		serverCert:        input.ServerCert,
+		serverKey:         input.ServerKey,
+		registry:          input.Registry,
+		sessionRegistries: make(map[string]*registry.Registry),
 	}
 	return collectProc, nil
 }
@@ -99,6 +201,7 @@ func (cp *CollectingProcess) Start() {
 
 func (cp *CollectingProcess) Stop() {
 	cp.stopChan <- true
+	cp.stopTemplateExpiry()
 }
 
 func (cp *CollectingProcess) GetAddress() net.Addr {
@@ -111,6 +214,13 @@ func (cp *CollectingProcess) GetMsgChan() chan *entities.Message {
 	return cp.messageChan
 }
 
+// GetTemplateEventChan returns the channel of template lifecycle events. The
+// channel is buffered; events are dropped when it is full, so consumers should
+// read from it continuously.
+func (cp *CollectingProcess) GetTemplateEventChan() chan *TemplateEvent {
+	return cp.templateEventChan
+}
+
 func (cp *CollectingProcess) CloseMsgChan() {
 	cp.mutex.Lock()
 	defer cp.mutex.Unlock()
@@ -151,14 +261,44 @@ func (cp *CollectingProcess) closeAllClients() {
 }
 
 func (cp *CollectingProcess) decodePacket(packetBuffer *bytes.Buffer, exportAddress string) (*entities.Message, error) {
-	var version, msgLen, setID, setLen uint16
-	var exportTime, sequencNum, obsDomainID uint32
-	err := util.Decode(packetBuffer, binary.BigEndian, &version, &msgLen, &exportTime, &sequencNum, &obsDomainID, &setID, &setLen)
+	// IPFIX, NetFlow and sFlow headers all start with the version number.
+	if packetBuffer.Len() < 2 {
+		return nil, fmt.Errorf("cannot decode message: message is too short")
+	}
+	var message *entities.Message
+	var err error
+	version := binary.BigEndian.Uint16(packetBuffer.Bytes()[0:2])
+	switch version {
+	case ipfixVersion:
+		message, err = cp.decodeIPFIXMessage(packetBuffer, exportAddress)
+	case netflowV9Version:
+		message, err = cp.decodeNetflowV9Message(packetBuffer, exportAddress)
+	case netflowV5Version:
+		message, err = cp.decodeNetflowV5Message(packetBuffer, exportAddress)
+	case 0:
+		// sFlow datagrams start with a 32-bit version number.
+		if !isSFlowDatagram(packetBuffer.Bytes()) {
+			return nil, fmt.Errorf("collector only supports sFlow v5; invalid sFlow version received")
+		}
+		message, err = cp.decodeSFlowMessage(packetBuffer, exportAddress)
+	default:
+		return nil, fmt.Errorf("collector only supports IPFIX (v10), NetFlow v9, NetFlow v5 and sFlow v5; invalid version %d received", version)
+	}
 	if err != nil {
 		return nil, err
 	}
-	if version != uint16(10) {
-		return nil, fmt.Errorf("collector only supports IPFIX (v10); invalid version %d received", version)
+
+	// the thread(s)/client(s) executing the code will get blocked until the message is consumed/read in other goroutines.
+	cp.messageChan <- message
+	return message, nil
+}
+
+func (cp *CollectingProcess) decodeIPFIXMessage(packetBuffer *bytes.Buffer, exportAddress string) (*entities.Message, error) {
+	var version, msgLen uint16
+	var exportTime, sequencNum, obsDomainID uint32
+	err := util.Decode(packetBuffer, binary.BigEndian, &version, &msgLen, &exportTime, &sequencNum, &obsDomainID)
+	if err != nil {
+		return nil, err
 	}
 
 	message := entities.NewMessage(true)
@@ -168,44 +308,127 @@ func (cp *CollectingProcess) decodePacket(packetBuffer *bytes.Buffer, exportAddr
 	message.SetSequenceNum(sequencNum)
 	message.SetObsDomainID(obsDomainID)
 	message.SetExportAddress(strings.Split(exportAddress, ":")[0])
+	scope := templateScope{exporterAddress: exportAddress, obsDomainID: obsDomainID}
 
-	var set entities.Set
-	if setID == entities.TemplateSetID {
-		set, err = cp.decodeTemplateSet(packetBuffer, obsDomainID)
+	// Walk through all the sets in the message using the length in each set header.
+	for packetBuffer.Len() > 0 {
+		var setID, setLen uint16
+		err = util.Decode(packetBuffer, binary.BigEndian, &setID, &setLen)
 		if err != nil {
-			return nil, fmt.Errorf("error in decoding message: %v", err)
+			return nil, fmt.Errorf("error in decoding set header: %v", err)
 		}
-	} else {
-		set, err = cp.decodeDataSet(packetBuffer, obsDomainID, setID)
-		if err != nil {
-			return nil, fmt.Errorf("error in decoding message: %v", err)
+		if setLen < entities.SetHeaderLen || int(setLen-entities.SetHeaderLen) > packetBuffer.Len() {
+			return nil, fmt.Errorf("error in decoding message: invalid set length %d for set with ID %d", setLen, setID)
 		}
-	}
-	message.AddSet(set)
+		setBuffer := bytes.NewBuffer(packetBuffer.Next(int(setLen - entities.SetHeaderLen)))
 
-	// the thread(s)/client(s) executing the code will get blocked until the message is consumed/read in other goroutines.
-	cp.messageChan <- message
+		var set entities.Set
+		if setID == entities.TemplateSetID {
+			set, err = cp.decodeTemplateSet(setBuffer, scope)
+			if err != nil {
+				return nil, fmt.Errorf("error in decoding message: %v", err)
+			}
+		} else if setID == entities.OptionsTemplateSetID {
+			set, err = cp.decodeOptionsTemplateSet(setBuffer, scope)
+			if err != nil {
+				return nil, fmt.Errorf("error in decoding message: %v", err)
+			}
+		} else if setID >= entities.MinDataSetID {
+			set, err = cp.decodeDataSet(setBuffer, scope, setID)
+			if err != nil {
+				return nil, fmt.Errorf("error in decoding message: %v", err)
+			}
+		} else {
+			return nil, fmt.Errorf("error in decoding message: set ID %d is not supported", setID)
+		}
+		message.AddSet(set)
+	}
 	return message, nil
 }
 
-func (cp *CollectingProcess) decodeTemplateSet(templateBuffer *bytes.Buffer, obsDomainID uint32) (entities.Set, error) {
-	var templateID uint16
-	var fieldCount uint16
-	err := util.Decode(templateBuffer, binary.BigEndian, &templateID, &fieldCount)
-	if err != nil {
-		return nil, err
+func (cp *CollectingProcess) decodeTemplateSet(templateBuffer *bytes.Buffer, scope templateScope) (entities.Set, error) {
+	templateSet := entities.NewSet(entities.Template, entities.TemplateSetID, true)
+	// A template set may contain multiple template records. Any remaining bytes
+	// shorter than a template record header are considered as set padding.
+	for templateBuffer.Len() >= templateRecordHeaderLen {
+		var templateID uint16
+		var fieldCount uint16
+		err := util.Decode(templateBuffer, binary.BigEndian, &templateID, &fieldCount)
+		if err != nil {
+			return nil, err
+		}
+		if fieldCount == 0 {
+			if templateID == 0 { // remaining bytes are set padding
+				break
+			}
+			cp.withdrawTemplate(scope, templateID, entities.TemplateSetID)
+			continue
+		}
+		if templateID < entities.MinDataSetID {
+			return nil, fmt.Errorf("template ID %d is not valid", templateID)
+		}
+		elementsWithValue, err := cp.decodeTemplateFields(templateBuffer, fieldCount, scope)
+		if err != nil {
+			return nil, err
+		}
+		templateSet.AddRecord(elementsWithValue, templateID)
+		cp.addTemplate(scope, templateID, elementsWithValue, 0)
 	}
-	elementsWithValue := make([]*entities.InfoElementWithValue, 0)
-	templateSet := entities.NewSet(entities.Template, templateID, true)
+	return templateSet, nil
+}
+
+func (cp *CollectingProcess) decodeOptionsTemplateSet(templateBuffer *bytes.Buffer, scope templateScope) (entities.Set, error) {
+	optionsTemplateSet := entities.NewSet(entities.OptionsTemplate, entities.OptionsTemplateSetID, true)
+	// Any remaining bytes shorter than an options template record header are
+	// considered as set padding. Options template withdrawal records do not have
+	// scope field count, so they are shorter than the header.
+	for templateBuffer.Len() >= templateRecordHeaderLen {
+		var templateID, fieldCount, scopeFieldCount uint16
+		err := util.Decode(templateBuffer, binary.BigEndian, &templateID, &fieldCount)
+		if err != nil {
+			return nil, err
+		}
+		if fieldCount == 0 {
+			if templateID == 0 { // remaining bytes are set padding
+				break
+			}
+			cp.withdrawTemplate(scope, templateID, entities.OptionsTemplateSetID)
+			continue
+		}
+		if templateID < entities.MinDataSetID {
+			return nil, fmt.Errorf("options template ID %d is not valid", templateID)
+		}
+		err = util.Decode(templateBuffer, binary.BigEndian, &scopeFieldCount)
+		if err != nil {
+			return nil, err
+		}
+		// Scope field count must not be zero (RFC7011 section 3.4.2.2)
+		if scopeFieldCount == 0 || scopeFieldCount > fieldCount {
+			return nil, fmt.Errorf("options template %d has invalid scope field count %d", templateID, scopeFieldCount)
+		}
+		elementsWithValue, err := cp.decodeTemplateFields(templateBuffer, fieldCount, scope)
+		if err != nil {
+			return nil, err
+		}
+		if err = optionsTemplateSet.AddOptionsRecord(elementsWithValue, scopeFieldCount, templateID); err != nil {
+			return nil, err
+		}
+		cp.addTemplate(scope, templateID, elementsWithValue, scopeFieldCount)
+	}
+	return optionsTemplateSet, nil
+}
 
+// decodeTemplateFields decodes fieldCount field specifiers of a (options)
+// template record and returns the corresponding elements without value.
+func (cp *CollectingProcess) decodeTemplateFields(templateBuffer *bytes.Buffer, fieldCount uint16, scope templateScope) ([]*entities.InfoElementWithValue, error) {
+	elementsWithValue := make([]*entities.InfoElementWithValue, 0)
 	for i := 0; i < int(fieldCount); i++ {
-		var element *entities.InfoElement
 		var enterpriseID uint32
 		var elementID uint16
 		// check whether enterprise ID is 0 or not
 		elementid := make([]byte, 2)
 		var elementLength uint16
-		err = util.Decode(templateBuffer, binary.BigEndian, &elementid, &elementLength)
+		err := util.Decode(templateBuffer, binary.BigEndian, &elementid, &elementLength)
 		if err != nil {
 			return nil, err
 		}
@@ -213,10 +436,6 @@ func (cp *CollectingProcess) decodeTemplateSet(templateBuffer *bytes.Buffer, obs
 		if !isNonIANARegistry {
 			elementID = binary.BigEndian.Uint16(elementid)
 			enterpriseID = registry.IANAEnterpriseID
-			element, err = registry.GetInfoElementFromID(elementID, enterpriseID)
-			if err != nil {
-				return nil, err
-			}
 		} else {
 			/*
 				Encoding format for Enterprise-Specific Information Elements:
@@ -239,91 +458,361 @@ func (cp *CollectingProcess) decodeTemplateSet(templateBuffer *bytes.Buffer, obs
 			}
 			elementid[0] = elementid[0] ^ 0x80
 			elementID = binary.BigEndian.Uint16(elementid)
-			element, err = registry.GetInfoElementFromID(elementID, enterpriseID)
-			if err != nil {
-				return nil, err
-			}
+		}
+		element := cp.getInfoElementFromID(scope, elementID, enterpriseID, elementLength)
+		// Field length in the template may be shorter than the element length
+		// in the registry when reduced-size encoding is used, but never 0.
+		if !entities.IsValidElementLength(element.DataType, elementLength) {
+			return nil, fmt.Errorf("field length %d is not valid for element %s", elementLength, element.Name)
+		}
+		if elementLength != element.Len {
+			element = element.WithLength(elementLength)
 		}
 		ie := entities.NewInfoElementWithValue(element, nil)
 		elementsWithValue = append(elementsWithValue, ie)
 	}
-	templateSet.AddRecord(elementsWithValue, templateID)
-	cp.addTemplate(obsDomainID, templateID, elementsWithValue)
-	return templateSet, nil
+	return elementsWithValue, nil
+}
+
+// getInfoElementFromID returns the element with the given ID from the registry,
+// or from the type information received in the transport session. Elements
+// which are not known, such as private elements of vendors, are synthesized as
+// octetArray elements with the field length in the template, so that the rest
+// of the record can still be decoded and the raw value is available to
+// consumers.
+func (cp *CollectingProcess) getInfoElementFromID(scope templateScope, elementID uint16, enterpriseID uint32, length uint16) *entities.InfoElement {
+	element, err := cp.getRegistry().Lookup(elementID, enterpriseID)
+	if err == nil {
+		return element
+	}
+	if element, exist := cp.getSessionInfoElement(scope.exporterAddress, elementID, enterpriseID); exist {
+		return element
+	}
+	klog.V(4).Infof("Decoding unknown information element with elementID %d and enterpriseID %d as octetArray: %v", elementID, enterpriseID, err)
+	return entities.NewInfoElement(getUnknownInfoElementName(elementID, enterpriseID), elementID, entities.OctetArray, enterpriseID, length)
 }
 
-func (cp *CollectingProcess) decodeDataSet(dataBuffer *bytes.Buffer, obsDomainID uint32, templateID uint16) (entities.Set, error) {
+// getUnknownInfoElementName returns the generated name of an element which is
+// not in the registry.
+func getUnknownInfoElementName(elementID uint16, enterpriseID uint32) string {
+	return fmt.Sprintf("enterprise_%d_ie_%d", enterpriseID, elementID)
+}
+
+// getRegistry returns the registry to look up Information Elements, which is
+// the default registry if no registry is given.
+func (cp *CollectingProcess) getRegistry() *registry.Registry {
+	if cp.registry == nil {
+		return registry.Default()
+	}
+	return cp.registry
+}
+
+func (cp *CollectingProcess) decodeDataSet(dataBuffer *bytes.Buffer, scope templateScope, templateID uint16) (entities.Set, error) {
 	// make sure template exists
-	template, err := cp.getTemplate(obsDomainID, templateID)
+	template, err := cp.getTemplate(scope, templateID)
 	if err != nil {
-		return nil, fmt.Errorf("template %d with obsDomainID %d does not exist", templateID, obsDomainID)
+		return nil, fmt.Errorf("template %d with obsDomainID %d from %s does not exist", templateID, scope.obsDomainID, scope.exporterAddress)
 	}
 	dataSet := entities.NewSet(entities.Data, templateID, true)
 
-	for dataBuffer.Len() > 0 {
+	isTypeInfo := isTypeInformationTemplate(template)
+
+	// Any remaining bytes shorter than the minimum data record length are
+	// considered as set padding.
+	minDataRecLen := getMinDataRecordLen(template.elements)
+	for minDataRecLen > 0 && dataBuffer.Len() >= minDataRecLen {
 		elements := make([]*entities.InfoElementWithValue, 0)
-		for _, element := range template {
+		for _, element := range template.elements {
 			var length int
 			if element.Len == entities.VariableLength { // string
 				length = getFieldLength(dataBuffer)
 			} else {
 				length = int(element.Len)
 			}
+			if length > dataBuffer.Len() {
+				return nil, fmt.Errorf("data record for template %d is shorter than expected", templateID)
+			}
 			val := dataBuffer.Next(length)
-			ie := entities.NewInfoElementWithValue(element, bytes.NewBuffer(val))
+			var value interface{} = bytes.NewBuffer(val)
+			if entities.IsStructuredDataType(element.DataType) {
+				// Templates in structured data are resolved in the same scope.
+				value, err = entities.DecodeStructuredData(element.DataType, bytes.NewBuffer(val), &templateResolver{cp, scope})
+				if err != nil {
+					return nil, fmt.Errorf("error in decoding %s of template %d: %v", element.Name, templateID, err)
+				}
+			}
+			ie := entities.NewInfoElementWithValue(element, value)
 			elements = append(elements, ie)
 		}
-		dataSet.AddRecord(elements, templateID)
+		// Records decoded from an options template are flagged as options data
+		// records with their scope fields.
+		if template.scopeFieldCount > 0 {
+			err = dataSet.AddOptionsRecord(elements, template.scopeFieldCount, templateID)
+		} else {
+			err = dataSet.AddRecord(elements, templateID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isTypeInfo {
+			records := dataSet.GetRecords()
+			cp.learnTypeInformation(scope, records[len(records)-1])
+		}
 	}
 	return dataSet, nil
 }
 
-func (cp *CollectingProcess) addTemplate(obsDomainID uint32, templateID uint16, elementsWithValue []*entities.InfoElementWithValue) {
+// templateResolver resolves the elements and templates referred by structured
+// data using the registry and the templates of the given scope.
+type templateResolver struct {
+	cp    *CollectingProcess
+	scope templateScope
+}
+
+func (r *templateResolver) GetInfoElement(elementID uint16, enterpriseID uint32) (*entities.InfoElement, error) {
+	// Length of the element is given in the basicList header.
+	return r.cp.getInfoElementFromID(r.scope, elementID, enterpriseID, entities.VariableLength), nil
+}
+
+func (r *templateResolver) GetTemplateElements(templateID uint16) ([]*entities.InfoElement, error) {
+	template, err := r.cp.getTemplate(r.scope, templateID)
+	if err != nil {
+		return nil, err
+	}
+	return template.elements, nil
+}
+
+func (cp *CollectingProcess) addTemplate(scope templateScope, templateID uint16, elementsWithValue []*entities.InfoElementWithValue, scopeFieldCount uint16) {
 	cp.mutex.Lock()
 	defer cp.mutex.Unlock()
-	if _, exists := cp.templatesMap[obsDomainID]; !exists {
-		cp.templatesMap[obsDomainID] = make(map[uint16][]*entities.InfoElement)
+	if _, exists := cp.templatesMap[scope]; !exists {
+		cp.templatesMap[scope] = make(map[uint16]*template)
 	}
 	elements := make([]*entities.InfoElement, 0)
 	for _, elementWithValue := range elementsWithValue {
 		elements = append(elements, elementWithValue.Element)
 	}
-	cp.templatesMap[obsDomainID][templateID] = elements
+	newTemplate := &template{
+		elements:        elements,
+		scopeFieldCount: scopeFieldCount,
+	}
+	if oldTemplate, exists := cp.templatesMap[scope][templateID]; exists {
+		if oldTemplate.isEqual(newTemplate) {
+			// Template refresh only extends the lifetime of the existing template.
+			if !oldTemplate.expiresAt.IsZero() {
+				oldTemplate.expiresAt = time.Now().Add(cp.getTemplateTTL())
+			}
+			cp.sendTemplateEvent(TemplateRefreshed, scope, templateID)
+			return
+		}
+		// Template ID is redefined; the old definition is no longer valid.
+		if cp.address.Network() == "tcp" {
+			// Templates must be withdrawn before they are redefined over TCP
+			// (RFC7011 section 8.1). The new definition is still used, as
+			// it is what the exporter sends data records for.
+			cp.unwithdrawnRedefinitionCount++
+			klog.Warningf("Template with id %d, and obsDomainID %d from %s is redefined without withdrawal.", templateID, scope.obsDomainID, scope.exporterAddress)
+		} else {
+			klog.Infof("Template with id %d, and obsDomainID %d from %s is redefined.", templateID, scope.obsDomainID, scope.exporterAddress)
+		}
+	}
+	cp.templatesMap[scope][templateID] = newTemplate
+	cp.sendTemplateEvent(TemplateAdded, scope, templateID)
 	// template lifetime management
 	if cp.address.Network() == "tcp" {
 		return
 	}
 
 	// Handle udp template expiration
-	if cp.templateTTL == 0 {
-		cp.templateTTL = entities.TemplateTTL // Default value
-	}
-	go func() {
-		ticker := time.NewTicker(time.Duration(cp.templateTTL) * time.Second)
-		defer ticker.Stop()
-		select {
-		case <-ticker.C:
-			klog.Infof("Template with id %d, and obsDomainID %d is expired.", templateID, obsDomainID)
-			cp.deleteTemplate(obsDomainID, templateID)
-			break
-		}
-	}()
+	newTemplate.expiresAt = time.Now().Add(cp.getTemplateTTL())
+	cp.scheduleTemplateExpiry(&expiryEntry{
+		scope:      scope,
+		templateID: templateID,
+		template:   newTemplate,
+		expiresAt:  newTemplate.expiresAt,
+	})
+}
+
+// GetUnwithdrawnRedefinitionCount returns the number of templates which are
+// redefined over TCP without being withdrawn first, which is not allowed by
+// RFC7011 (section 8.1).
+func (cp *CollectingProcess) GetUnwithdrawnRedefinitionCount() uint64 {
+	cp.mutex.RLock()
+	defer cp.mutex.RUnlock()
+	return cp.unwithdrawnRedefinitionCount
 }
 
-func (cp *CollectingProcess) getTemplate(obsDomainID uint32, templateID uint16) ([]*entities.InfoElement, error) {
+func (cp *CollectingProcess) getTemplate(scope templateScope, templateID uint16) (*template, error) {
 	cp.mutex.RLock()
 	defer cp.mutex.RUnlock()
-	if elements, exists := cp.templatesMap[obsDomainID][templateID]; exists {
-		return elements, nil
+	if template, exists := cp.templatesMap[scope][templateID]; exists {
+		return template, nil
 	} else {
-		return nil, fmt.Errorf("template %d with obsDomainID %d does not exist", templateID, obsDomainID)
+		return nil, fmt.Errorf("template %d with obsDomainID %d from %s does not exist", templateID, scope.obsDomainID, scope.exporterAddress)
+	}
+}
+
+// ListTemplates returns the templates currently known by the collecting
+// process, ordered by exporter address, obsDomainID and template ID.
+func (cp *CollectingProcess) ListTemplates() []*TemplateInfo {
+	cp.mutex.RLock()
+	defer cp.mutex.RUnlock()
+	templates := make([]*TemplateInfo, 0)
+	for scope, templatesInScope := range cp.templatesMap {
+		for templateID, template := range templatesInScope {
+			templates = append(templates, &TemplateInfo{
+				ExporterAddress: scope.exporterAddress,
+				ObsDomainID:     scope.obsDomainID,
+				TemplateID:      templateID,
+				ScopeFieldCount: template.scopeFieldCount,
+				Elements:        template.elements,
+			})
+		}
 	}
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].ExporterAddress != templates[j].ExporterAddress {
+			return templates[i].ExporterAddress < templates[j].ExporterAddress
+		}
+		if templates[i].ObsDomainID != templates[j].ObsDomainID {
+			return templates[i].ObsDomainID < templates[j].ObsDomainID
+		}
+		return templates[i].TemplateID < templates[j].TemplateID
+	})
+	return templates
 }
 
-func (cp *CollectingProcess) deleteTemplate(obsDomainID uint32, templateID uint16) {
+// deleteSessionTemplates deletes all the templates of the transport session
+// with given exporter address. It is called when a TCP session is closed.
+func (cp *CollectingProcess) deleteSessionTemplates(exporterAddress string) {
 	cp.mutex.Lock()
 	defer cp.mutex.Unlock()
-	delete(cp.templatesMap[obsDomainID], templateID)
+	for scope, templatesInScope := range cp.templatesMap {
+		if scope.exporterAddress != exporterAddress {
+			continue
+		}
+		for templateID := range templatesInScope {
+			cp.deleteTemplateLocked(scope, templateID)
+			cp.sendTemplateEvent(TemplateWithdrawn, scope, templateID)
+		}
+		delete(cp.templatesMap, scope)
+	}
+	delete(cp.sessionRegistries, exporterAddress)
+}
+
+// withdrawTemplate handles a template withdrawal record received in the set
+// with given set ID. If template ID is equal to set ID, all the templates
+// (or options templates) of the observation domain are withdrawn. Withdrawals
+// are not allowed over UDP (RFC7011 section 8.1), so they are ignored.
+func (cp *CollectingProcess) withdrawTemplate(scope templateScope, templateID uint16, setID uint16) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	if cp.address.Network() == "udp" {
+		klog.Warningf("Ignoring withdrawal of template with id %d, and obsDomainID %d from %s: template withdrawal is not allowed over UDP.", templateID, scope.obsDomainID, scope.exporterAddress)
+		return
+	}
+	isOptions := setID == entities.OptionsTemplateSetID
+	if templateID == setID {
+		for id, template := range cp.templatesMap[scope] {
+			if (template.scopeFieldCount > 0) == isOptions {
+				cp.deleteTemplateLocked(scope, id)
+				cp.sendTemplateEvent(TemplateWithdrawn, scope, id)
+			}
+		}
+		return
+	}
+	template, exists := cp.templatesMap[scope][templateID]
+	if !exists {
+		klog.Warningf("Ignoring withdrawal of template with id %d, and obsDomainID %d from %s: template does not exist.", templateID, scope.obsDomainID, scope.exporterAddress)
+		return
+	}
+	if (template.scopeFieldCount > 0) != isOptions {
+		klog.Errorf("Ignoring withdrawal of template with id %d, and obsDomainID %d from %s: withdrawal is received in set with ID %d.", templateID, scope.obsDomainID, scope.exporterAddress, setID)
+		return
+	}
+	cp.deleteTemplateLocked(scope, templateID)
+	cp.sendTemplateEvent(TemplateWithdrawn, scope, templateID)
+}
+
+// expireTemplate is called by the template expiry goroutine when the entry is
+// due. The entry is dropped if its template has been deleted or redefined, and
+// rescheduled if the template has been refreshed in the meantime.
+func (cp *CollectingProcess) expireTemplate(entry *expiryEntry) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	scope, templateID := entry.scope, entry.templateID
+	if current, exists := cp.templatesMap[scope][templateID]; !exists || current != entry.template {
+		return
+	}
+	if entry.template.expiresAt.After(time.Now()) {
+		entry.expiresAt = entry.template.expiresAt
+		cp.scheduleTemplateExpiry(entry)
+		return
+	}
+	klog.Infof("Template with id %d, and obsDomainID %d from %s is expired.", templateID, scope.obsDomainID, scope.exporterAddress)
+	cp.deleteTemplateLocked(scope, templateID)
+	// Type information is refreshed with templates over UDP, so it expires
+	// with the last template of the transport session.
+	sessionHasTemplates := false
+	for otherScope := range cp.templatesMap {
+		if otherScope.exporterAddress == scope.exporterAddress {
+			sessionHasTemplates = true
+			break
+		}
+	}
+	if !sessionHasTemplates {
+		delete(cp.sessionRegistries, scope.exporterAddress)
+	}
+	cp.templateExpiry.mutex.Lock()
+	cp.templateExpiry.expiredCount++
+	cp.templateExpiry.mutex.Unlock()
+	cp.sendTemplateEvent(TemplateExpired, scope, templateID)
+}
+
+// deleteTemplateLocked deletes the template; the caller must hold the mutex.
+func (cp *CollectingProcess) deleteTemplateLocked(scope templateScope, templateID uint16) {
+	delete(cp.templatesMap[scope], templateID)
+	if len(cp.templatesMap[scope]) == 0 {
+		delete(cp.templatesMap, scope)
+	}
+}
+
+func (cp *CollectingProcess) getTemplateTTL() time.Duration {
+	if cp.templateTTL == 0 {
+		cp.templateTTL = entities.TemplateTTL // Default value
+	}
+	return time.Duration(cp.templateTTL) * time.Second
+}
+
+// sendTemplateEvent sends the event without blocking the collecting process.
+func (cp *CollectingProcess) sendTemplateEvent(eventType TemplateEventType, scope templateScope, templateID uint16) {
+	if cp.templateEventChan == nil {
+		return
+	}
+	event := &TemplateEvent{
+		Type:            eventType,
+		ExporterAddress: scope.exporterAddress,
+		ObsDomainID:     scope.obsDomainID,
+		TemplateID:      templateID,
+	}
+	select {
+	case cp.templateEventChan <- event:
+	default:
+		klog.Warningf("Template event channel is full; dropping %s event of template with id %d, and obsDomainID %d from %s.", eventType, templateID, scope.obsDomainID, scope.exporterAddress)
+	}
+}
+
+// isEqual returns true if both templates have the same definition.
+func (t *template) isEqual(other *template) bool {
+	if t.scopeFieldCount != other.scopeFieldCount || len(t.elements) != len(other.elements) {
+		return false
+	}
+	for i, element := range t.elements {
+		otherElement := other.elements[i]
+		if element.ElementId != otherElement.ElementId || element.EnterpriseId != otherElement.EnterpriseId || element.Len != otherElement.Len {
+			return false
+		}
+	}
+	return true
 }
 
 func (cp *CollectingProcess) updateAddress(address net.Addr) {
@@ -334,17 +823,35 @@ func (cp *CollectingProcess) updateAddress(address net.Addr) {
 
 // getMessageLength returns buffer length by decoding the header
 func getMessageLength(msgBuffer *bytes.Buffer) (int, error) {
-	var version, msgLen, setID, setLen uint16
-	var exportTime, sequencNum, obsDomainID uint32
-	// We do not really need to decode whole header. Support an utility function
-	// that decodes header based on the offset.
-	err := util.Decode(msgBuffer, binary.BigEndian, &version, &msgLen, &exportTime, &sequencNum, &obsDomainID, &setID, &setLen)
+	var version, msgLen uint16
+	// We do not really need to decode whole header. Decode only the version and
+	// message length, which are the first two fields of the header.
+	err := util.Decode(msgBuffer, binary.BigEndian, &version, &msgLen)
 	if err != nil {
 		return 0, fmt.Errorf("cannot decode message: %v", err)
 	}
+	// NetFlow v9 header does not carry the message length, so messages cannot
+	// be delimited in a stream.
+	if version != ipfixVersion {
+		return 0, fmt.Errorf("cannot decode message: version %d is not supported over a stream transport", version)
+	}
 	return int(msgLen), nil
 }
 
+// getMinDataRecordLen returns the minimum length of a data record for the given
+// template. Elements with variable length are considered to be one byte.
+func getMinDataRecordLen(template []*entities.InfoElement) int {
+	minLen := 0
+	for _, element := range template {
+		if element.Len == entities.VariableLength {
+			minLen = minLen + 1
+		} else {
+			minLen = minLen + int(element.Len)
+		}
+	}
+	return minLen
+}
+
 // getFieldLength returns string field length for data record
 // (encoding reference: https://tools.ietf.org/html/rfc7011#appendix-A.5)
 func getFieldLength(dataBuffer *bytes.Buffer) int {
@@ -18,8 +18,8 @@ import (
 	"bytes"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/binary"
 	"net"
-	"sync"
 	"testing"
 	"time"
 
@@ -195,18 +195,21 @@ func TestTCPCollectingProcess_ReceiveTemplateRecord(t *testing.T) {
 	// wait until collector is ready
 	waitForCollectorReady(t, address)
 
-	go func() {
-		conn, err := net.Dial(address.Network(), address.String())
-		if err != nil {
-			t.Errorf("Cannot establish connection to %s", address.String())
-		}
-		defer conn.Close()
-		conn.Write(validTemplatePacket)
-	}()
+	conn, err := net.Dial(address.Network(), address.String())
+	if err != nil {
+		t.Fatalf("Cannot establish connection to %s", address.String())
+	}
+	conn.Write(validTemplatePacket)
 	<-cp.GetMsgChan()
-	cp.Stop()
-	template, _ := cp.getTemplate(1, 256)
+	template, _ := cp.getTemplate(templateScope{conn.LocalAddr().String(), 1}, 256)
 	assert.NotNil(t, template, "TCP Collecting Process should receive and store the received template.")
+	// Templates of the session are deleted when the session is closed.
+	conn.Close()
+	err = wait.Poll(10*time.Millisecond, 1*time.Second, func() (bool, error) {
+		return len(cp.ListTemplates()) == 0, nil
+	})
+	assert.NoError(t, err, "Templates should be deleted when the TCP session is closed.")
+	cp.Stop()
 }
 
 func TestUDPCollectingProcess_ReceiveTemplateRecord(t *testing.T) {
@@ -244,8 +247,11 @@ func TestUDPCollectingProcess_ReceiveTemplateRecord(t *testing.T) {
 	}()
 	<-cp.GetMsgChan()
 	cp.Stop()
-	template, _ := cp.getTemplate(1, 256)
-	assert.NotNil(t, template, "UDP Collecting Process should receive and store the received template.")
+	templates := cp.ListTemplates()
+	if assert.Equal(t, 1, len(templates), "UDP Collecting Process should receive and store the received template.") {
+		assert.Equal(t, uint32(1), templates[0].ObsDomainID)
+		assert.Equal(t, uint16(256), templates[0].TemplateID)
+	}
 
 }
 
@@ -263,8 +269,6 @@ func TestTCPCollectingProcess_ReceiveDataRecord(t *testing.T) {
 		ServerKey:     nil,
 	}
 	cp, err := InitCollectingProcess(input)
-	// Add the templates before sending data record
-	cp.addTemplate(uint32(1), uint16(256), elementsWithValue)
 	if err != nil {
 		t.Fatalf("TCP Collecting Process does not start correctly: %v", err)
 	}
@@ -280,9 +284,13 @@ func TestTCPCollectingProcess_ReceiveDataRecord(t *testing.T) {
 			t.Errorf("Cannot establish connection to %s", address.String())
 		}
 		defer conn.Close()
+		// Send the template before sending data record
+		conn.Write(validTemplatePacket)
 		conn.Write(validDataPacket)
 	}()
 	<-cp.GetMsgChan()
+	message := <-cp.GetMsgChan()
+	assert.Equal(t, entities.Data, message.GetSets()[0].GetSetType())
 	cp.Stop()
 }
 
@@ -300,8 +308,6 @@ func TestUDPCollectingProcess_ReceiveDataRecord(t *testing.T) {
 		ServerKey:     nil,
 	}
 	cp, err := InitCollectingProcess(input)
-	// Add the templates before sending data record
-	cp.addTemplate(uint32(1), uint16(256), elementsWithValue)
 	if err != nil {
 		t.Fatalf("UDP Collecting Process does not start correctly: %v", err)
 	}
@@ -320,9 +326,13 @@ func TestUDPCollectingProcess_ReceiveDataRecord(t *testing.T) {
 			t.Errorf("UDP Collecting Process does not start correctly.")
 		}
 		defer conn.Close()
+		// Send the template before sending data record
+		conn.Write(validTemplatePacket)
 		conn.Write(validDataPacket)
 	}()
 	<-cp.GetMsgChan()
+	message := <-cp.GetMsgChan()
+	assert.Equal(t, entities.Data, message.GetSets()[0].GetSetType())
 	cp.Stop()
 }
 
@@ -413,71 +423,48 @@ func TestUDPCollectingProcess_ConcurrentClient(t *testing.T) {
 }
 
 func TestCollectingProcess_DecodeTemplateRecord(t *testing.T) {
-	cp := CollectingProcess{}
-	cp.templatesMap = make(map[uint32]map[uint16][]*entities.InfoElement)
-	cp.mutex = sync.RWMutex{}
-	address, err := net.ResolveTCPAddr("tcp", "0.0.0.0:4736")
-	if err != nil {
-		t.Error(err)
-	}
-	cp.address = address
-	cp.messageChan = make(chan *entities.Message)
-	go func() { // remove the message from the message channel
-		for range cp.GetMsgChan() {
-		}
-	}()
+	cp := newTestCollectingProcess(t, "tcp", "0.0.0.0:4736")
+	address := cp.address
 	message, err := cp.decodePacket(bytes.NewBuffer(validTemplatePacket), address.String())
 	if err != nil {
 		t.Fatalf("Got error in decoding template record: %v", err)
 	}
 	assert.Equal(t, uint16(10), message.GetVersion(), "Flow record version should be 10.")
 	assert.Equal(t, uint32(1), message.GetObsDomainID(), "Flow record obsDomainID should be 1.")
-	assert.NotNil(t, cp.templatesMap[message.GetObsDomainID()], "Template should be stored in template map")
+	_, err = cp.getTemplate(templateScope{address.String(), message.GetObsDomainID()}, 256)
+	assert.Nil(t, err, "Template should be stored in template map")
 
-	templateSet := message.GetSet()
+	templateSet := message.GetSets()[0]
 	assert.NotNil(t, templateSet, "Template record should be stored in message flowset")
 	sourceIPv4Address, exist := templateSet.GetRecords()[0].GetInfoElementWithValue("sourceIPv4Address")
 	assert.Equal(t, true, exist)
 	assert.Equal(t, uint32(0), sourceIPv4Address.Element.EnterpriseId, "Template record is not stored correctly.")
 	// Invalid version
-	templateRecord := []byte{0, 9, 0, 40, 95, 40, 211, 236, 0, 0, 0, 0, 0, 0, 0, 1, 0, 2, 0, 24, 1, 0, 0, 3, 0, 8, 0, 4, 0, 12, 0, 4, 128, 105, 255, 255, 0, 0, 218, 21}
+	templateRecord := []byte{0, 8, 0, 40, 95, 40, 211, 236, 0, 0, 0, 0, 0, 0, 0, 1, 0, 2, 0, 24, 1, 0, 0, 3, 0, 8, 0, 4, 0, 12, 0, 4, 128, 105, 255, 255, 0, 0, 218, 21}
 	_, err = cp.decodePacket(bytes.NewBuffer(templateRecord), address.String())
 	assert.NotNil(t, err, "Error should be logged for invalid version")
 	// Malformed record
 	templateRecord = []byte{0, 10, 0, 40, 95, 40, 211, 236, 0, 0, 0, 0, 0, 0, 0, 1, 0, 2, 0, 24, 1, 0, 0, 3, 0, 8, 0, 4, 0, 12, 0, 4, 128, 105, 255, 255, 0, 0}
-	cp.templatesMap = make(map[uint32]map[uint16][]*entities.InfoElement)
+	cp.templatesMap = make(map[templateScope]map[uint16]*template)
 	_, err = cp.decodePacket(bytes.NewBuffer(templateRecord), address.String())
 	assert.NotNil(t, err, "Error should be logged for malformed template record")
-	if _, exist := cp.templatesMap[uint32(1)]; exist {
-		t.Fatal("Template should not be stored for malformed template record")
-	}
+	assert.Empty(t, cp.ListTemplates(), "Template should not be stored for malformed template record")
 }
 
 func TestCollectingProcess_DecodeDataRecord(t *testing.T) {
-	cp := CollectingProcess{}
-	cp.templatesMap = make(map[uint32]map[uint16][]*entities.InfoElement)
-	cp.mutex = sync.RWMutex{}
-	address, err := net.ResolveTCPAddr("tcp", "0.0.0.0:4737")
-	if err != nil {
-		t.Error(err)
-	}
-	cp.address = address
-	cp.messageChan = make(chan *entities.Message)
-	go func() { // remove the message from the message channel
-		for range cp.GetMsgChan() {
-		}
-	}()
+	cp := newTestCollectingProcess(t, "tcp", "0.0.0.0:4737")
+	address := cp.address
 	// Decode without template
-	_, err = cp.decodePacket(bytes.NewBuffer(validDataPacket), address.String())
+	_, err := cp.decodePacket(bytes.NewBuffer(validDataPacket), address.String())
 	assert.NotNil(t, err, "Error should be logged if corresponding template does not exist.")
 	// Decode with template
-	cp.addTemplate(uint32(1), uint16(256), elementsWithValue)
+	cp.addTemplate(templateScope{address.String(), 1}, uint16(256), elementsWithValue, 0)
 	message, err := cp.decodePacket(bytes.NewBuffer(validDataPacket), address.String())
 	assert.Nil(t, err, "Error should not be logged if corresponding template exists.")
 	assert.Equal(t, uint16(10), message.GetVersion(), "Flow record version should be 10.")
 	assert.Equal(t, uint32(1), message.GetObsDomainID(), "Flow record obsDomainID should be 1.")
 
-	set := message.GetSet()
+	set := message.GetSets()[0]
 	assert.NotNil(t, set, "Data set should be stored in message set")
 	ipAddress := net.IP([]byte{1, 2, 3, 4})
 	sourceIPv4Address, exist := set.GetRecords()[0].GetInfoElementWithValue("sourceIPv4Address")
@@ -489,6 +476,565 @@ func TestCollectingProcess_DecodeDataRecord(t *testing.T) {
 	assert.NotNil(t, err, "Error should be logged for malformed data record")
 }
 
+func TestCollectingProcess_DecodeSubTemplateList(t *testing.T) {
+	cp := newTestCollectingProcess(t, "tcp", "0.0.0.0:4749")
+	address := cp.address
+	// Template 257 with sourceIPv4Address and sourceTransportPort, and template
+	// 256 with subTemplateList, followed by a data record of template 256 with a
+	// list of two records of template 257.
+	packet := []byte{0, 10, 0, 60, 95, 154, 107, 127, 0, 0, 0, 1, 0, 0, 0, 1,
+		0, 2, 0, 24, 1, 1, 0, 2, 0, 8, 0, 4, 0, 7, 0, 2, 1, 0, 0, 1, 1, 36, 255, 255,
+		1, 0, 0, 20, 15, 3, 1, 1, 10, 0, 0, 1, 0, 80, 10, 0, 0, 2, 1, 187}
+	message, err := cp.decodePacket(bytes.NewBuffer(packet), address.String())
+	if err != nil {
+		t.Fatalf("Got error in decoding subTemplateList: %v", err)
+	}
+	ie, exist := message.GetSets()[1].GetRecords()[0].GetInfoElementWithValue("subTemplateList")
+	assert.True(t, exist)
+	list, ok := ie.Value.(*entities.SubTemplateListValue)
+	assert.True(t, ok, "subTemplateList should be decoded to SubTemplateListValue")
+	assert.Equal(t, entities.AllOf, list.Semantic)
+	assert.Equal(t, uint16(257), list.TemplateID)
+	assert.Equal(t, 2, len(list.Records))
+	port, exist := list.Records[1].GetInfoElementWithValue("sourceTransportPort")
+	assert.True(t, exist)
+	assert.Equal(t, uint16(443), port.Value)
+	// Template of the list does not exist in the session
+	packet = []byte{0, 10, 0, 24, 95, 154, 107, 127, 0, 0, 0, 2, 0, 0, 0, 1, 1, 0, 0, 8, 3, 3, 1, 2}
+	_, err = cp.decodePacket(bytes.NewBuffer(packet), address.String())
+	assert.NotNil(t, err, "Error should be logged if template of the list does not exist")
+}
+
+func TestCollectingProcess_DecodeReducedSizeEncoding(t *testing.T) {
+	cp := newTestCollectingProcess(t, "tcp", "0.0.0.0:4750")
+	address := cp.address
+	// Template 256 with octetDeltaCount in 4 bytes and sourceTransportPort,
+	// followed by a data record of template 256.
+	packet := []byte{0, 10, 0, 42, 95, 154, 107, 127, 0, 0, 0, 1, 0, 0, 0, 1,
+		0, 2, 0, 16, 1, 0, 0, 2, 0, 1, 0, 4, 0, 7, 0, 2,
+		1, 0, 0, 10, 0, 1, 134, 160, 1, 187}
+	message, err := cp.decodePacket(bytes.NewBuffer(packet), address.String())
+	if err != nil {
+		t.Fatalf("Got error in decoding reduced-size encoding: %v", err)
+	}
+	record := message.GetSets()[1].GetRecords()[0]
+	octetDeltaCount, exist := record.GetInfoElementWithValue("octetDeltaCount")
+	assert.True(t, exist)
+	assert.Equal(t, uint16(4), octetDeltaCount.Element.Len)
+	assert.Equal(t, uint64(100000), octetDeltaCount.Value, "octetDeltaCount should be decoded to its data type.")
+	port, exist := record.GetInfoElementWithValue("sourceTransportPort")
+	assert.True(t, exist)
+	assert.Equal(t, uint16(443), port.Value)
+	// Reduced-size encoding is not allowed for sourceIPv4Address
+	packet = []byte{0, 10, 0, 28, 95, 154, 107, 127, 0, 0, 0, 2, 0, 0, 0, 1, 0, 2, 0, 12, 1, 1, 0, 1, 0, 8, 0, 2}
+	_, err = cp.decodePacket(bytes.NewBuffer(packet), address.String())
+	assert.NotNil(t, err, "Error should be logged for invalid field length")
+	// Template 258 with interfaceName of length 0
+	packet = []byte{0, 10, 0, 28, 95, 154, 107, 127, 0, 0, 0, 3, 0, 0, 0, 1, 0, 2, 0, 12, 1, 2, 0, 1, 0, 82, 0, 0}
+	_, err = cp.decodePacket(bytes.NewBuffer(packet), address.String())
+	assert.NotNil(t, err, "Error should be logged for field of length 0")
+	_, err = cp.getTemplate(templateScope{address.String(), 1}, 258)
+	assert.NotNil(t, err, "Template with field of length 0 should not be stored")
+	// Template 258 with unknown element 1 of enterprise 12345 of length 0
+	packet = []byte{0, 10, 0, 32, 95, 154, 107, 127, 0, 0, 0, 3, 0, 0, 0, 1, 0, 2, 0, 16, 1, 2, 0, 1, 128, 1, 0, 0, 0, 0, 48, 57}
+	_, err = cp.decodePacket(bytes.NewBuffer(packet), address.String())
+	assert.NotNil(t, err, "Error should be logged for unknown field of length 0")
+}
+
+func TestCollectingProcess_DecodeUnknownInfoElements(t *testing.T) {
+	cp := newTestCollectingProcess(t, "tcp", "0.0.0.0:4751")
+	address := cp.address
+	// Template 256 with element 1 of enterprise 12345 in 4 bytes, element 2 of
+	// enterprise 12345 with variable length and sourceTransportPort, followed by
+	// a data record of template 256.
+	packet := []byte{0, 10, 0, 62, 95, 154, 107, 127, 0, 0, 0, 1, 0, 0, 0, 1,
+		0, 2, 0, 28, 1, 0, 0, 3, 128, 1, 0, 4, 0, 0, 48, 57, 128, 2, 255, 255, 0, 0, 48, 57, 0, 7, 0, 2,
+		1, 0, 0, 18, 222, 173, 190, 239, 3, 1, 2, 3, 1, 187, 0, 0, 0, 0}
+	message, err := cp.decodePacket(bytes.NewBuffer(packet), address.String())
+	if err != nil {
+		t.Fatalf("Got error in decoding unknown information elements: %v", err)
+	}
+	record := message.GetSets()[1].GetRecords()[0]
+	unknownFixed, exist := record.GetInfoElementWithValue("enterprise_12345_ie_1")
+	assert.True(t, exist, "Unknown element should be decoded with generated name.")
+	assert.Equal(t, entities.OctetArray, unknownFixed.Element.DataType)
+	assert.Equal(t, uint16(4), unknownFixed.Element.Len)
+	assert.Equal(t, []byte{222, 173, 190, 239}, unknownFixed.Value)
+	unknownVariable, exist := record.GetInfoElementWithValue("enterprise_12345_ie_2")
+	assert.True(t, exist)
+	assert.Equal(t, entities.VariableLength, unknownVariable.Element.Len)
+	assert.Equal(t, []byte{1, 2, 3}, unknownVariable.Value)
+	port, exist := record.GetInfoElementWithValue("sourceTransportPort")
+	assert.True(t, exist)
+	assert.Equal(t, uint16(443), port.Value, "Elements after unknown elements should be decoded.")
+}
+
+func TestCollectingProcess_DecodeWithRegistry(t *testing.T) {
+	vendorRegistry := registry.New()
+	vendorRegistry.LoadRegistry()
+	err := vendorRegistry.Register(entities.NewInfoElement("vendorCounter", 1, entities.Unsigned32, 12345, 4))
+	assert.Nil(t, err)
+	address, err := net.ResolveTCPAddr("tcp", "0.0.0.0:4752")
+	if err != nil {
+		t.Error(err)
+	}
+	cp, err := InitCollectingProcess(CollectorInput{Address: address, MaxBufferSize: 1024, Registry: vendorRegistry})
+	if err != nil {
+		t.Fatalf("Got error in initializing collecting process: %v", err)
+	}
+	go func() { // remove the message from the message channel
+		for range cp.GetMsgChan() {
+		}
+	}()
+	// Template 256 with element 1 of enterprise 12345 and sourceTransportPort,
+	// followed by a data record of template 256.
+	packet := []byte{0, 10, 0, 48, 95, 154, 107, 127, 0, 0, 0, 1, 0, 0, 0, 1,
+		0, 2, 0, 20, 1, 0, 0, 2, 128, 1, 0, 4, 0, 0, 48, 57, 0, 7, 0, 2,
+		1, 0, 0, 12, 0, 0, 0, 42, 1, 187, 0, 0}
+	message, err := cp.decodePacket(bytes.NewBuffer(packet), address.String())
+	if err != nil {
+		t.Fatalf("Got error in decoding message: %v", err)
+	}
+	ie, exist := message.GetSets()[1].GetRecords()[0].GetInfoElementWithValue("vendorCounter")
+	assert.True(t, exist, "Element should be looked up in the registry of the collecting process.")
+	assert.Equal(t, uint32(42), ie.Value)
+	// Element is not in the default registry
+	_, err = registry.GetInfoElementFromID(1, 12345)
+	assert.NotNil(t, err)
+}
+
+func TestCollectingProcess_DecodeMultipleSets(t *testing.T) {
+	cp := newTestCollectingProcess(t, "tcp", "0.0.0.0:4741")
+	address := cp.address
+	// Message with one template set and one data set that has 3 bytes of padding.
+	multiSetPacket := []byte{0, 10, 0, 60, 95, 154, 107, 127, 0, 0, 0, 0, 0, 0, 0, 1, 0, 2, 0, 24, 1, 0, 0, 3, 0, 8, 0, 4, 0, 12, 0, 4, 128, 101, 255, 255, 0, 0, 220, 186, 1, 0, 0, 20, 1, 2, 3, 4, 5, 6, 7, 8, 4, 112, 111, 100, 49, 0, 0, 0}
+	message, err := cp.decodePacket(bytes.NewBuffer(multiSetPacket), address.String())
+	if err != nil {
+		t.Fatalf("Got error in decoding message with multiple sets: %v", err)
+	}
+	sets := message.GetSets()
+	assert.Equal(t, 2, len(sets), "Message should contain two sets.")
+	assert.Equal(t, entities.Template, sets[0].GetSetType())
+	assert.Equal(t, entities.Data, sets[1].GetSetType())
+	assert.Equal(t, uint32(1), sets[1].GetNumberOfRecords(), "Set padding should not be decoded as a data record.")
+	sourcePodName, exist := sets[1].GetRecords()[0].GetInfoElementWithValue("sourcePodName")
+	assert.True(t, exist)
+	assert.Equal(t, "pod1", sourcePodName.Value)
+	// Set length exceeding the message
+	malformedPacket := []byte{0, 10, 0, 40, 95, 154, 107, 127, 0, 0, 0, 0, 0, 0, 0, 1, 0, 2, 0, 48, 1, 0, 0, 3, 0, 8, 0, 4, 0, 12, 0, 4, 128, 101, 255, 255, 0, 0, 220, 186}
+	_, err = cp.decodePacket(bytes.NewBuffer(malformedPacket), address.String())
+	assert.NotNil(t, err, "Error should be logged for set length exceeding the message length")
+}
+
+func TestCollectingProcess_DecodeOptionsTemplateAndData(t *testing.T) {
+	cp := newTestCollectingProcess(t, "tcp", "0.0.0.0:4742")
+	address := cp.address
+	// Options template 257 with scope field exportingProcessId and field
+	// exportedMessageTotalCount, followed by one options data record.
+	optionsPacket := []byte{0, 10, 0, 50, 95, 154, 107, 127, 0, 0, 0, 0, 0, 0, 0, 1, 0, 3, 0, 18, 1, 1, 0, 2, 0, 1, 0, 144, 0, 4, 0, 41, 0, 8, 1, 1, 0, 16, 0, 0, 0, 7, 0, 0, 0, 0, 0, 0, 0, 100}
+	message, err := cp.decodePacket(bytes.NewBuffer(optionsPacket), address.String())
+	if err != nil {
+		t.Fatalf("Got error in decoding options template and data: %v", err)
+	}
+	sets := message.GetSets()
+	assert.Equal(t, 2, len(sets), "Message should contain two sets.")
+	assert.Equal(t, entities.OptionsTemplate, sets[0].GetSetType())
+	assert.Equal(t, uint16(1), sets[0].GetRecords()[0].GetScopeFieldCount())
+	template, err := cp.getTemplate(templateScope{address.String(), 1}, uint16(257))
+	assert.Nil(t, err, "Options template should be stored in template map")
+	assert.Equal(t, uint16(1), template.scopeFieldCount)
+
+	dataRecord := sets[1].GetRecords()[0]
+	assert.Equal(t, uint16(1), dataRecord.GetScopeFieldCount(), "Data record of options template should carry scope field count.")
+	exportingProcessId, exist := dataRecord.GetInfoElementWithValue("exportingProcessId")
+	assert.True(t, exist)
+	assert.Equal(t, uint32(7), exportingProcessId.Value)
+	exportedMessageTotalCount, exist := dataRecord.GetInfoElementWithValue("exportedMessageTotalCount")
+	assert.True(t, exist)
+	assert.Equal(t, uint64(100), exportedMessageTotalCount.Value)
+	// Scope field count of zero is invalid
+	invalidPacket := []byte{0, 10, 0, 34, 95, 154, 107, 127, 0, 0, 0, 0, 0, 0, 0, 1, 0, 3, 0, 18, 1, 2, 0, 2, 0, 0, 0, 144, 0, 4, 0, 41, 0, 8}
+	_, err = cp.decodePacket(bytes.NewBuffer(invalidPacket), address.String())
+	assert.NotNil(t, err, "Error should be logged for options template with zero scope field count")
+}
+
+func TestCollectingProcess_DecodeNetflowV9(t *testing.T) {
+	cp := newTestCollectingProcess(t, "udp", "0.0.0.0:4746")
+	address := cp.address
+	// Template 256 with IPV4_SRC_ADDR, IPV4_DST_ADDR, a 4-byte IN_BYTES and
+	// L4_SRC_PORT, a data record of the template, and options template 257
+	// with Interface scope and SAMPLING_INTERVAL. FlowSets are padded to 4
+	// bytes.
+	netflowV9Packet := []byte{0, 9, 0, 3, 0, 0, 0, 100, 95, 154, 107, 127, 0, 0, 0, 5, 0, 0, 0, 2,
+		0, 0, 0, 24, 1, 0, 0, 4, 0, 8, 0, 4, 0, 12, 0, 4, 0, 1, 0, 4, 0, 7, 0, 2,
+		1, 0, 0, 20, 10, 0, 0, 1, 10, 0, 0, 2, 0, 0, 3, 232, 0, 80, 0, 0,
+		0, 1, 0, 20, 1, 1, 0, 4, 0, 4, 0, 2, 0, 4, 0, 34, 0, 4, 0, 0}
+	message, err := cp.decodePacket(bytes.NewBuffer(netflowV9Packet), address.String())
+	if err != nil {
+		t.Fatalf("Got error in decoding NetFlow v9 packet: %v", err)
+	}
+	assert.Equal(t, uint16(9), message.GetVersion())
+	assert.Equal(t, uint16(len(netflowV9Packet)), message.GetMessageLen())
+	assert.Equal(t, uint32(1603955583), message.GetExportTime())
+	assert.Equal(t, uint32(5), message.GetSequenceNum())
+	assert.Equal(t, uint32(2), message.GetObsDomainID(), "Source ID should be decoded as obsDomainID.")
+	sets := message.GetSets()
+	assert.Equal(t, 3, len(sets), "Message should contain three sets.")
+	assert.Equal(t, entities.Template, sets[0].GetSetType())
+	assert.Equal(t, entities.Data, sets[1].GetSetType())
+	assert.Equal(t, entities.OptionsTemplate, sets[2].GetSetType())
+
+	dataRecord := sets[1].GetRecords()[0]
+	sourceIPv4Address, exist := dataRecord.GetInfoElementWithValue("sourceIPv4Address")
+	assert.True(t, exist)
+	assert.Equal(t, net.IP([]byte{10, 0, 0, 1}), sourceIPv4Address.Value)
+	octetDeltaCount, exist := dataRecord.GetInfoElementWithValue("octetDeltaCount")
+	assert.True(t, exist)
+	assert.Equal(t, uint16(4), octetDeltaCount.Element.Len, "Element should carry the field length of the template.")
+	assert.Equal(t, uint64(1000), octetDeltaCount.Value, "Reduced-size value should be expanded to the data type.")
+	sourceTransportPort, exist := dataRecord.GetInfoElementWithValue("sourceTransportPort")
+	assert.True(t, exist)
+	assert.Equal(t, uint16(80), sourceTransportPort.Value)
+
+	template, err := cp.getTemplate(templateScope{address.String(), 2}, uint16(257))
+	assert.Nil(t, err, "Options template should be stored in template map")
+	assert.Equal(t, uint16(1), template.scopeFieldCount)
+	optionsDataPacket := []byte{0, 9, 0, 1, 0, 0, 0, 200, 95, 154, 107, 128, 0, 0, 0, 6, 0, 0, 0, 2,
+		1, 1, 0, 12, 0, 0, 0, 3, 0, 0, 0, 100}
+	message, err = cp.decodePacket(bytes.NewBuffer(optionsDataPacket), address.String())
+	if err != nil {
+		t.Fatalf("Got error in decoding NetFlow v9 options data: %v", err)
+	}
+	optionsDataRecord := message.GetSets()[0].GetRecords()[0]
+	assert.Equal(t, uint16(1), optionsDataRecord.GetScopeFieldCount())
+	ingressInterface, exist := optionsDataRecord.GetInfoElementWithValue("ingressInterface")
+	assert.True(t, exist)
+	assert.Equal(t, uint32(3), ingressInterface.Value)
+	samplingInterval, exist := optionsDataRecord.GetInfoElementWithValue("samplingInterval")
+	assert.True(t, exist)
+	assert.Equal(t, uint32(100), samplingInterval.Value)
+	// FlowSet with reserved ID 128 is skipped.
+	reservedFlowSetPacket := []byte{0, 9, 0, 2, 0, 0, 0, 200, 95, 154, 107, 128, 0, 0, 0, 6, 0, 0, 0, 2,
+		0, 128, 0, 8, 1, 2, 3, 4, 1, 1, 0, 12, 0, 0, 0, 4, 0, 0, 0, 200}
+	message, err = cp.decodePacket(bytes.NewBuffer(reservedFlowSetPacket), address.String())
+	if err != nil {
+		t.Fatalf("Got error in decoding NetFlow v9 packet with reserved FlowSet ID: %v", err)
+	}
+	if assert.Equal(t, 1, len(message.GetSets()), "FlowSet with reserved ID should be skipped.") {
+		assert.Equal(t, uint16(257), message.GetSets()[0].GetRecords()[0].GetTemplateID())
+	}
+
+	// Field length longer than the data type is invalid
+	invalidPacket := []byte{0, 9, 0, 1, 0, 0, 0, 100, 95, 154, 107, 127, 0, 0, 0, 7, 0, 0, 0, 2,
+		0, 0, 0, 12, 1, 2, 0, 1, 0, 7, 0, 4}
+	_, err = cp.decodePacket(bytes.NewBuffer(invalidPacket), address.String())
+	assert.NotNil(t, err, "Error should be logged for invalid field length")
+	// Template 258 with IPV4_SRC_ADDR, vendor specific field type 40000 and
+	// field type 95, which is not listed in RFC3954, and a data record of the
+	// template.
+	unknownFieldPacket := []byte{0, 9, 0, 2, 0, 0, 0, 100, 95, 154, 107, 129, 0, 0, 0, 8, 0, 0, 0, 2,
+		0, 0, 0, 20, 1, 2, 0, 3, 0, 8, 0, 4, 156, 64, 0, 2, 0, 95, 0, 4,
+		1, 2, 0, 16, 10, 0, 0, 1, 171, 205, 1, 2, 3, 4, 0, 0}
+	message, err = cp.decodePacket(bytes.NewBuffer(unknownFieldPacket), address.String())
+	if err != nil {
+		t.Fatalf("Got error in decoding NetFlow v9 packet with unknown field type: %v", err)
+	}
+	dataRecord = message.GetSets()[1].GetRecords()[0]
+	unknownElement, exist := dataRecord.GetInfoElementWithValue("enterprise_0_ie_40000")
+	assert.True(t, exist, "Unknown field type should be decoded as octetArray.")
+	assert.Equal(t, entities.OctetArray, unknownElement.Element.DataType)
+	assert.Equal(t, []byte{171, 205}, unknownElement.Value)
+	applicationID, exist := dataRecord.GetInfoElementWithValue("applicationId")
+	assert.True(t, exist, "Field type should be looked up in the IANA registry.")
+	assert.Equal(t, []byte{1, 2, 3, 4}, applicationID.Value)
+	// NetFlow v9 messages cannot be delimited over TCP
+	_, err = getMessageLength(bytes.NewBuffer(netflowV9Packet))
+	assert.NotNil(t, err, "Error should be logged for NetFlow v9 message over TCP")
+}
+
+func TestCollectingProcess_DecodeNetflowV5(t *testing.T) {
+	cp := newTestCollectingProcess(t, "udp", "0.0.0.0:4747")
+	address := cp.address
+	// One flow record with system uptime of 10s, engine type 1, engine ID 2 and
+	// random sampling with interval 100. The flow starts at uptime 4s and ends
+	// at uptime 9s.
+	netflowV5Packet := []byte{0, 5, 0, 1, 0, 0, 39, 16, 95, 154, 107, 127, 0, 0, 0, 0, 0, 0, 0, 42, 1, 2, 128, 100,
+		10, 0, 0, 1, 10, 0, 0, 2, 10, 0, 0, 254, 0, 3, 0, 4, 0, 0, 0, 10, 0, 0, 3, 232,
+		0, 0, 15, 160, 0, 0, 35, 40, 195, 80, 0, 80, 0, 24, 6, 0, 253, 232, 253, 233, 24, 16, 0, 0}
+	message, err := cp.decodePacket(bytes.NewBuffer(netflowV5Packet), address.String())
+	if err != nil {
+		t.Fatalf("Got error in decoding NetFlow v5 packet: %v", err)
+	}
+	assert.Equal(t, uint16(5), message.GetVersion())
+	assert.Equal(t, uint32(1603955583), message.GetExportTime())
+	assert.Equal(t, uint32(42), message.GetSequenceNum())
+	assert.Equal(t, uint32(0x0102), message.GetObsDomainID(), "Engine type and engine ID should be decoded as obsDomainID.")
+	assert.Equal(t, 1, len(message.GetSets()))
+	dataSet := message.GetSets()[0]
+	assert.Equal(t, entities.Data, dataSet.GetSetType())
+	assert.Equal(t, 1, len(dataSet.GetRecords()))
+	record := dataSet.GetRecords()[0]
+	assert.Equal(t, NetflowV5TemplateID, record.GetTemplateID())
+
+	expectedValues := map[string]interface{}{
+		"sourceIPv4Address":        net.IP([]byte{10, 0, 0, 1}),
+		"destinationIPv4Address":   net.IP([]byte{10, 0, 0, 2}),
+		"ingressInterface":         uint32(3),
+		"packetDeltaCount":         uint64(10),
+		"octetDeltaCount":          uint64(1000),
+		"flowStartSysUpTime":       uint32(4000),
+		"sourceTransportPort":      uint16(50000),
+		"destinationTransportPort": uint16(80),
+		"tcpControlBits":           uint16(24),
+		"protocolIdentifier":       uint8(6),
+		"bgpSourceAsNumber":        uint32(65000),
+		"sourceIPv4PrefixLength":   uint8(24),
+		"flowStartSeconds":         uint32(1603955577),
+		"flowEndSeconds":           uint32(1603955582),
+		"engineType":               uint8(1),
+		"engineId":                 uint8(2),
+		"samplingAlgorithm":        uint8(2),
+		"samplingInterval":         uint32(100),
+	}
+	for name, expectedValue := range expectedValues {
+		ie, exist := record.GetInfoElementWithValue(name)
+		assert.True(t, exist, "%s should exist in NetFlow v5 record", name)
+		assert.Equal(t, expectedValue, ie.Value, "%s is not decoded correctly", name)
+	}
+	// Packet shorter than the record count in the header
+	_, err = cp.decodePacket(bytes.NewBuffer(netflowV5Packet[:60]), address.String())
+	assert.NotNil(t, err, "Error should be logged for truncated NetFlow v5 packet")
+}
+
+func TestCollectingProcess_DecodeSFlow(t *testing.T) {
+	cp := newTestCollectingProcess(t, "udp", "0.0.0.0:4748")
+	address := cp.address
+	// withLength prefixes the data format and length to the given data.
+	withLength := func(format uint32, data []byte) []byte {
+		buf := make([]byte, 8)
+		binary.BigEndian.PutUint32(buf[0:4], format)
+		binary.BigEndian.PutUint32(buf[4:8], uint32(len(data)))
+		return append(buf, data...)
+	}
+	words := func(values ...uint32) []byte {
+		buf := make([]byte, 4*len(values))
+		for i, v := range values {
+			binary.BigEndian.PutUint32(buf[4*i:], v)
+		}
+		return buf
+	}
+	// Ethernet, IPv4 and TCP headers of a packet from 10.0.0.1:50000 to
+	// 10.0.0.2:80 with IP total length 46, padded to 4 bytes.
+	sampledHeader := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 8, 0,
+		0x45, 0, 0, 46, 0, 0, 0x40, 0, 64, 6, 0, 0, 10, 0, 0, 1, 10, 0, 0, 2,
+		195, 80, 0, 80, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	rawPacketHeader := append(words(1, 64, 4, 54), sampledHeader...)
+	// Flow sample with sampling rate 100, input interface 5 and output
+	// interface 6.
+	flowSample := append(words(1, 5, 100, 1000, 0, 5, 6, 1), withLength(1, rawPacketHeader)...)
+	genericInterfaceCounters := append(words(5, 6), 0, 0, 0, 0, 0x3b, 0x9a, 0xca, 0)
+	genericInterfaceCounters = append(genericInterfaceCounters, words(1, 3, 0, 5000, 40, 5, 5, 0, 0, 0, 0, 7000, 60, 0, 0, 0, 0, 0)...)
+	counterSample := append(words(1, 5, 1), withLength(1, genericInterfaceCounters)...)
+	datagram := words(5, 1, 0x0a0000fe, 3, 9, 1000, 2)
+	datagram = append(datagram, withLength(1, flowSample)...)
+	datagram = append(datagram, withLength(2, counterSample)...)
+
+	message, err := cp.decodePacket(bytes.NewBuffer(datagram), address.String())
+	if err != nil {
+		t.Fatalf("Got error in decoding sFlow datagram: %v", err)
+	}
+	assert.Equal(t, uint16(5), message.GetVersion())
+	assert.Equal(t, uint32(9), message.GetSequenceNum())
+	assert.Equal(t, uint32(3), message.GetObsDomainID(), "Sub-agent ID should be decoded as obsDomainID.")
+	sets := message.GetSets()
+	assert.Equal(t, 3, len(sets), "Message should contain a data set for flows and one for each direction of counters.")
+
+	flowRecord := sets[0].GetRecords()[0]
+	assert.Equal(t, SFlowIPv4FlowTemplateID, flowRecord.GetTemplateID())
+	expectedFlowValues := map[string]interface{}{
+		"ingressInterface":         uint32(5),
+		"egressInterface":          uint32(6),
+		"sourceIPv4Address":        net.IP([]byte{10, 0, 0, 1}),
+		"destinationIPv4Address":   net.IP([]byte{10, 0, 0, 2}),
+		"protocolIdentifier":       uint8(6),
+		"sourceTransportPort":      uint16(50000),
+		"destinationTransportPort": uint16(80),
+		"octetDeltaCount":          uint64(46),
+		"packetDeltaCount":         uint64(1),
+		"samplingInterval":         uint32(100),
+	}
+	for name, expectedValue := range expectedFlowValues {
+		ie, exist := flowRecord.GetInfoElementWithValue(name)
+		assert.True(t, exist, "%s should exist in sFlow flow record", name)
+		assert.Equal(t, expectedValue, ie.Value, "%s is not decoded correctly", name)
+	}
+
+	for i, data := range []struct {
+		templateID     uint16
+		expectedValues map[string]interface{}
+	}{
+		{SFlowIngressCountersTemplateID, map[string]interface{}{
+			"ingressInterface": uint32(5),
+			"octetTotalCount":  uint64(5000),
+			"packetTotalCount": uint64(50),
+		}},
+		{SFlowEgressCountersTemplateID, map[string]interface{}{
+			"egressInterface":  uint32(5),
+			"octetTotalCount":  uint64(7000),
+			"packetTotalCount": uint64(60),
+		}},
+	} {
+		counterRecord := sets[i+1].GetRecords()[0]
+		assert.Equal(t, data.templateID, counterRecord.GetTemplateID())
+		assert.Equal(t, uint16(1), counterRecord.GetScopeFieldCount(), "Counters should be options data records scoped by interface.")
+		assert.Equal(t, len(data.expectedValues), len(counterRecord.GetOrderedElementList()))
+		for name, expectedValue := range data.expectedValues {
+			ie, exist := counterRecord.GetInfoElementWithValue(name)
+			assert.True(t, exist, "%s should exist in sFlow counter record", name)
+			assert.Equal(t, expectedValue, ie.Value, "%s is not decoded correctly", name)
+		}
+	}
+	// Sample length longer than the datagram
+	_, err = cp.decodePacket(bytes.NewBuffer(datagram[:len(datagram)-4]), address.String())
+	assert.NotNil(t, err, "Error should be logged for truncated sFlow datagram")
+
+	// IPv6 and UDP headers of a packet from ::ffff:10.0.0.1:50000 to
+	// ::ffff:10.0.0.2:53 with payload length 8. IPv4-mapped IPv6 addresses
+	// are kept on the IPv6 template.
+	ipv6Header := []byte{0x60, 0, 0, 0, 0, 8, 17, 64,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 10, 0, 0, 1,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 10, 0, 0, 2,
+		195, 80, 0, 53, 0, 8, 0, 0}
+	rawPacketHeader = append(words(12, 48, 0, 48), ipv6Header...)
+	flowSample = append(words(2, 5, 100, 1000, 0, 5, 6, 1), withLength(1, rawPacketHeader)...)
+	datagram = words(5, 1, 0x0a0000fe, 3, 10, 1000, 1)
+	datagram = append(datagram, withLength(1, flowSample)...)
+	message, err = cp.decodePacket(bytes.NewBuffer(datagram), address.String())
+	if err != nil {
+		t.Fatalf("Got error in decoding sFlow datagram: %v", err)
+	}
+	flowRecord = message.GetSets()[0].GetRecords()[0]
+	assert.Equal(t, SFlowIPv6FlowTemplateID, flowRecord.GetTemplateID())
+	expectedFlowValues = map[string]interface{}{
+		"sourceIPv6Address":        net.ParseIP("::ffff:10.0.0.1"),
+		"destinationIPv6Address":   net.ParseIP("::ffff:10.0.0.2"),
+		"protocolIdentifier":       uint8(17),
+		"sourceTransportPort":      uint16(50000),
+		"destinationTransportPort": uint16(53),
+		"octetDeltaCount":          uint64(48),
+	}
+	for name, expectedValue := range expectedFlowValues {
+		ie, exist := flowRecord.GetInfoElementWithValue(name)
+		assert.True(t, exist, "%s should exist in sFlow flow record", name)
+		assert.Equal(t, expectedValue, ie.Value, "%s is not decoded correctly", name)
+	}
+}
+
+func TestCollectingProcess_TemplateWithdrawalAndRedefinition(t *testing.T) {
+	cp := newTestCollectingProcess(t, "tcp", "0.0.0.0:4743")
+	cp.templateEventChan = make(chan *TemplateEvent, templateEventChanSize)
+	address := cp.address
+	scope := templateScope{address.String(), 1}
+	assertEvent := func(eventType TemplateEventType, templateID uint16) {
+		event := <-cp.GetTemplateEventChan()
+		assert.Equal(t, TemplateEvent{Type: eventType, ExporterAddress: address.String(), ObsDomainID: 1, TemplateID: templateID}, *event)
+	}
+
+	_, err := cp.decodePacket(bytes.NewBuffer(validTemplatePacket), address.String())
+	assert.Nil(t, err)
+	assertEvent(TemplateAdded, 256)
+	// Receiving the same template again is a refresh.
+	_, err = cp.decodePacket(bytes.NewBuffer(validTemplatePacket), address.String())
+	assert.Nil(t, err)
+	assertEvent(TemplateRefreshed, 256)
+	// Template 256 is redefined with two fields.
+	redefinedTemplatePacket := []byte{0, 10, 0, 32, 95, 154, 107, 127, 0, 0, 0, 0, 0, 0, 0, 1, 0, 2, 0, 16, 1, 0, 0, 2, 0, 8, 0, 4, 0, 12, 0, 4}
+	_, err = cp.decodePacket(bytes.NewBuffer(redefinedTemplatePacket), address.String())
+	assert.Nil(t, err)
+	assertEvent(TemplateAdded, 256)
+	template, err := cp.getTemplate(scope, 256)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(template.elements), "Old template definition should be replaced.")
+	assert.Equal(t, uint64(1), cp.GetUnwithdrawnRedefinitionCount(), "Redefinition without withdrawal over TCP should be counted.")
+	// Template 256 is withdrawn.
+	withdrawalPacket := []byte{0, 10, 0, 24, 95, 154, 107, 127, 0, 0, 0, 0, 0, 0, 0, 1, 0, 2, 0, 8, 1, 0, 0, 0}
+	message, err := cp.decodePacket(bytes.NewBuffer(withdrawalPacket), address.String())
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0), message.GetSets()[0].GetNumberOfRecords(), "Withdrawal should not be decoded as a template record.")
+	assertEvent(TemplateWithdrawn, 256)
+	_, err = cp.getTemplate(scope, 256)
+	assert.NotNil(t, err, "Template should be deleted after withdrawal.")
+	// All templates are withdrawn, options templates are kept.
+	cp.addTemplate(scope, 257, elementsWithValue, 0)
+	cp.addTemplate(scope, 258, elementsWithValue, 1)
+	assertEvent(TemplateAdded, 257)
+	assertEvent(TemplateAdded, 258)
+	allWithdrawalPacket := []byte{0, 10, 0, 24, 95, 154, 107, 127, 0, 0, 0, 0, 0, 0, 0, 1, 0, 2, 0, 8, 0, 2, 0, 0}
+	_, err = cp.decodePacket(bytes.NewBuffer(allWithdrawalPacket), address.String())
+	assert.Nil(t, err)
+	assertEvent(TemplateWithdrawn, 257)
+	_, err = cp.getTemplate(scope, 257)
+	assert.NotNil(t, err, "Template should be deleted after all templates withdrawal.")
+	_, err = cp.getTemplate(scope, 258)
+	assert.Nil(t, err, "Options template should not be deleted by all templates withdrawal.")
+	// Options template withdrawal does not carry scope field count.
+	optionsWithdrawalPacket := []byte{0, 10, 0, 24, 95, 154, 107, 127, 0, 0, 0, 0, 0, 0, 0, 1, 0, 3, 0, 8, 1, 2, 0, 0}
+	_, err = cp.decodePacket(bytes.NewBuffer(optionsWithdrawalPacket), address.String())
+	assert.Nil(t, err)
+	assertEvent(TemplateWithdrawn, 258)
+	_, err = cp.getTemplate(scope, 258)
+	assert.NotNil(t, err, "Options template should be deleted after withdrawal.")
+
+	// Withdrawal is ignored over UDP.
+	udpAddress, err := net.ResolveUDPAddr("udp", "0.0.0.0:4743")
+	if err != nil {
+		t.Error(err)
+	}
+	cp.address = udpAddress
+	cp.templateTTL = 1
+	cp.addTemplate(scope, 256, elementsWithValue, 0)
+	assertEvent(TemplateAdded, 256)
+	_, err = cp.decodePacket(bytes.NewBuffer(withdrawalPacket), address.String())
+	assert.Nil(t, err)
+	_, err = cp.getTemplate(scope, 256)
+	assert.Nil(t, err, "Template should not be withdrawn over UDP.")
+	assertEvent(TemplateExpired, 256)
+}
+
+func TestCollectingProcess_TemplatesScopedBySession(t *testing.T) {
+	cp := newTestCollectingProcess(t, "tcp", "0.0.0.0:4744")
+	// Two exporters use the same obsDomainID and template ID with different templates.
+	exporter1 := "10.0.0.1:50000"
+	exporter2 := "10.0.0.2:50000"
+	_, err := cp.decodePacket(bytes.NewBuffer(validTemplatePacket), exporter1)
+	assert.Nil(t, err)
+	otherTemplatePacket := []byte{0, 10, 0, 32, 95, 154, 107, 127, 0, 0, 0, 0, 0, 0, 0, 1, 0, 2, 0, 16, 1, 0, 0, 2, 0, 8, 0, 4, 0, 12, 0, 4}
+	_, err = cp.decodePacket(bytes.NewBuffer(otherTemplatePacket), exporter2)
+	assert.Nil(t, err)
+
+	templates := cp.ListTemplates()
+	if assert.Equal(t, 2, len(templates)) {
+		assert.Equal(t, exporter1, templates[0].ExporterAddress)
+		assert.Equal(t, 3, len(templates[0].Elements))
+		assert.Equal(t, exporter2, templates[1].ExporterAddress)
+		assert.Equal(t, 2, len(templates[1].Elements))
+	}
+	message, err := cp.decodePacket(bytes.NewBuffer(validDataPacket), exporter1)
+	assert.Nil(t, err)
+	sourcePodName, exist := message.GetSets()[0].GetRecords()[0].GetInfoElementWithValue("sourcePodName")
+	assert.True(t, exist)
+	assert.Equal(t, "pod1", sourcePodName.Value)
+	// Data from an exporter without template is not decoded with templates of other sessions.
+	_, err = cp.decodePacket(bytes.NewBuffer(validDataPacket), "10.0.0.3:50000")
+	assert.NotNil(t, err)
+
+	cp.deleteSessionTemplates(exporter1)
+	templates = cp.ListTemplates()
+	if assert.Equal(t, 1, len(templates)) {
+		assert.Equal(t, exporter2, templates[0].ExporterAddress)
+	}
+}
+
 func TestUDPCollectingProcess_TemplateExpire(t *testing.T) {
 	address, err := net.ResolveUDPAddr("udp", "0.0.0.0:4738")
 	if err != nil {
@@ -525,14 +1071,69 @@ func TestUDPCollectingProcess_TemplateExpire(t *testing.T) {
 		}
 	}()
 	<-cp.GetMsgChan()
-	cp.Stop()
-	template, err := cp.getTemplate(1, 256)
-	assert.NotNil(t, template, "Template should be stored in the template map.")
-	assert.Nil(t, err, "Template should be stored in the template map.")
+	assert.Equal(t, 1, len(cp.ListTemplates()), "Template should be stored in the template map.")
+	assert.Equal(t, 1, cp.GetActiveTemplateCount())
 	time.Sleep(2 * time.Second)
-	template, err = cp.getTemplate(1, 256)
-	assert.Nil(t, template, "Template should be deleted after 5 seconds.")
-	assert.NotNil(t, err, "Template should be deleted after 5 seconds.")
+	assert.Empty(t, cp.ListTemplates(), "Template should be deleted after 5 seconds.")
+	assert.Equal(t, 0, cp.GetActiveTemplateCount())
+	assert.Equal(t, uint64(1), cp.GetExpiredTemplateCount())
+	cp.Stop()
+}
+
+func TestCollectingProcess_TemplateRefreshExtendsLifetime(t *testing.T) {
+	cp := newTestCollectingProcess(t, "udp", "0.0.0.0:4745")
+	cp.templateTTL = 1
+	scope := templateScope{cp.address.String(), 1}
+	cp.addTemplate(scope, 256, elementsWithValue, 0)
+	cp.addTemplate(scope, 257, elementsWithValue, 0)
+	// Keep refreshing template 256 until template 257 expires.
+	for i := 0; i < 3; i++ {
+		time.Sleep(500 * time.Millisecond)
+		cp.addTemplate(scope, 256, elementsWithValue, 0)
+	}
+	_, err := cp.getTemplate(scope, 256)
+	assert.Nil(t, err, "Refreshed template should not expire.")
+	_, err = cp.getTemplate(scope, 257)
+	assert.NotNil(t, err, "Template should expire when it is not refreshed.")
+	assert.Equal(t, 1, cp.GetActiveTemplateCount())
+	assert.Equal(t, uint64(1), cp.GetExpiredTemplateCount())
+	// Templates do not expire after the expiry is stopped.
+	cp.stopTemplateExpiry()
+	time.Sleep(1500 * time.Millisecond)
+	_, err = cp.getTemplate(scope, 256)
+	assert.Nil(t, err, "Template should not expire after collecting process is stopped.")
+	// Templates refreshed after the expiry is stopped are not queued.
+	cp.addTemplate(scope, 256, elementsWithValue, 0)
+	assert.Equal(t, 0, len(cp.templateExpiry.entries))
+}
+
+func TestCollectingProcess_TypeInformation(t *testing.T) {
+	cp := newTestCollectingProcess(t, "udp", "0.0.0.0:4753")
+	cp.templateTTL = 1
+	address := cp.address.String()
+	// Options template 256 with informationElementId in 1 byte and
+	// privateEnterpriseNumber in 2 bytes as scope fields, followed by
+	// informationElementDataType, informationElementSemantics and
+	// informationElementName, and a type information record of element 100 of
+	// enterprise 12345 named vendorCount of type unsigned32.
+	packet := []byte{0, 10, 0, 67, 95, 154, 107, 127, 0, 0, 0, 1, 0, 0, 0, 1,
+		0, 3, 0, 30, 1, 0, 0, 5, 0, 2, 1, 47, 0, 1, 1, 90, 0, 2, 1, 83, 0, 1, 1, 88, 0, 1, 1, 85, 255, 255,
+		1, 0, 0, 21, 100, 48, 57, 3, 1, 11, 'v', 'e', 'n', 'd', 'o', 'r', 'C', 'o', 'u', 'n', 't'}
+	_, err := cp.decodePacket(bytes.NewBuffer(packet), address)
+	if err != nil {
+		t.Fatalf("Got error in decoding type information: %v", err)
+	}
+	element, exist := cp.getSessionInfoElement(address, 100, 12345)
+	if assert.True(t, exist, "Element described with reduced-size encoding should be learned.") {
+		assert.Equal(t, "vendorCount", element.Name)
+		assert.Equal(t, entities.Unsigned32, element.DataType)
+		assert.Equal(t, entities.Quantity, element.Semantics)
+	}
+	// Type information expires with the last template of the session.
+	time.Sleep(1500 * time.Millisecond)
+	_, exist = cp.getSessionInfoElement(address, 100, 12345)
+	assert.False(t, exist, "Type information should be deleted when the templates of the session expire.")
+	assert.Empty(t, cp.sessionRegistries)
 }
 
 func TestTLSCollectingProcess(t *testing.T) {
@@ -582,7 +1183,8 @@ func TestTLSCollectingProcess(t *testing.T) {
 	}()
 	<-cp.GetMsgChan()
 	cp.Stop()
-	assert.NotNil(t, cp.templatesMap[1], "TLS Collecting Process should receive and store the received template.")
+	event := <-cp.GetTemplateEventChan()
+	assert.Equal(t, TemplateAdded, event.Type, "TLS Collecting Process should receive and store the received template.")
 }
 
 func TestDTLSCollectingProcess(t *testing.T) {
@@ -625,7 +1227,8 @@ func TestDTLSCollectingProcess(t *testing.T) {
 	}()
 	<-cp.GetMsgChan()
 	cp.Stop()
-	assert.NotNil(t, cp.templatesMap[1], "DTLS Collecting Process should receive and store the received template.")
+	event := <-cp.GetTemplateEventChan()
+	assert.Equal(t, TemplateAdded, event.Type, "DTLS Collecting Process should receive and store the received template.")
 }
 
 func waitForCollectorReady(t *testing.T, address net.Addr) {
@@ -639,3 +1242,32 @@ func waitForCollectorReady(t *testing.T, address net.Addr) {
 		t.Errorf("Cannot establish connection to %s", address.String())
 	}
 }
+
+// newTestCollectingProcess returns a collecting process which is not started,
+// for decoding packets received at the given address. Messages sent to its
+// message channel are discarded until the test finishes.
+func newTestCollectingProcess(t *testing.T, network, address string) *CollectingProcess {
+	cp := &CollectingProcess{}
+	cp.templatesMap = make(map[templateScope]map[uint16]*template)
+	var err error
+	if network == "tcp" {
+		cp.address, err = net.ResolveTCPAddr(network, address)
+	} else {
+		cp.address, err = net.ResolveUDPAddr(network, address)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp.messageChan = make(chan *entities.Message)
+	drained := make(chan struct{})
+	go func() { // remove the message from the message channel
+		defer close(drained)
+		for range cp.GetMsgChan() {
+		}
+	}()
+	t.Cleanup(func() {
+		close(cp.messageChan)
+		<-drained
+	})
+	return cp
+}
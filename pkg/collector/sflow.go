@@ -0,0 +1,430 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"k8s.io/klog"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+	"github.com/vmware/go-ipfix/pkg/registry"
+	"github.com/vmware/go-ipfix/pkg/util"
+)
+
+// sFlow v5 datagram format is defined in https://sflow.org/sflow_version_5.txt.
+// Flow samples are translated into data records with the 5-tuple and counts of
+// the sampled packet header, and counter samples are translated into options
+// data records scoped by interface. Each kind of record is decoded as a record
+// of a virtual template.
+const (
+	// sflowVersion is the version number in the sFlow datagram header.
+	sflowVersion uint32 = 5
+	// Agent address types
+	sflowAddressTypeIPv4 uint32 = 1
+	sflowAddressTypeIPv6 uint32 = 2
+	// Sample formats with enterprise 0
+	sflowFlowSampleFormat            uint32 = 1
+	sflowCounterSampleFormat         uint32 = 2
+	sflowExpandedFlowSampleFormat    uint32 = 3
+	sflowExpandedCounterSampleFormat uint32 = 4
+	// Flow record format of a raw packet header
+	sflowRawPacketHeaderFormat uint32 = 1
+	// Counter record format of generic interface counters
+	sflowGenericInterfaceCountersFormat uint32 = 1
+	// Header protocols of a raw packet header
+	sflowHeaderProtocolEthernet uint32 = 1
+	sflowHeaderProtocolIPv4     uint32 = 11
+	sflowHeaderProtocolIPv6     uint32 = 12
+
+	// The virtual templates of sFlow use the highest template IDs, so that their
+	// records can be told apart from the records of the NetFlow v5 virtual
+	// template and of templates allocated from 256 by exporters.
+
+	// SFlowIPv4FlowTemplateID is the ID of the virtual template used for the
+	// data records of sFlow flow samples of IPv4 packets.
+	SFlowIPv4FlowTemplateID uint16 = 65533
+	// SFlowIPv6FlowTemplateID is the ID of the virtual template used for the
+	// data records of sFlow flow samples of IPv6 packets. IPv4-mapped IPv6
+	// addresses of IPv6 packets are kept as IPv6 addresses of this template.
+	SFlowIPv6FlowTemplateID uint16 = 65534
+	// SFlowIngressCountersTemplateID is the ID of the virtual options template
+	// used for the options data records of the input counters of interfaces in
+	// sFlow counter samples.
+	SFlowIngressCountersTemplateID uint16 = 65535
+	// SFlowEgressCountersTemplateID is the ID of the virtual options template
+	// used for the options data records of the output counters of interfaces in
+	// sFlow counter samples.
+	SFlowEgressCountersTemplateID uint16 = 65532
+)
+
+var (
+	sflowIPv4FlowFields = []string{"ingressInterface", "egressInterface", "sourceIPv4Address", "destinationIPv4Address", "protocolIdentifier",
+		"sourceTransportPort", "destinationTransportPort", "ipClassOfService", "octetDeltaCount", "packetDeltaCount", "samplingInterval"}
+	sflowIPv6FlowFields = []string{"ingressInterface", "egressInterface", "sourceIPv6Address", "destinationIPv6Address", "protocolIdentifier",
+		"sourceTransportPort", "destinationTransportPort", "ipClassOfService", "octetDeltaCount", "packetDeltaCount", "samplingInterval"}
+	// The first field is the scope of interface counters.
+	sflowIngressCountersFields = []string{"ingressInterface", "octetTotalCount", "packetTotalCount"}
+	sflowEgressCountersFields  = []string{"egressInterface", "octetTotalCount", "packetTotalCount"}
+)
+
+// sflowRecord is a record decoded from an sFlow sample.
+type sflowRecord struct {
+	templateID      uint16
+	scopeFieldCount uint16
+	elements        []*entities.InfoElementWithValue
+}
+
+// sflowSampledFlow is the flow information extracted from a sampled packet
+// header.
+type sflowSampledFlow struct {
+	// isIPv6 is true if the sampled packet is an IPv6 packet.
+	isIPv6             bool
+	sourceAddress      net.IP
+	destinationAddress net.IP
+	protocol           uint8
+	sourcePort         uint16
+	destinationPort    uint16
+	classOfService     uint8
+	// length is the length of the IP packet.
+	length uint64
+}
+
+// isSFlowDatagram returns true if the packet starts with the 32-bit sFlow
+// version number.
+func isSFlowDatagram(packet []byte) bool {
+	return len(packet) >= 4 && binary.BigEndian.Uint32(packet[0:4]) == sflowVersion
+}
+
+func (cp *CollectingProcess) decodeSFlowMessage(packetBuffer *bytes.Buffer, exportAddress string) (*entities.Message, error) {
+	msgLen := packetBuffer.Len()
+	var version, agentAddressType uint32
+	err := util.Decode(packetBuffer, binary.BigEndian, &version, &agentAddressType)
+	if err != nil {
+		return nil, err
+	}
+	switch agentAddressType {
+	case sflowAddressTypeIPv4:
+		packetBuffer.Next(net.IPv4len)
+	case sflowAddressTypeIPv6:
+		packetBuffer.Next(net.IPv6len)
+	default:
+		return nil, fmt.Errorf("error in decoding message: sFlow agent address type %d is not valid", agentAddressType)
+	}
+	var subAgentID, sequenceNum, upTime, sampleCount uint32
+	err = util.Decode(packetBuffer, binary.BigEndian, &subAgentID, &sequenceNum, &upTime, &sampleCount)
+	if err != nil {
+		return nil, err
+	}
+
+	// sFlow datagrams do not carry the export time, so the time of receipt is
+	// used instead. Sub-agent ID is the equivalent of the IPFIX observation
+	// domain ID.
+	message := entities.NewMessage(true)
+	message.SetVersion(uint16(version))
+	message.SetMessageLen(uint16(msgLen))
+	message.SetExportTime(uint32(time.Now().Unix()))
+	message.SetSequenceNum(sequenceNum)
+	message.SetObsDomainID(subAgentID)
+	message.SetExportAddress(strings.Split(exportAddress, ":")[0])
+
+	records := make([]*sflowRecord, 0)
+	for i := 0; i < int(sampleCount); i++ {
+		var sampleFormat, sampleLen uint32
+		err = util.Decode(packetBuffer, binary.BigEndian, &sampleFormat, &sampleLen)
+		if err != nil {
+			return nil, fmt.Errorf("error in decoding sFlow sample header: %v", err)
+		}
+		if int(sampleLen) > packetBuffer.Len() {
+			return nil, fmt.Errorf("error in decoding message: invalid sFlow sample length %d", sampleLen)
+		}
+		sampleBuffer := bytes.NewBuffer(packetBuffer.Next(int(sampleLen)))
+		var sampleRecords []*sflowRecord
+		switch sampleFormat {
+		case sflowFlowSampleFormat, sflowExpandedFlowSampleFormat:
+			sampleRecords, err = cp.decodeSFlowFlowSample(sampleBuffer, sampleFormat == sflowExpandedFlowSampleFormat)
+		case sflowCounterSampleFormat, sflowExpandedCounterSampleFormat:
+			sampleRecords, err = cp.decodeSFlowCounterSample(sampleBuffer, sampleFormat == sflowExpandedCounterSampleFormat)
+		default:
+			// Enterprise specific samples are not supported.
+			klog.V(4).Infof("Skipping sFlow sample with format %d from %s", sampleFormat, exportAddress)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error in decoding message: %v", err)
+		}
+		records = append(records, sampleRecords...)
+	}
+
+	// Group the records into data sets by their virtual template.
+	dataSets := make(map[uint16]entities.Set)
+	for _, record := range records {
+		dataSet, exist := dataSets[record.templateID]
+		if !exist {
+			dataSet = entities.NewSet(entities.Data, record.templateID, true)
+			dataSets[record.templateID] = dataSet
+			message.AddSet(dataSet)
+		}
+		if record.scopeFieldCount > 0 {
+			err = dataSet.AddOptionsRecord(record.elements, record.scopeFieldCount, record.templateID)
+		} else {
+			err = dataSet.AddRecord(record.elements, record.templateID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error in decoding message: %v", err)
+		}
+	}
+	return message, nil
+}
+
+func (cp *CollectingProcess) decodeSFlowFlowSample(sampleBuffer *bytes.Buffer, isExpanded bool) ([]*sflowRecord, error) {
+	var sequenceNum, samplingRate, samplePool, drops, recordCount uint32
+	var inputInterface, outputInterface uint32
+	if isExpanded {
+		var sourceIDType, sourceIDIndex, inputFormat, inputValue, outputFormat, outputValue uint32
+		err := util.Decode(sampleBuffer, binary.BigEndian, &sequenceNum, &sourceIDType, &sourceIDIndex, &samplingRate, &samplePool, &drops,
+			&inputFormat, &inputValue, &outputFormat, &outputValue, &recordCount)
+		if err != nil {
+			return nil, err
+		}
+		// Interface values are ifIndex only when the format is 0.
+		if inputFormat == 0 {
+			inputInterface = inputValue
+		}
+		if outputFormat == 0 {
+			outputInterface = outputValue
+		}
+	} else {
+		var sourceID, input, output uint32
+		err := util.Decode(sampleBuffer, binary.BigEndian, &sequenceNum, &sourceID, &samplingRate, &samplePool, &drops, &input, &output, &recordCount)
+		if err != nil {
+			return nil, err
+		}
+		// The first two bits give the format of the interface value, and the
+		// value is ifIndex only when the format is 0.
+		if input>>30 == 0 {
+			inputInterface = input
+		}
+		if output>>30 == 0 {
+			outputInterface = output
+		}
+	}
+
+	records := make([]*sflowRecord, 0)
+	for i := 0; i < int(recordCount); i++ {
+		var recordFormat, recordLen uint32
+		err := util.Decode(sampleBuffer, binary.BigEndian, &recordFormat, &recordLen)
+		if err != nil {
+			return nil, err
+		}
+		if int(recordLen) > sampleBuffer.Len() {
+			return nil, fmt.Errorf("invalid sFlow flow record length %d", recordLen)
+		}
+		recordBuffer := bytes.NewBuffer(sampleBuffer.Next(int(recordLen)))
+		if recordFormat != sflowRawPacketHeaderFormat {
+			continue
+		}
+		var headerProtocol, frameLength, stripped, headerLength uint32
+		err = util.Decode(recordBuffer, binary.BigEndian, &headerProtocol, &frameLength, &stripped, &headerLength)
+		if err != nil {
+			return nil, err
+		}
+		if int(headerLength) > recordBuffer.Len() {
+			return nil, fmt.Errorf("invalid sFlow sampled header length %d", headerLength)
+		}
+		flow, err := parseSFlowSampledHeader(headerProtocol, recordBuffer.Next(int(headerLength)))
+		if err != nil {
+			klog.V(4).Infof("Skipping sFlow sampled header: %v", err)
+			continue
+		}
+		templateID := SFlowIPv4FlowTemplateID
+		fields := sflowIPv4FlowFields
+		if flow.isIPv6 {
+			templateID = SFlowIPv6FlowTemplateID
+			fields = sflowIPv6FlowFields
+		}
+		// Counts are of the sampled packet. Sampling interval is given so that
+		// the counts can be scaled to estimate the total traffic.
+		elements, err := cp.newSFlowRecordElements(fields, inputInterface, outputInterface, []byte(flow.sourceAddress), []byte(flow.destinationAddress),
+			flow.protocol, flow.sourcePort, flow.destinationPort, flow.classOfService, flow.length, uint64(1), samplingRate)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, &sflowRecord{templateID: templateID, elements: elements})
+	}
+	return records, nil
+}
+
+func (cp *CollectingProcess) decodeSFlowCounterSample(sampleBuffer *bytes.Buffer, isExpanded bool) ([]*sflowRecord, error) {
+	var sequenceNum, recordCount uint32
+	var err error
+	if isExpanded {
+		var sourceIDType, sourceIDIndex uint32
+		err = util.Decode(sampleBuffer, binary.BigEndian, &sequenceNum, &sourceIDType, &sourceIDIndex, &recordCount)
+	} else {
+		var sourceID uint32
+		err = util.Decode(sampleBuffer, binary.BigEndian, &sequenceNum, &sourceID, &recordCount)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*sflowRecord, 0)
+	for i := 0; i < int(recordCount); i++ {
+		var recordFormat, recordLen uint32
+		err = util.Decode(sampleBuffer, binary.BigEndian, &recordFormat, &recordLen)
+		if err != nil {
+			return nil, err
+		}
+		if int(recordLen) > sampleBuffer.Len() {
+			return nil, fmt.Errorf("invalid sFlow counter record length %d", recordLen)
+		}
+		recordBuffer := bytes.NewBuffer(sampleBuffer.Next(int(recordLen)))
+		if recordFormat != sflowGenericInterfaceCountersFormat {
+			continue
+		}
+		var ifIndex, ifType, ifDirection, ifStatus uint32
+		var ifSpeed, ifInOctets, ifOutOctets uint64
+		var ifInUcastPkts, ifInMulticastPkts, ifInBroadcastPkts, ifInDiscards, ifInErrors, ifInUnknownProtos uint32
+		var ifOutUcastPkts, ifOutMulticastPkts, ifOutBroadcastPkts, ifOutDiscards, ifOutErrors, ifPromiscuousMode uint32
+		err = util.Decode(recordBuffer, binary.BigEndian, &ifIndex, &ifType, &ifSpeed, &ifDirection, &ifStatus,
+			&ifInOctets, &ifInUcastPkts, &ifInMulticastPkts, &ifInBroadcastPkts, &ifInDiscards, &ifInErrors, &ifInUnknownProtos,
+			&ifOutOctets, &ifOutUcastPkts, &ifOutMulticastPkts, &ifOutBroadcastPkts, &ifOutDiscards, &ifOutErrors, &ifPromiscuousMode)
+		if err != nil {
+			return nil, err
+		}
+		inPackets := uint64(ifInUcastPkts) + uint64(ifInMulticastPkts) + uint64(ifInBroadcastPkts)
+		outPackets := uint64(ifOutUcastPkts) + uint64(ifOutMulticastPkts) + uint64(ifOutBroadcastPkts)
+		ingressElements, err := cp.newSFlowRecordElements(sflowIngressCountersFields, ifIndex, ifInOctets, inPackets)
+		if err != nil {
+			return nil, err
+		}
+		egressElements, err := cp.newSFlowRecordElements(sflowEgressCountersFields, ifIndex, ifOutOctets, outPackets)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, &sflowRecord{templateID: SFlowIngressCountersTemplateID, scopeFieldCount: 1, elements: ingressElements},
+			&sflowRecord{templateID: SFlowEgressCountersTemplateID, scopeFieldCount: 1, elements: egressElements})
+	}
+	return records, nil
+}
+
+// newSFlowRecordElements returns the elements of a record with the given field
+// names and values. Values must be of the data types of the elements.
+func (cp *CollectingProcess) newSFlowRecordElements(fields []string, values ...interface{}) ([]*entities.InfoElementWithValue, error) {
+	elements := make([]*entities.InfoElementWithValue, 0, len(fields))
+	for i, name := range fields {
+		element, err := cp.getRegistry().LookupByName(name, registry.IANAEnterpriseID)
+		if err != nil {
+			return nil, err
+		}
+		value := &bytes.Buffer{}
+		if err = util.Encode(value, binary.BigEndian, values[i]); err != nil {
+			return nil, err
+		}
+		elements = append(elements, entities.NewInfoElementWithValue(element, value))
+	}
+	return elements, nil
+}
+
+// parseSFlowSampledHeader extracts the flow information from a sampled packet
+// header. Only IP packets are supported.
+func parseSFlowSampledHeader(headerProtocol uint32, header []byte) (*sflowSampledFlow, error) {
+	switch headerProtocol {
+	case sflowHeaderProtocolEthernet:
+		if len(header) < 14 {
+			return nil, fmt.Errorf("ethernet header is too short")
+		}
+		etherType := binary.BigEndian.Uint16(header[12:14])
+		offset := 14
+		// Skip VLAN tags
+		for etherType == 0x8100 || etherType == 0x88a8 {
+			if len(header) < offset+4 {
+				return nil, fmt.Errorf("ethernet header is too short")
+			}
+			etherType = binary.BigEndian.Uint16(header[offset+2 : offset+4])
+			offset = offset + 4
+		}
+		switch etherType {
+		case 0x0800:
+			return parseIPv4Header(header[offset:])
+		case 0x86dd:
+			return parseIPv6Header(header[offset:])
+		default:
+			return nil, fmt.Errorf("ether type 0x%x is not supported", etherType)
+		}
+	case sflowHeaderProtocolIPv4:
+		return parseIPv4Header(header)
+	case sflowHeaderProtocolIPv6:
+		return parseIPv6Header(header)
+	default:
+		return nil, fmt.Errorf("header protocol %d is not supported", headerProtocol)
+	}
+}
+
+func parseIPv4Header(header []byte) (*sflowSampledFlow, error) {
+	if len(header) < 20 || header[0]>>4 != 4 {
+		return nil, fmt.Errorf("IPv4 header is not valid")
+	}
+	headerLen := int(header[0]&0x0f) * 4
+	flow := &sflowSampledFlow{
+		sourceAddress:      net.IP(header[12:16]),
+		destinationAddress: net.IP(header[16:20]),
+		protocol:           header[9],
+		classOfService:     header[1],
+		length:             uint64(binary.BigEndian.Uint16(header[2:4])),
+	}
+	// Only the first fragment has the transport header.
+	fragmentOffset := binary.BigEndian.Uint16(header[6:8]) & 0x1fff
+	if fragmentOffset == 0 && headerLen >= 20 && headerLen <= len(header) {
+		parseTransportPorts(flow, header[headerLen:])
+	}
+	return flow, nil
+}
+
+func parseIPv6Header(header []byte) (*sflowSampledFlow, error) {
+	if len(header) < 40 || header[0]>>4 != 6 {
+		return nil, fmt.Errorf("IPv6 header is not valid")
+	}
+	flow := &sflowSampledFlow{
+		isIPv6:             true,
+		sourceAddress:      net.IP(header[8:24]),
+		destinationAddress: net.IP(header[24:40]),
+		protocol:           header[6],
+		classOfService:     uint8(binary.BigEndian.Uint16(header[0:2]) >> 4),
+		length:             uint64(binary.BigEndian.Uint16(header[4:6])) + 40,
+	}
+	// Extension headers are not parsed, so ports are only found when the
+	// transport header follows the fixed header.
+	parseTransportPorts(flow, header[40:])
+	return flow, nil
+}
+
+// parseTransportPorts sets the ports of TCP, UDP and SCTP flows. Ports are left
+// as zero for other protocols, or when the sampled header is truncated.
+func parseTransportPorts(flow *sflowSampledFlow, transportHeader []byte) {
+	switch flow.protocol {
+	case 6, 17, 132:
+		if len(transportHeader) >= 4 {
+			flow.sourcePort = binary.BigEndian.Uint16(transportHeader[0:2])
+			flow.destinationPort = binary.BigEndian.Uint16(transportHeader[2:4])
+		}
+	}
+}
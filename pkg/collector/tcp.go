@@ -64,6 +64,9 @@ func (cp *CollectingProcess) handleTCPClient(conn net.Conn, wg *sync.WaitGroup)
 	cp.addClient(address, client)
 	go func() {
 		defer conn.Close()
+		// Templates are scoped by transport session, so they are not valid
+		// anymore once the session is closed.
+		defer cp.deleteSessionTemplates(address)
 	out:
 		for {
 			buff := make([]byte, cp.maxBufferSize)
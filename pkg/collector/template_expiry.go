@@ -0,0 +1,173 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// expiryEntry schedules the expiry check of a template. The entry refers to the
+// template definition it was created for, so that the entry is dropped when the
+// template is deleted or redefined.
+type expiryEntry struct {
+	scope      templateScope
+	templateID uint16
+	template   *template
+	// expiresAt is the time at which the template is checked. The template may
+	// have been refreshed in between, in which case the entry is rescheduled.
+	expiresAt time.Time
+}
+
+// expiryHeap is a min-heap of expiry entries ordered by expiry time.
+type expiryHeap []*expiryEntry
+
+func (h expiryHeap) Len() int { return len(h) }
+
+func (h expiryHeap) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+
+func (h expiryHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *expiryHeap) Push(x interface{}) { *h = append(*h, x.(*expiryEntry)) }
+
+func (h *expiryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return entry
+}
+
+// templateExpiryQueue keeps track of the expiry of templates received over UDP.
+// A single goroutine waits for the earliest expiry and deletes the templates
+// which are not refreshed within template TTL. Refreshing a template only
+// updates its expiry time, so no work is done in the queue for refreshes. The
+// zero value is ready to use; the goroutine is started when the first entry is
+// added.
+type templateExpiryQueue struct {
+	mutex     sync.Mutex
+	entries   expiryHeap
+	wakeChan  chan struct{}
+	stopChan  chan struct{}
+	isRunning bool
+	isStopped bool
+	// expiredCount is the number of templates expired so far
+	expiredCount uint64
+}
+
+// scheduleTemplateExpiry adds the entry to the expiry queue and starts the
+// expiry goroutine if it is not running yet. Entries are ignored after the
+// queue is stopped.
+func (cp *CollectingProcess) scheduleTemplateExpiry(entry *expiryEntry) {
+	q := &cp.templateExpiry
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.isStopped {
+		return
+	}
+	heap.Push(&q.entries, entry)
+	if !q.isRunning {
+		q.isRunning = true
+		q.wakeChan = make(chan struct{}, 1)
+		q.stopChan = make(chan struct{})
+		go cp.runTemplateExpiry(q.wakeChan, q.stopChan)
+		return
+	}
+	// Wake up the goroutine if the new entry expires earlier than the one it
+	// is waiting for.
+	if q.entries[0] == entry {
+		select {
+		case q.wakeChan <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// stopTemplateExpiry stops the expiry goroutine. Templates are not expired
+// after the collecting process is stopped.
+func (cp *CollectingProcess) stopTemplateExpiry() {
+	q := &cp.templateExpiry
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.isRunning {
+		close(q.stopChan)
+		q.isRunning = false
+	}
+	q.isStopped = true
+	q.entries = nil
+}
+
+func (cp *CollectingProcess) runTemplateExpiry(wakeChan <-chan struct{}, stopChan <-chan struct{}) {
+	q := &cp.templateExpiry
+	for {
+		q.mutex.Lock()
+		now := time.Now()
+		dueEntries := make([]*expiryEntry, 0)
+		for len(q.entries) > 0 && !q.entries[0].expiresAt.After(now) {
+			dueEntries = append(dueEntries, heap.Pop(&q.entries).(*expiryEntry))
+		}
+		var timer *time.Timer
+		var timerChan <-chan time.Time
+		if len(dueEntries) == 0 && len(q.entries) > 0 {
+			timer = time.NewTimer(q.entries[0].expiresAt.Sub(now))
+			timerChan = timer.C
+		}
+		q.mutex.Unlock()
+
+		if len(dueEntries) > 0 {
+			// Due entries may be rescheduled, so check the queue again after
+			// processing them.
+			for _, entry := range dueEntries {
+				cp.expireTemplate(entry)
+			}
+			continue
+		}
+		select {
+		case <-timerChan:
+		case <-wakeChan:
+		case <-stopChan:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		select {
+		case <-stopChan:
+			return
+		default:
+		}
+	}
+}
+
+// GetActiveTemplateCount returns the number of templates currently known by
+// the collecting process.
+func (cp *CollectingProcess) GetActiveTemplateCount() int {
+	cp.mutex.RLock()
+	defer cp.mutex.RUnlock()
+	count := 0
+	for _, templatesInScope := range cp.templatesMap {
+		count = count + len(templatesInScope)
+	}
+	return count
+}
+
+// GetExpiredTemplateCount returns the number of templates that have expired
+// because they were not refreshed within template TTL.
+func (cp *CollectingProcess) GetExpiredTemplateCount() uint64 {
+	cp.templateExpiry.mutex.Lock()
+	defer cp.templateExpiry.mutex.Unlock()
+	return cp.templateExpiry.expiredCount
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"math"
+
+	"k8s.io/klog"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+	"github.com/vmware/go-ipfix/pkg/registry"
+)
+
+// Exporters describe the Information Elements they use with type information
+// options records (RFC5610). Elements described by an exporter are registered
+// in the registry of its transport session, which is used to decode the
+// templates received afterwards in the session.
+const (
+	informationElementIDName        = "informationElementId"
+	privateEnterpriseNumberName     = "privateEnterpriseNumber"
+	informationElementDataTypeName  = "informationElementDataType"
+	informationElementSemanticsName = "informationElementSemantics"
+	informationElementNameName      = "informationElementName"
+)
+
+// isTypeInformationTemplate returns true if data records of the options
+// template are type information records.
+func isTypeInformationTemplate(tmpl *template) bool {
+	if tmpl.scopeFieldCount == 0 {
+		return false
+	}
+	requiredElements := map[string]bool{
+		informationElementIDName:       false,
+		privateEnterpriseNumberName:    false,
+		informationElementDataTypeName: false,
+		informationElementNameName:     false,
+	}
+	for _, element := range tmpl.elements {
+		if _, exist := requiredElements[element.Name]; exist && element.EnterpriseId == registry.IANAEnterpriseID {
+			requiredElements[element.Name] = true
+		}
+	}
+	for _, exist := range requiredElements {
+		if !exist {
+			return false
+		}
+	}
+	return true
+}
+
+// learnTypeInformation registers the element described by the type information
+// record in the registry of the transport session. Invalid descriptions are
+// ignored, as the record is still delivered to consumers.
+func (cp *CollectingProcess) learnTypeInformation(scope templateScope, record entities.Record) {
+	elementID, ok1 := getTypeInformationUint(record, informationElementIDName, math.MaxUint16)
+	enterpriseID, ok2 := getTypeInformationUint(record, privateEnterpriseNumberName, math.MaxUint32)
+	dataTypeNum, ok3 := getTypeInformationUint(record, informationElementDataTypeName, math.MaxUint8)
+	name, exist, err := record.GetString(informationElementNameName)
+	if !ok1 || !ok2 || !ok3 || !exist || err != nil {
+		klog.Warningf("Ignoring type information record from %s: values are not valid", scope.exporterAddress)
+		return
+	}
+	dataType := entities.IEDataType(dataTypeNum)
+	length, exist := entities.InfoElementLength[dataType]
+	if !exist || !entities.IsValidDataType(dataType) || name == "" {
+		klog.Warningf("Ignoring type information of element %d with enterpriseID %d from %s: data type %d or name %q is not valid", elementID, enterpriseID, scope.exporterAddress, dataTypeNum, name)
+		return
+	}
+
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	if cp.sessionRegistries == nil {
+		cp.sessionRegistries = make(map[string]*registry.Registry)
+	}
+	sessionRegistry, exist := cp.sessionRegistries[scope.exporterAddress]
+	if !exist {
+		sessionRegistry = registry.New()
+		cp.sessionRegistries[scope.exporterAddress] = sessionRegistry
+	}
+	// Type information is refreshed with templates over UDP.
+	if element, err := sessionRegistry.Lookup(uint16(elementID), uint32(enterpriseID)); err == nil {
+		if element.Name != name || element.DataType != dataType {
+			klog.Warningf("Ignoring type information of element %d with enterpriseID %d from %s: element is already described as %s", elementID, enterpriseID, scope.exporterAddress, element.Name)
+		}
+		return
+	}
+	element := entities.NewInfoElement(name, uint16(elementID), dataType, uint32(enterpriseID), length)
+	// informationElementSemantics is optional in type information records.
+	if semantics, ok := getTypeInformationUint(record, informationElementSemanticsName, math.MaxUint8); ok {
+		element.Semantics = entities.IESemantics(semantics)
+	}
+	if err := sessionRegistry.Register(element); err != nil {
+		klog.Warningf("Ignoring type information of element %d with enterpriseID %d from %s: %v", elementID, enterpriseID, scope.exporterAddress, err)
+		return
+	}
+	klog.V(2).Infof("Learned element %s with elementID %d and enterpriseID %d from %s", name, elementID, enterpriseID, scope.exporterAddress)
+}
+
+// getTypeInformationUint returns the value of an integer element of the type
+// information record if it does not exceed max. Exporters may send the values
+// with reduced-size encoding.
+func getTypeInformationUint(record entities.Record, name string, max uint64) (uint64, bool) {
+	value, exist, err := record.GetUint64(name)
+	return value, exist && err == nil && value <= max
+}
+
+// deleteSessionRegistry deletes the elements described by type information in
+// the transport session. It is called when a UDP session times out.
+func (cp *CollectingProcess) deleteSessionRegistry(exporterAddress string) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	delete(cp.sessionRegistries, exporterAddress)
+}
+
+// getSessionInfoElement returns the element described by type information in
+// the transport session.
+func (cp *CollectingProcess) getSessionInfoElement(exporterAddress string, elementID uint16, enterpriseID uint32) (*entities.InfoElement, bool) {
+	cp.mutex.RLock()
+	sessionRegistry, exist := cp.sessionRegistries[exporterAddress]
+	cp.mutex.RUnlock()
+	if !exist {
+		return nil, false
+	}
+	element, err := sessionRegistry.Lookup(elementID, enterpriseID)
+	return element, err == nil
+}
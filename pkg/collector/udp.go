@@ -134,6 +134,7 @@ func (cp *CollectingProcess) handleUDPClient(address net.Addr, wg *sync.WaitGrou
 				case <-ticker.C: // set timeout for udp connection
 					klog.Errorf("UDP connection from %s timed out.", address.String())
 					cp.deleteClient(address.String())
+					cp.deleteSessionRegistry(address.String())
 					return
 				case packet := <-client.packetChan:
 					// get the message here
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"time"
 
 	"github.com/vmware/go-ipfix/pkg/util"
 )
@@ -94,6 +95,15 @@ type InfoElement struct {
 	EnterpriseId uint32
 	// Length of IE
 	Len uint16
+	// Semantics, Units and range of IE follow the specification in RFC7012
+	// (section 3.2, 3.3 and 3.4). They are set only if given in the registry.
+	Semantics  IESemantics
+	Units      IEUnits
+	RangeBegin uint64
+	RangeEnd   uint64
+	// Reversible is true if IE has a reverse IE in the reverse registry
+	// (RFC5103 section 6.1).
+	Reversible bool
 }
 
 // InfoElementWithValue represents mapping from element to value for data records
@@ -174,12 +184,25 @@ func IsValidDataType(tp IEDataType) bool {
 	return tp != InvalidDataType
 }
 
-// DecodeToIEDataType is to decode to specific type
+// DecodeToIEDataType is to decode to specific type. Structured data refers to
+// other elements and templates, so it is decoded by DecodeStructuredData and
+// the decoded value is returned as is.
 func DecodeToIEDataType(dataType IEDataType, val interface{}) (interface{}, error) {
+	switch val.(type) {
+	case *BasicListValue, *SubTemplateListValue, *SubTemplateMultiListValue:
+		if !IsStructuredDataType(dataType) {
+			return nil, fmt.Errorf("structured data value is not valid for data type %d", dataType)
+		}
+		return val, nil
+	}
 	value, ok := val.(*bytes.Buffer)
 	if !ok {
 		return nil, fmt.Errorf("error when converting value to bytes.Buffer for decoding")
 	}
+	if fullLen := InfoElementLength[dataType]; fullLen != VariableLength && value.Len() > 0 && value.Len() < int(fullLen) &&
+		IsValidElementLength(dataType, uint16(value.Len())) {
+		return decodeReducedSize(dataType, value)
+	}
 	switch dataType {
 	case Unsigned8:
 		var v uint8
@@ -277,18 +300,84 @@ func DecodeToIEDataType(dataType IEDataType, val interface{}) (interface{}, erro
 		}
 		return v, nil
 	case DateTimeMicroseconds, DateTimeNanoseconds:
-		return nil, fmt.Errorf("API does not support micro and nano seconds types yet")
+		var seconds, fraction uint32
+		err := util.Decode(value, binary.BigEndian, &seconds, &fraction)
+		if err != nil {
+			return nil, fmt.Errorf("error in decoding val to NTP timestamp: %v", err)
+		}
+		return decodeNTPTimestamp(dataType, seconds, fraction), nil
 	case MacAddress:
 		return net.HardwareAddr(value.Bytes()), nil
 	case Ipv4Address, Ipv6Address:
 		return net.IP(value.Bytes()), nil
 	case String:
 		return value.String(), nil
+	case OctetArray:
+		return value.Bytes(), nil
+	case BasicList, SubTemplateList, SubTemplateMultiList:
+		return nil, fmt.Errorf("structured data needs to be decoded with DecodeStructuredData")
 	default:
 		return nil, fmt.Errorf("API supports only valid information elements with datatypes given in RFC7011")
 	}
 }
 
+// EncodeToIEDataTypeWithLength is to encode data to specific type to the buff
+// with given length. Reduced-size encoding is used when the length is shorter
+// than the length of the data type (RFC7011 section 6.2).
+func EncodeToIEDataTypeWithLength(dataType IEDataType, val interface{}, length uint16, buff *bytes.Buffer) (interface{}, error) {
+	fullLen := InfoElementLength[dataType]
+	if dataType == OctetArray && length != VariableLength {
+		// Fixed length octetArray values are written without length prefix.
+		v, ok := val.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("val argument is not of type []byte for this element")
+		}
+		if len(v) != int(length) {
+			return nil, fmt.Errorf("octetArray value of length %d cannot be encoded with length %d", len(v), length)
+		}
+		_, err := buff.Write(v)
+		return v, err
+	}
+	if fullLen == VariableLength || length == fullLen {
+		return EncodeToIEDataType(dataType, val, buff)
+	}
+	if !IsValidElementLength(dataType, length) {
+		return nil, fmt.Errorf("data type %d cannot be encoded with length %d", dataType, length)
+	}
+	if dataType == Float64 {
+		v, ok := val.(float64)
+		if !ok {
+			return nil, fmt.Errorf("val argument is not of type float64")
+		}
+		reduced := float32(v)
+		if math.IsInf(float64(reduced), 0) && !math.IsInf(v, 0) {
+			return nil, fmt.Errorf("value %v cannot be encoded with length %d", v, length)
+		}
+		err := util.Encode(buff, binary.BigEndian, math.Float32bits(reduced))
+		return v, err
+	}
+	fullBuff := new(bytes.Buffer)
+	v, err := EncodeToIEDataType(dataType, val, fullBuff)
+	if err != nil {
+		return nil, err
+	}
+	// Dropped bytes must be zero for unsigned values, and the sign extension
+	// of the remaining bytes for signed values.
+	encoded := fullBuff.Bytes()
+	reduced := encoded[fullLen-length:]
+	var extension byte
+	if isSignedDataType(dataType) && reduced[0]>>7 == 1 {
+		extension = 0xff
+	}
+	for _, b := range encoded[:fullLen-length] {
+		if b != extension {
+			return nil, fmt.Errorf("value %v cannot be encoded with length %d", val, length)
+		}
+	}
+	_, err = buff.Write(reduced)
+	return v, err
+}
+
 // EncodeToIEDataType is to encode data to specific type to the buff
 func EncodeToIEDataType(dataType IEDataType, val interface{}, buff *bytes.Buffer) (interface{}, error) {
 	switch dataType {
@@ -389,10 +478,14 @@ func EncodeToIEDataType(dataType IEDataType, val interface{}, buff *bytes.Buffer
 		}
 		err := util.Encode(buff, binary.BigEndian, v)
 		return v, err
-		// Currently only supporting seconds and milliseconds
 	case DateTimeMicroseconds, DateTimeNanoseconds:
-		// TODO: RFC 7011 has extra spec for these data types. Need to follow that
-		return 0, fmt.Errorf("API does not support micro and nano seconds types yet")
+		v, ok := val.(time.Time)
+		if !ok {
+			return 0, fmt.Errorf("val argument is not of type time.Time")
+		}
+		seconds, fraction := encodeNTPTimestamp(dataType, v)
+		err := util.Encode(buff, binary.BigEndian, seconds, fraction)
+		return v, err
 	case MacAddress:
 		// Expects net.Hardware type
 		v, ok := val.(net.HardwareAddr)
@@ -420,8 +513,8 @@ func EncodeToIEDataType(dataType IEDataType, val interface{}, buff *bytes.Buffer
 			return 0, fmt.Errorf("val argument is not of type net.IP for this element")
 		}
 		if ipv6Add := v.To16(); ipv6Add != nil {
-			err := util.Encode(buff, binary.BigEndian, v)
-			return v, err
+			err := util.Encode(buff, binary.BigEndian, ipv6Add)
+			return ipv6Add, err
 		} else {
 			return 0, fmt.Errorf("provided IPv6 address is not of correct length")
 		}
@@ -437,6 +530,127 @@ func EncodeToIEDataType(dataType IEDataType, val interface{}, buff *bytes.Buffer
 			err := util.Encode(buff, binary.BigEndian, byte(255), uint16(len(v)), []byte(v))
 			return []byte(v), err
 		}
+	case OctetArray:
+		v, ok := val.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("val argument is not of type []byte for this element")
+		}
+		return v, encodeVariableLength(v, buff)
+	case BasicList:
+		v, ok := val.(*BasicListValue)
+		if !ok {
+			return nil, fmt.Errorf("val argument is not of type *BasicListValue for this element")
+		}
+		return v, encodeBasicList(v, buff)
+	case SubTemplateList:
+		v, ok := val.(*SubTemplateListValue)
+		if !ok {
+			return nil, fmt.Errorf("val argument is not of type *SubTemplateListValue for this element")
+		}
+		return v, encodeSubTemplateList(v, buff)
+	case SubTemplateMultiList:
+		v, ok := val.(*SubTemplateMultiListValue)
+		if !ok {
+			return nil, fmt.Errorf("val argument is not of type *SubTemplateMultiListValue for this element")
+		}
+		return v, encodeSubTemplateMultiList(v, buff)
 	}
 	return nil, fmt.Errorf("API supports only valid information elements with datatypes given in RFC7011")
 }
+
+const (
+	// ntpEpochOffset is the number of seconds from the NTP epoch (1900-01-01)
+	// to the Unix epoch (1970-01-01).
+	ntpEpochOffset = 2208988800
+	// microsecondsFractionMask masks the fraction bits which must be zero for
+	// dateTimeMicroseconds (RFC7011 section 6.1.9).
+	microsecondsFractionMask uint32 = 0xfffff800
+)
+
+// encodeNTPTimestamp returns the seconds and fraction of the NTP timestamp of
+// given time, as encoded for dateTimeMicroseconds and dateTimeNanoseconds
+// (RFC7011 section 6.1.9 and 6.1.10).
+func encodeNTPTimestamp(dataType IEDataType, t time.Time) (uint32, uint32) {
+	seconds := uint32(t.Unix() + ntpEpochOffset)
+	var fraction uint32
+	if dataType == DateTimeMicroseconds {
+		microseconds := uint64(t.Nanosecond() / 1000)
+		fraction = uint32(((microseconds << 32) + 500000) / 1000000)
+		fraction = fraction & microsecondsFractionMask
+	} else {
+		fraction = uint32(((uint64(t.Nanosecond()) << 32) + 500000000) / 1000000000)
+	}
+	return seconds, fraction
+}
+
+// decodeNTPTimestamp returns the time of the NTP timestamp with given seconds
+// and fraction. Times with the most significant bit of seconds unset are after
+// the NTP era wrap in 2036 (RFC4330 section 3).
+func decodeNTPTimestamp(dataType IEDataType, seconds uint32, fraction uint32) time.Time {
+	unixSeconds := int64(seconds) - ntpEpochOffset
+	if seconds&0x80000000 == 0 {
+		unixSeconds = unixSeconds + 1<<32
+	}
+	var nanoseconds int64
+	if dataType == DateTimeMicroseconds {
+		// Lower bits of the fraction are ignored for dateTimeMicroseconds.
+		fraction = fraction & microsecondsFractionMask
+		microseconds := ((uint64(fraction) * 1000000) + 1<<31) >> 32
+		nanoseconds = int64(microseconds) * 1000
+	} else {
+		nanoseconds = int64(((uint64(fraction) * 1000000000) + 1<<31) >> 32)
+	}
+	return time.Unix(unixSeconds, nanoseconds).UTC()
+}
+
+// IsValidElementLength returns true if an element of the data type can be
+// encoded with the given length. Reduced-size encoding is allowed for signed
+// and unsigned integers and float64 (RFC7011 section 6.2), and elements of
+// variable length data types may have a fixed length of at least one octet.
+func IsValidElementLength(dataType IEDataType, length uint16) bool {
+	fullLen, exist := InfoElementLength[dataType]
+	if !exist {
+		return false
+	}
+	if fullLen == VariableLength {
+		return length >= 1
+	}
+	if length == fullLen {
+		return true
+	}
+	switch dataType {
+	case Unsigned16, Unsigned32, Unsigned64, Signed16, Signed32, Signed64:
+		return length >= 1 && length < fullLen
+	case Float64:
+		return length == 4
+	}
+	return false
+}
+
+func isSignedDataType(dataType IEDataType) bool {
+	return dataType == Signed8 || dataType == Signed16 || dataType == Signed32 || dataType == Signed64
+}
+
+// decodeReducedSize decodes a value encoded with reduced-size encoding to the
+// data type. Unsigned values are padded with zeros and signed values are
+// sign-extended.
+func decodeReducedSize(dataType IEDataType, value *bytes.Buffer) (interface{}, error) {
+	if dataType == Float64 {
+		var v float32
+		err := util.Decode(value, binary.BigEndian, &v)
+		if err != nil {
+			return nil, fmt.Errorf("error when decoding val to float32: %v", err)
+		}
+		return float64(v), nil
+	}
+	val := value.Bytes()
+	fullLen := int(InfoElementLength[dataType])
+	expanded := make([]byte, fullLen)
+	if isSignedDataType(dataType) && val[0]>>7 == 1 {
+		for i := 0; i < fullLen-len(val); i++ {
+			expanded[i] = 0xff
+		}
+	}
+	copy(expanded[fullLen-len(val):], val)
+	return DecodeToIEDataType(dataType, bytes.NewBuffer(expanded))
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entities
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IESemantics is the data type semantics of an IE, which follows the
+// specification in RFC7012 (section 3.2). The values are the values of
+// informationElementSemantics in RFC5610 (section 3.6).
+type IESemantics uint8
+
+const (
+	DefaultSemantics IESemantics = iota
+	Quantity
+	TotalCounter
+	DeltaCounter
+	Identifier
+	Flags
+	List
+	SNMPCounter
+	SNMPGauge
+)
+
+// IEUnits is the units of an IE. The values are the values of
+// informationElementUnits in RFC5610 (section 3.7), which are maintained by
+// IANA in the IPFIX Information Element Units registry.
+type IEUnits uint16
+
+const (
+	UnitsNone IEUnits = iota
+	UnitsBits
+	UnitsOctets
+	UnitsPackets
+	UnitsFlows
+	UnitsSeconds
+	UnitsMilliseconds
+	UnitsMicroseconds
+	UnitsNanoseconds
+	UnitsFourOctetWords
+	UnitsMessages
+	UnitsHops
+	UnitsEntries
+	UnitsFrames
+	UnitsPorts
+	UnitsInferred
+)
+
+var ieSemanticsNames = map[string]IESemantics{
+	"default":      DefaultSemantics,
+	"quantity":     Quantity,
+	"totalCounter": TotalCounter,
+	"deltaCounter": DeltaCounter,
+	"identifier":   Identifier,
+	"flags":        Flags,
+	"list":         List,
+	"snmpCounter":  SNMPCounter,
+	"snmpGauge":    SNMPGauge,
+}
+
+var ieUnitsNames = map[string]IEUnits{
+	"none":          UnitsNone,
+	"bits":          UnitsBits,
+	"octets":        UnitsOctets,
+	"packets":       UnitsPackets,
+	"flows":         UnitsFlows,
+	"seconds":       UnitsSeconds,
+	"milliseconds":  UnitsMilliseconds,
+	"microseconds":  UnitsMicroseconds,
+	"nanoseconds":   UnitsNanoseconds,
+	"4-octet words": UnitsFourOctetWords,
+	"messages":      UnitsMessages,
+	"hops":          UnitsHops,
+	"entries":       UnitsEntries,
+	"frames":        UnitsFrames,
+	"ports":         UnitsPorts,
+	"inferred":      UnitsInferred,
+}
+
+// IENameToSemantics returns the semantics with the given name in the IANA
+// registry. Empty or unknown names are the default semantics.
+func IENameToSemantics(name string) IESemantics {
+	return ieSemanticsNames[strings.TrimSpace(name)]
+}
+
+// IENameToUnits returns the units with the given name in the IANA registry.
+// Empty or unknown names are no units.
+func IENameToUnits(name string) IEUnits {
+	return ieUnitsNames[strings.ToLower(strings.TrimSpace(name))]
+}
+
+// ParseIERange parses the range of an IE in the IANA registry, e.g. "0-255" or
+// "0x0-0xFFFF". ok is false if the range is not given or cannot be parsed.
+func ParseIERange(value string) (begin uint64, end uint64, ok bool) {
+	bounds := strings.Split(strings.TrimSpace(value), "-")
+	if len(bounds) != 2 {
+		return 0, 0, false
+	}
+	begin, err := strconv.ParseUint(strings.TrimSpace(bounds[0]), 0, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	end, err = strconv.ParseUint(strings.TrimSpace(bounds[1]), 0, 64)
+	if err != nil || end < begin {
+		return 0, 0, false
+	}
+	return begin, end, true
+}
+
+// IsCounter returns true if the IE is a total or delta counter.
+func (ie *InfoElement) IsCounter() bool {
+	return ie.Semantics == TotalCounter || ie.Semantics == DeltaCounter || ie.Semantics == SNMPCounter
+}
+
+// WithLength returns a copy of the IE with the given length, which keeps the
+// metadata of the IE. It is used for reduced-size encoding and lengths given
+// in templates.
+func (ie *InfoElement) WithLength(length uint16) *InfoElement {
+	element := *ie
+	element.Len = length
+	return &element
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -48,6 +49,10 @@ func TestDecodeToIEDataType(t *testing.T) {
 	v, err := DecodeToIEDataType(String, buff)
 	assert.Nil(t, err)
 	assert.Equal(t, s, v)
+	// octetArray is decoded as raw bytes
+	v, err = DecodeToIEDataType(OctetArray, bytes.NewBuffer([]byte{0xde, 0xad, 0xbe, 0xef}))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, v)
 }
 
 func TestEncodeToIEDataType(t *testing.T) {
@@ -62,16 +67,105 @@ func TestEncodeToIEDataType(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, data.expectedEncode, buff.Bytes())
 	}
-	s := "Test"
+	// IPv4 addresses of ipv6Address elements are encoded as IPv4-mapped IPv6
+	// addresses.
 	buff := new(bytes.Buffer)
-	_, err := EncodeToIEDataType(String, s, buff)
+	v, err := EncodeToIEDataType(Ipv6Address, net.IP{1, 2, 3, 4}, buff)
+	assert.Nil(t, err)
+	assert.Equal(t, net.ParseIP("1.2.3.4").To16(), v)
+	assert.Equal(t, []byte(net.ParseIP("1.2.3.4").To16()), buff.Bytes())
+	s := "Test"
+	buff = new(bytes.Buffer)
+	_, err = EncodeToIEDataType(String, s, buff)
 	assert.Nil(t, err)
 	assert.Equal(t, []byte{0x4, 0x54, 0x65, 0x73, 0x74}, buff.Bytes())
+	// octetArray of variable length has length prefix, and octetArray of fixed
+	// length does not.
+	buff = new(bytes.Buffer)
+	_, err = EncodeToIEDataType(OctetArray, []byte{0xbe, 0xef}, buff)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x2, 0xbe, 0xef}, buff.Bytes())
+	buff = new(bytes.Buffer)
+	_, err = EncodeToIEDataTypeWithLength(OctetArray, []byte{0xbe, 0xef}, 2, buff)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xbe, 0xef}, buff.Bytes())
+	_, err = EncodeToIEDataTypeWithLength(OctetArray, []byte{0xbe, 0xef}, 4, new(bytes.Buffer))
+	assert.NotNil(t, err, "Error should be returned for octetArray value of wrong length")
+}
+
+func TestDateTimeMicroAndNanoseconds(t *testing.T) {
+	for _, data := range []struct {
+		dataType IEDataType
+		value    time.Time
+		encoded  []byte
+	}{
+		{DateTimeMicroseconds, time.Unix(1603955583, 123456000).UTC(), []byte{0xe3, 0x44, 0xe9, 0xff, 0x1f, 0x9a, 0xc8, 0x0}},
+		{DateTimeNanoseconds, time.Unix(1603955583, 123456789).UTC(), []byte{0xe3, 0x44, 0xe9, 0xff, 0x1f, 0x9a, 0xdd, 0x37}},
+		{DateTimeNanoseconds, time.Unix(1603955583, 500000000).UTC(), []byte{0xe3, 0x44, 0xe9, 0xff, 0x80, 0x0, 0x0, 0x0}},
+		// NTP era 1 starts at 2036-02-07T06:28:16Z
+		{DateTimeNanoseconds, time.Date(2036, 2, 7, 6, 28, 17, 0, time.UTC), []byte{0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0, 0x0}},
+	} {
+		buff := new(bytes.Buffer)
+		_, err := EncodeToIEDataType(data.dataType, data.value, buff)
+		assert.Nil(t, err)
+		assert.Equal(t, data.encoded, buff.Bytes())
+		v, err := DecodeToIEDataType(data.dataType, bytes.NewBuffer(data.encoded))
+		assert.Nil(t, err)
+		assert.Equal(t, data.value, v)
+	}
+	// Lower 11 bits of the fraction are ignored for dateTimeMicroseconds
+	v, err := DecodeToIEDataType(DateTimeMicroseconds, bytes.NewBuffer([]byte{0xe3, 0x44, 0xe9, 0xff, 0x1f, 0x9a, 0xcf, 0xff}))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Unix(1603955583, 123456000).UTC(), v)
+	// Sub-microsecond part is dropped when encoding dateTimeMicroseconds
+	buff := new(bytes.Buffer)
+	_, err = EncodeToIEDataType(DateTimeMicroseconds, time.Unix(1603955583, 123456789), buff)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xe3, 0x44, 0xe9, 0xff, 0x1f, 0x9a, 0xc8, 0x0}, buff.Bytes())
+
+	_, err = EncodeToIEDataType(DateTimeNanoseconds, uint64(1603955583), new(bytes.Buffer))
+	assert.NotNil(t, err, "Error should be returned for value of wrong type")
+	_, err = DecodeToIEDataType(DateTimeNanoseconds, bytes.NewBuffer([]byte{0xe3, 0x44, 0xe9, 0xff}))
+	assert.NotNil(t, err, "Error should be returned for truncated value")
+}
+
+func TestInfoElementMetadata(t *testing.T) {
+	assert.Equal(t, DeltaCounter, IENameToSemantics("deltaCounter"))
+	assert.Equal(t, SNMPGauge, IENameToSemantics("snmpGauge"))
+	assert.Equal(t, DefaultSemantics, IENameToSemantics(""))
+	assert.Equal(t, DefaultSemantics, IENameToSemantics("unknown"))
+	assert.Equal(t, UnitsOctets, IENameToUnits("octets"))
+	assert.Equal(t, UnitsFourOctetWords, IENameToUnits("4-octet words"))
+	assert.Equal(t, UnitsNone, IENameToUnits(""))
+
+	begin, end, ok := ParseIERange("0-255")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), begin)
+	assert.Equal(t, uint64(255), end)
+	begin, end, ok = ParseIERange("0x1-0xFFFF")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), begin)
+	assert.Equal(t, uint64(0xFFFF), end)
+	for _, invalidRange := range []string{"", "255", "a-b", "255-0"} {
+		_, _, ok = ParseIERange(invalidRange)
+		assert.False(t, ok, "Range %q should not be valid", invalidRange)
+	}
+
+	element := NewInfoElement("octetDeltaCount", 1, Unsigned64, 0, 8)
+	element.Semantics = DeltaCounter
+	element.Units = UnitsOctets
+	assert.True(t, element.IsCounter())
+	reducedElement := element.WithLength(4)
+	assert.Equal(t, uint16(4), reducedElement.Len)
+	assert.Equal(t, uint16(8), element.Len)
+	assert.Equal(t, DeltaCounter, reducedElement.Semantics)
+	assert.Equal(t, UnitsOctets, reducedElement.Units)
+	assert.False(t, NewInfoElement("protocolIdentifier", 4, Unsigned8, 0, 1).IsCounter())
 }
 
 func TestNewInfoElementWithValue(t *testing.T) {
 	ip := net.ParseIP("10.0.0.1")
-	element := NewInfoElementWithValue(&InfoElement{"sourceIPv4Address", 8, 18, 0, 4}, ip)
+	element := NewInfoElementWithValue(NewInfoElement("sourceIPv4Address", 8, 18, 0, 4), ip)
 	assert.Equal(t, element.Element.Name, "sourceIPv4Address")
 	assert.Equal(t, element.Value, ip)
 }
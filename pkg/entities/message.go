@@ -23,11 +23,11 @@ const (
 	MaxTcpSocketMsgSize int = 65535
 	DefaultUDPMsgSize   int = 512
 	MaxUDPMsgSize       int = 1500
+	MsgHeaderLength     int = 16
 )
 
-// Message represents IPFIX message.
-// TODO: Currently, it supports only one set. This will be extended to support multiple
-// sets.
+// Message represents IPFIX message. A message carries an ordered list of sets,
+// which can be a mix of template and data sets.
 type Message struct {
 	buffer        *bytes.Buffer
 	version       uint16
@@ -37,13 +37,14 @@ type Message struct {
 	exportTime    uint32
 	exportAddress string
 	isDecoding    bool
-	set           Set
+	sets          []Set
 }
 
 func NewMessage(isDecoding bool) *Message {
 	return &Message{
 		buffer:     &bytes.Buffer{},
 		isDecoding: isDecoding,
+		sets:       make([]Set, 0),
 	}
 }
 
@@ -110,12 +111,14 @@ func (m *Message) SetExportAddress(ipAddr string) {
 	m.exportAddress = ipAddr
 }
 
-func (m *Message) GetSet() Set {
-	return m.set
+// GetSets returns the sets in the message in the order they were added.
+func (m *Message) GetSets() []Set {
+	return m.sets
 }
 
+// AddSet appends the set to the list of sets in the message.
 func (m *Message) AddSet(set Set) {
-	m.set = set
+	m.sets = append(m.sets, set)
 }
 
 func (m *Message) GetMsgBuffer() *bytes.Buffer {
@@ -131,7 +134,7 @@ func (m *Message) WriteToMsgBuffer(bytesToWrite []byte) (int, error) {
 }
 
 func (m *Message) CreateHeader() (int, error) {
-	header := make([]byte, 16)
+	header := make([]byte, MsgHeaderLength)
 	return m.WriteToMsgBuffer(header)
 }
 
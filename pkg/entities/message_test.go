@@ -47,7 +47,10 @@ func TestMessage_SetAndGetFunctions(t *testing.T) {
 	message.SetExportAddress("127.0.0.1")
 	assert.Equal(t, message.GetExportAddress(), "127.0.0.1")
 	message.AddSet(set)
-	assert.Equal(t, message.GetSet(), set)
+	assert.Equal(t, []Set{set}, message.GetSets())
+	templateSet := NewSet(Template, 256, false)
+	message.AddSet(templateSet)
+	assert.Equal(t, []Set{set, templateSet}, message.GetSets())
 	message.ResetMsgBuffer()
 	assert.Equal(t, message.GetMsgBufferLen(), 0)
 }
@@ -18,6 +18,8 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"net"
+	"time"
 
 	"github.com/vmware/go-ipfix/pkg/util"
 )
@@ -37,15 +39,30 @@ type Record interface {
 	GetBuffer() *bytes.Buffer
 	GetTemplateID() uint16
 	GetFieldCount() uint16
+	GetScopeFieldCount() uint16
 	GetOrderedElementList() []*InfoElementWithValue
 	GetInfoElementWithValue(name string) (*InfoElementWithValue, bool)
 	GetMinDataRecordLen() uint16
+	// Typed accessors return the value of the element with given name,
+	// converted to the Go type. Found is false if the record does not have the
+	// element, and error is returned if the value cannot be converted.
+	GetUint64(name string) (value uint64, found bool, err error)
+	GetInt64(name string) (value int64, found bool, err error)
+	GetFloat64(name string) (value float64, found bool, err error)
+	GetBool(name string) (value bool, found bool, err error)
+	GetString(name string) (value string, found bool, err error)
+	GetIP(name string) (value net.IP, found bool, err error)
+	GetMAC(name string) (value net.HardwareAddr, found bool, err error)
+	GetTime(name string) (value time.Time, found bool, err error)
 }
 
 type baseRecord struct {
-	buff               bytes.Buffer
-	len                uint16
-	fieldCount         uint16
+	buff       bytes.Buffer
+	len        uint16
+	fieldCount uint16
+	// scopeFieldCount is non-zero only for options template records and options
+	// data records; the first scopeFieldCount elements are scope fields.
+	scopeFieldCount    uint16
 	templateID         uint16
 	orderedElementList []*InfoElementWithValue
 	elementsMap        map[string]*InfoElementWithValue
@@ -69,6 +86,14 @@ func NewDataRecord(id uint16) *dataRecord {
 	}
 }
 
+// NewOptionsDataRecord creates a data record that follows an options template
+// with given scope field count.
+func NewOptionsDataRecord(scopeCount uint16, id uint16) *dataRecord {
+	record := NewDataRecord(id)
+	record.scopeFieldCount = scopeCount
+	return record
+}
+
 type templateRecord struct {
 	*baseRecord
 	// Minimum data record length required to be sent for this template.
@@ -90,6 +115,16 @@ func NewTemplateRecord(count uint16, id uint16) *templateRecord {
 	}
 }
 
+type optionsTemplateRecord struct {
+	*templateRecord
+}
+
+func NewOptionsTemplateRecord(count uint16, scopeCount uint16, id uint16) *optionsTemplateRecord {
+	record := NewTemplateRecord(count, id)
+	record.scopeFieldCount = scopeCount
+	return &optionsTemplateRecord{record}
+}
+
 func (b *baseRecord) GetBuffer() *bytes.Buffer {
 	return &b.buff
 }
@@ -102,6 +137,10 @@ func (b *baseRecord) GetFieldCount() uint16 {
 	return b.fieldCount
 }
 
+func (b *baseRecord) GetScopeFieldCount() uint16 {
+	return b.scopeFieldCount
+}
+
 func (d *baseRecord) GetOrderedElementList() []*InfoElementWithValue {
 	return d.orderedElementList
 }
@@ -127,7 +166,7 @@ func (d *dataRecord) AddInfoElement(element *InfoElementWithValue, isDecoding bo
 	if isDecoding {
 		value, err = DecodeToIEDataType(element.Element.DataType, element.Value)
 	} else {
-		value, err = EncodeToIEDataType(element.Element.DataType, element.Value, &d.buff)
+		value, err = EncodeToIEDataTypeWithLength(element.Element.DataType, element.Value, element.Element.Len, &d.buff)
 	}
 
 	if err != nil {
@@ -157,6 +196,10 @@ func (t *templateRecord) AddInfoElement(element *InfoElementWithValue, isDecodin
 	if element.Value != nil {
 		return 0, fmt.Errorf("AddInfoElement(templateRecord) cannot take value %v (nil is expected)", element.Value)
 	}
+	// Element length shorter than its data type is used for reduced-size encoding.
+	if !IsValidElementLength(element.Element.DataType, element.Element.Len) {
+		return 0, fmt.Errorf("AddInfoElement(templateRecord) length %d is not valid for element %s", element.Element.Len, element.Element.Name)
+	}
 	initialLength := t.buff.Len()
 	// Add field specifier {elementID: uint16, elementLen: uint16}
 	err := util.Encode(&t.buff, binary.BigEndian, element.Element.ElementId, element.Element.Len)
@@ -185,3 +228,13 @@ func (t *templateRecord) AddInfoElement(element *InfoElementWithValue, isDecodin
 func (t *templateRecord) GetMinDataRecordLen() uint16 {
 	return t.minDataRecLength
 }
+
+func (o *optionsTemplateRecord) PrepareRecord() (uint16, error) {
+	// Add Options Template Record Header
+	initialLength := o.buff.Len()
+	err := util.Encode(&o.buff, binary.BigEndian, o.templateID, o.fieldCount, o.scopeFieldCount)
+	if err != nil {
+		return 0, fmt.Errorf("AddInfoElement(optionsTemplateRecord) error in writing options template header: %v", err)
+	}
+	return uint16(o.buff.Len() - initialLength), nil
+}
@@ -0,0 +1,365 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entities
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"time"
+)
+
+// RecordBuilder builds a data set of the records of a template, whose values
+// are set by element name. Values are converted to the Go types expected for
+// the data types of the elements, e.g. int to uint16 for unsigned16, string to
+// net.IP for ipv4Address, and time.Time to uint32 for dateTimeSeconds.
+//
+//	builder := NewRecordBuilder(templateID, elements)
+//	builder.Set("sourceIPv4Address", "10.0.0.1").Set("sourceTransportPort", 443)
+//	err := builder.AddRecord()
+//	...
+//	set, err := builder.Build()
+type RecordBuilder struct {
+	templateID uint16
+	elements   []*InfoElement
+	// scopeFieldCount is non-zero only for options templates.
+	scopeFieldCount uint16
+	indexes         map[string]int
+	// values are the values of the current record. err is the first error
+	// when setting the values, which is returned by AddRecord.
+	values  []interface{}
+	err     error
+	records [][]*InfoElementWithValue
+}
+
+// NewRecordBuilder returns the builder of the data records of the template
+// with given elements, in the order of the template.
+func NewRecordBuilder(templateID uint16, elements []*InfoElement) *RecordBuilder {
+	return NewOptionsRecordBuilder(templateID, elements, 0)
+}
+
+// NewOptionsRecordBuilder returns the builder of the data records of the
+// options template with given elements, whose first scopeFieldCount elements
+// are the scope fields.
+func NewOptionsRecordBuilder(templateID uint16, elements []*InfoElement, scopeFieldCount uint16) *RecordBuilder {
+	b := &RecordBuilder{
+		templateID:      templateID,
+		elements:        elements,
+		scopeFieldCount: scopeFieldCount,
+		indexes:         make(map[string]int),
+		values:          make([]interface{}, len(elements)),
+	}
+	for i := len(elements) - 1; i >= 0; i-- {
+		b.indexes[elements[i].Name] = i
+	}
+	return b
+}
+
+// Set sets the value of the element of the current record. If the template
+// has multiple elements with the name, the first one is set.
+func (b *RecordBuilder) Set(name string, value interface{}) *RecordBuilder {
+	if b.err != nil {
+		return b
+	}
+	index, exist := b.indexes[name]
+	if !exist {
+		b.err = fmt.Errorf("element %s does not exist in template %d", name, b.templateID)
+		return b
+	}
+	v, err := convertValue(b.elements[index], value)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	b.values[index] = v
+	return b
+}
+
+// AddRecord adds the current record to the set after checking that all
+// elements are set with valid values. The current record is cleared even if it
+// returns error.
+func (b *RecordBuilder) AddRecord() error {
+	defer func() {
+		b.values = make([]interface{}, len(b.elements))
+		b.err = nil
+	}()
+	if err := b.validateTemplate(); err != nil {
+		return err
+	}
+	if b.err != nil {
+		return b.err
+	}
+	elements := make([]*InfoElementWithValue, len(b.elements))
+	// The values are encoded to check that they fit in the element lengths.
+	record := NewDataRecord(b.templateID)
+	for i, element := range b.elements {
+		if b.values[i] == nil {
+			return fmt.Errorf("element %s of template %d is not set", element.Name, b.templateID)
+		}
+		elements[i] = NewInfoElementWithValue(element, b.values[i])
+		if _, err := record.AddInfoElement(elements[i], false); err != nil {
+			return fmt.Errorf("error when encoding element %s: %v", element.Name, err)
+		}
+	}
+	b.records = append(b.records, elements)
+	return nil
+}
+
+// Build returns the data set of the added records, which is ready to be sent
+// by the exporting process. The builder can be used for the next set after it
+// returns.
+func (b *RecordBuilder) Build() (Set, error) {
+	if len(b.records) == 0 {
+		return nil, fmt.Errorf("no record is added to the set of template %d", b.templateID)
+	}
+	set := NewSet(Data, b.templateID, false)
+	for _, elements := range b.records {
+		var err error
+		if b.scopeFieldCount > 0 {
+			err = set.AddOptionsRecord(elements, b.scopeFieldCount, b.templateID)
+		} else {
+			err = set.AddRecord(elements, b.templateID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	set.UpdateLenInHeader()
+	b.records = nil
+	return set, nil
+}
+
+func (b *RecordBuilder) validateTemplate() error {
+	if b.templateID < MinDataSetID {
+		return fmt.Errorf("template ID %d is not valid", b.templateID)
+	}
+	if len(b.elements) == 0 {
+		return fmt.Errorf("template %d has no elements", b.templateID)
+	}
+	if int(b.scopeFieldCount) > len(b.elements) {
+		return fmt.Errorf("scope field count %d is not valid for template %d with %d fields", b.scopeFieldCount, b.templateID, len(b.elements))
+	}
+	return nil
+}
+
+// convertValue converts the value to the Go type expected for the data type of
+// the element.
+func convertValue(element *InfoElement, value interface{}) (interface{}, error) {
+	var converted interface{}
+	var err error
+	switch element.DataType {
+	case Unsigned8, Unsigned16, Unsigned32, Unsigned64:
+		var v uint64
+		bits := InfoElementLength[element.DataType] * 8
+		if v, err = toUnsigned(value, bits); err == nil {
+			if element.RangeEnd > 0 && (v < element.RangeBegin || v > element.RangeEnd) {
+				return nil, fmt.Errorf("value %d is out of the range %d-%d of element %s", v, element.RangeBegin, element.RangeEnd, element.Name)
+			}
+			switch element.DataType {
+			case Unsigned8:
+				converted = uint8(v)
+			case Unsigned16:
+				converted = uint16(v)
+			case Unsigned32:
+				converted = uint32(v)
+			default:
+				converted = v
+			}
+		}
+	case Signed8, Signed16, Signed32, Signed64:
+		var v int64
+		bits := InfoElementLength[element.DataType] * 8
+		if v, err = toSigned(value, bits); err == nil {
+			switch element.DataType {
+			case Signed8:
+				converted = int8(v)
+			case Signed16:
+				converted = int16(v)
+			case Signed32:
+				converted = int32(v)
+			default:
+				converted = v
+			}
+		}
+	case Float32, Float64:
+		var v float64
+		switch f := value.(type) {
+		case float32:
+			v = float64(f)
+		case float64:
+			v = f
+		default:
+			var i int64
+			if i, err = toSigned(value, 64); err == nil {
+				v = float64(i)
+			}
+		}
+		if element.DataType == Float32 {
+			converted = float32(v)
+		} else {
+			converted = v
+		}
+	case DateTimeSeconds:
+		if t, ok := value.(time.Time); ok {
+			converted = uint32(t.Unix())
+		} else {
+			var v uint64
+			v, err = toUnsigned(value, 32)
+			converted = uint32(v)
+		}
+	case DateTimeMilliseconds:
+		if t, ok := value.(time.Time); ok {
+			converted = uint64(t.UnixNano() / int64(time.Millisecond))
+		} else {
+			converted, err = toUnsigned(value, 64)
+		}
+	case Ipv4Address, Ipv6Address:
+		var ip net.IP
+		switch v := value.(type) {
+		case net.IP:
+			ip = v
+		case string:
+			if ip = net.ParseIP(v); ip == nil {
+				return nil, fmt.Errorf("value %s of element %s is not a valid IP address", v, element.Name)
+			}
+		}
+		if ip != nil {
+			// Addresses are kept in the length of the data type.
+			if element.DataType == Ipv4Address {
+				if ip.To4() == nil {
+					return nil, fmt.Errorf("value %s of element %s is not an IPv4 address", ip.String(), element.Name)
+				}
+				converted = ip.To4()
+			} else {
+				if ip.To16() == nil {
+					return nil, fmt.Errorf("value of element %s is not a valid IPv6 address", element.Name)
+				}
+				converted = ip.To16()
+			}
+		}
+	case MacAddress:
+		switch v := value.(type) {
+		case net.HardwareAddr:
+			converted = v
+		case string:
+			converted, err = net.ParseMAC(v)
+		}
+	case String:
+		switch v := value.(type) {
+		case string:
+			converted = v
+		case []byte:
+			converted = string(v)
+		case fmt.Stringer:
+			converted = v.String()
+		}
+	case OctetArray:
+		switch v := value.(type) {
+		case []byte:
+			converted = v
+		case string:
+			converted = []byte(v)
+		}
+	case Boolean:
+		if v, ok := value.(bool); ok {
+			converted = v
+		} else if i, u, isUnsigned, ok := getInteger(value); ok {
+			if isUnsigned {
+				i = int64(u)
+			}
+			// Following boolean spec from RFC7011
+			if i == 1 || i == 2 {
+				converted = i == 1
+			}
+		}
+	default:
+		// dateTimeMicroseconds, dateTimeNanoseconds and list values are not
+		// converted.
+		converted = value
+	}
+	if err != nil {
+		return nil, fmt.Errorf("value of element %s is not valid: %v", element.Name, err)
+	}
+	if converted == nil {
+		return nil, fmt.Errorf("value of type %T cannot be converted for element %s", value, element.Name)
+	}
+	return converted, nil
+}
+
+// toUnsigned converts the integer value of any Go integer type to an unsigned
+// integer of given bits.
+func toUnsigned(value interface{}, bits uint16) (uint64, error) {
+	i, u, isUnsigned, ok := getInteger(value)
+	if !ok {
+		return 0, fmt.Errorf("value of type %T is not an integer", value)
+	}
+	if !isUnsigned {
+		if i < 0 {
+			return 0, fmt.Errorf("value %d is negative", i)
+		}
+		u = uint64(i)
+	}
+	if bits < 64 && u > 1<<bits-1 {
+		return 0, fmt.Errorf("value %d overflows unsigned%d", u, bits)
+	}
+	return u, nil
+}
+
+// toSigned converts the integer value of any Go integer type to a signed
+// integer of given bits.
+func toSigned(value interface{}, bits uint16) (int64, error) {
+	i, u, isUnsigned, ok := getInteger(value)
+	if !ok {
+		return 0, fmt.Errorf("value of type %T is not an integer", value)
+	}
+	if isUnsigned {
+		if u > math.MaxInt64 {
+			return 0, fmt.Errorf("value %d overflows signed%d", u, bits)
+		}
+		i = int64(u)
+	}
+	if bits < 64 && (i < -1<<(bits-1) || i > 1<<(bits-1)-1) {
+		return 0, fmt.Errorf("value %d overflows signed%d", i, bits)
+	}
+	return i, nil
+}
+
+// getInteger returns the value of a signed integer in i, or the value of an
+// unsigned integer in u.
+func getInteger(value interface{}) (i int64, u uint64, isUnsigned bool, ok bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), 0, false, true
+	case int8:
+		return int64(v), 0, false, true
+	case int16:
+		return int64(v), 0, false, true
+	case int32:
+		return int64(v), 0, false, true
+	case int64:
+		return v, 0, false, true
+	case uint:
+		return 0, uint64(v), true, true
+	case uint8:
+		return 0, uint64(v), true, true
+	case uint16:
+		return 0, uint64(v), true, true
+	case uint32:
+		return 0, uint64(v), true, true
+	case uint64:
+		return 0, v, true, true
+	}
+	return 0, 0, false, false
+}
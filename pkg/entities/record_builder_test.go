@@ -0,0 +1,179 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entities
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var builderElements = []*InfoElement{
+	NewInfoElement("sourceIPv4Address", 8, Ipv4Address, 0, 4),
+	NewInfoElement("sourceTransportPort", 7, Unsigned16, 0, 2),
+	NewInfoElement("flowStartSeconds", 150, DateTimeSeconds, 0, 4),
+	NewInfoElement("flowEndMilliseconds", 153, DateTimeMilliseconds, 0, 8),
+	NewInfoElement("packetDeltaCount", 2, Unsigned64, 0, 8),
+	NewInfoElement("sourceMacAddress", 56, MacAddress, 0, 6),
+	NewInfoElement("interfaceName", 82, String, 0, VariableLength),
+}
+
+func TestRecordBuilder(t *testing.T) {
+	builder := NewRecordBuilder(256, builderElements)
+	startTime := time.Unix(1600000000, 0)
+	for _, port := range []int{443, 80} {
+		builder.Set("sourceIPv4Address", "10.0.0.1").
+			Set("sourceTransportPort", port).
+			Set("flowStartSeconds", startTime).
+			Set("flowEndMilliseconds", startTime.Add(1500*time.Millisecond)).
+			Set("packetDeltaCount", 10).
+			Set("sourceMacAddress", "aa:bb:cc:dd:ee:0f").
+			Set("interfaceName", []byte("eth0"))
+		assert.NoError(t, builder.AddRecord())
+	}
+	set, err := builder.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, Data, set.GetSetType())
+	assert.Equal(t, uint32(2), set.GetNumberOfRecords())
+	assert.Equal(t, set.GetBuffLen(), int(set.GetBuffer().Bytes()[3]))
+	record := set.GetRecords()[0]
+	assert.Equal(t, uint16(256), record.GetTemplateID())
+	ie, _ := record.GetInfoElementWithValue("sourceIPv4Address")
+	assert.Equal(t, net.ParseIP("10.0.0.1").To4(), ie.Value)
+	ie, _ = record.GetInfoElementWithValue("sourceTransportPort")
+	assert.Equal(t, uint16(443), ie.Value)
+	ie, _ = record.GetInfoElementWithValue("flowStartSeconds")
+	assert.Equal(t, uint32(1600000000), ie.Value)
+	ie, _ = record.GetInfoElementWithValue("flowEndMilliseconds")
+	assert.Equal(t, uint64(1600000001500), ie.Value)
+	ie, _ = record.GetInfoElementWithValue("packetDeltaCount")
+	assert.Equal(t, uint64(10), ie.Value)
+	ie, _ = set.GetRecords()[1].GetInfoElementWithValue("sourceTransportPort")
+	assert.Equal(t, uint16(80), ie.Value)
+
+	// Records are cleared after the set is built.
+	_, err = builder.Build()
+	assert.Error(t, err)
+
+	// Addresses are kept in the length of the data type, and booleans are
+	// converted to Go bool.
+	builder = NewRecordBuilder(257, []*InfoElement{
+		NewInfoElement("sourceIPv4Address", 8, Ipv4Address, 0, 4),
+		NewInfoElement("destinationIPv6Address", 28, Ipv6Address, 0, 16),
+		NewInfoElement("dataRecordsReliability", 276, Boolean, 0, 1),
+	})
+	assert.NoError(t, builder.Set("sourceIPv4Address", net.ParseIP("10.0.0.1")).
+		Set("destinationIPv6Address", net.IP{10, 0, 0, 2}).
+		Set("dataRecordsReliability", true).
+		AddRecord())
+	assert.NoError(t, builder.Set("sourceIPv4Address", net.IP{10, 0, 0, 1}).
+		Set("destinationIPv6Address", "10.0.0.2").
+		Set("dataRecordsReliability", int8(2)).
+		AddRecord())
+	// Boolean can be set from RFC7011 values of any integer type.
+	assert.NoError(t, builder.Set("sourceIPv4Address", "10.0.0.1").
+		Set("destinationIPv6Address", "10.0.0.2").
+		Set("dataRecordsReliability", 1).
+		AddRecord())
+	assert.NoError(t, builder.Set("sourceIPv4Address", "10.0.0.1").
+		Set("destinationIPv6Address", "10.0.0.2").
+		Set("dataRecordsReliability", uint8(2)).
+		AddRecord())
+	set, err = builder.Build()
+	assert.NoError(t, err)
+	for i, reliability := range []bool{true, false, true, false} {
+		record = set.GetRecords()[i]
+		ie, _ = record.GetInfoElementWithValue("sourceIPv4Address")
+		assert.Equal(t, net.IP{10, 0, 0, 1}, ie.Value)
+		ie, _ = record.GetInfoElementWithValue("destinationIPv6Address")
+		assert.Equal(t, net.ParseIP("10.0.0.2").To16(), ie.Value)
+		value, _, err := record.GetBool("dataRecordsReliability")
+		assert.NoError(t, err)
+		assert.Equal(t, reliability, value)
+		assert.Equal(t, 4+16+1, len(record.GetBuffer().Bytes()))
+	}
+	assert.Error(t, builder.Set("sourceIPv4Address", "10.0.0.1").
+		Set("destinationIPv6Address", "::1").
+		Set("dataRecordsReliability", "true").
+		AddRecord(), "Boolean should not be set from string.")
+	assert.Error(t, builder.Set("sourceIPv4Address", "10.0.0.1").
+		Set("destinationIPv6Address", "::1").
+		Set("dataRecordsReliability", int8(3)).
+		AddRecord(), "Boolean should not be set from invalid RFC7011 value.")
+	assert.Error(t, builder.Set("sourceIPv4Address", "10.0.0.1").
+		Set("destinationIPv6Address", "::1").
+		Set("dataRecordsReliability", uint64(1)<<32+1).
+		AddRecord(), "Boolean should not be set from invalid RFC7011 value.")
+}
+
+func TestRecordBuilderErrors(t *testing.T) {
+	builder := NewRecordBuilder(256, builderElements[:2])
+	for _, tc := range []struct {
+		name  string
+		value interface{}
+	}{
+		{"destinationIPv4Address", "10.0.0.1"},
+		{"sourceIPv4Address", "2001:db8::1"},
+		{"sourceIPv4Address", "10.0.0"},
+		{"sourceIPv4Address", 10},
+		{"sourceTransportPort", 65536},
+		{"sourceTransportPort", -1},
+		{"sourceTransportPort", "443"},
+	} {
+		builder.Set("sourceIPv4Address", "10.0.0.1").Set("sourceTransportPort", 443)
+		builder.Set(tc.name, tc.value)
+		assert.Error(t, builder.AddRecord(), "Setting %s to %v should fail", tc.name, tc.value)
+	}
+	// Record is cleared after the error, so all elements need to be set again.
+	builder.Set("sourceIPv4Address", net.ParseIP("10.0.0.1"))
+	assert.Error(t, builder.AddRecord())
+	_, err := builder.Build()
+	assert.Error(t, err)
+
+	// Values are checked against the range and the length of the element.
+	protocol := NewInfoElement("protocolIdentifier", 4, Unsigned8, 0, 1)
+	protocol.RangeBegin, protocol.RangeEnd = 1, 200
+	packets := NewInfoElement("packetDeltaCount", 2, Unsigned64, 0, 2)
+	builder = NewRecordBuilder(256, []*InfoElement{protocol, packets})
+	assert.Error(t, builder.Set("protocolIdentifier", 201).Set("packetDeltaCount", 1).AddRecord())
+	assert.Error(t, builder.Set("protocolIdentifier", 6).Set("packetDeltaCount", 65536).AddRecord())
+	assert.NoError(t, builder.Set("protocolIdentifier", 6).Set("packetDeltaCount", 65535).AddRecord())
+
+	assert.Error(t, NewRecordBuilder(255, builderElements).AddRecord())
+	assert.Error(t, NewRecordBuilder(256, nil).AddRecord())
+}
+
+func TestOptionsRecordBuilder(t *testing.T) {
+	elements := []*InfoElement{
+		NewInfoElement("exportingProcessId", 144, Unsigned32, 0, 4),
+		NewInfoElement("exportedMessageTotalCount", 41, Unsigned64, 0, 8),
+		NewInfoElement("samplingInterval", 34, Signed32, 0, 4),
+		NewInfoElement("samplingProbability", 311, Float64, 0, 8),
+	}
+	builder := NewOptionsRecordBuilder(256, elements, 1)
+	assert.NoError(t, builder.Set("exportingProcessId", uint8(7)).Set("exportedMessageTotalCount", uint32(100)).Set("samplingInterval", -1).Set("samplingProbability", 1).AddRecord())
+	set, err := builder.Build()
+	assert.NoError(t, err)
+	record := set.GetRecords()[0]
+	assert.Equal(t, uint16(1), record.GetScopeFieldCount())
+	ie, _ := record.GetInfoElementWithValue("exportingProcessId")
+	assert.Equal(t, uint32(7), ie.Value)
+	ie, _ = record.GetInfoElementWithValue("samplingInterval")
+	assert.Equal(t, int32(-1), ie.Value)
+
+	assert.Error(t, NewOptionsRecordBuilder(256, elements, 5).Set("exportingProcessId", 7).AddRecord())
+}
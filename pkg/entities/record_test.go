@@ -15,6 +15,7 @@
 package entities
 
 import (
+	"bytes"
 	"net"
 	"testing"
 	"time"
@@ -129,6 +130,58 @@ func TestAddInfoElements(t *testing.T) {
 	}
 }
 
+func TestAddReducedSizeInfoElements(t *testing.T) {
+	octetDeltaCount := NewInfoElement("octetDeltaCount", 1, Unsigned64, 0, 4)
+	mibObjectValueInteger := NewInfoElement("mibObjectValueInteger", 434, Signed32, 0, 2)
+	samplingProbability := NewInfoElement("samplingProbability", 311, Float64, 0, 4)
+	templateRec := NewTemplateRecord(3, uniqueTemplateID)
+	for _, element := range []*InfoElement{octetDeltaCount, mibObjectValueInteger, samplingProbability} {
+		_, err := templateRec.AddInfoElement(NewInfoElementWithValue(element, nil), false)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, []byte{0, 1, 0, 4, 1, 178, 0, 2, 1, 55, 0, 4}, templateRec.GetBuffer().Bytes(), "Template should carry reduced field lengths.")
+	assert.Equal(t, uint16(10), templateRec.GetMinDataRecordLen())
+	// Reduced-size encoding is not allowed for IP addresses
+	_, err := templateRec.AddInfoElement(NewInfoElementWithValue(NewInfoElement("sourceIPv4Address", 8, Ipv4Address, 0, 2), nil), false)
+	assert.NotNil(t, err)
+	// Fields of variable length data types need at least one octet
+	_, err = templateRec.AddInfoElement(NewInfoElementWithValue(NewInfoElement("interfaceName", 82, String, 0, 0), nil), false)
+	assert.NotNil(t, err)
+
+	dataRec := NewDataRecord(uniqueTemplateID)
+	length, err := dataRec.AddInfoElement(NewInfoElementWithValue(octetDeltaCount, uint64(100000)), false)
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(4), length)
+	length, err = dataRec.AddInfoElement(NewInfoElementWithValue(mibObjectValueInteger, int32(-2)), false)
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(2), length)
+	length, err = dataRec.AddInfoElement(NewInfoElementWithValue(samplingProbability, 0.5), false)
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(4), length)
+	assert.Equal(t, []byte{0, 1, 134, 160, 255, 254, 63, 0, 0, 0}, dataRec.GetBuffer().Bytes())
+	// Values which do not fit in the reduced size cannot be encoded
+	_, err = NewDataRecord(uniqueTemplateID).AddInfoElement(NewInfoElementWithValue(octetDeltaCount, uint64(1)<<32), false)
+	assert.NotNil(t, err)
+	_, err = NewDataRecord(uniqueTemplateID).AddInfoElement(NewInfoElementWithValue(mibObjectValueInteger, int32(40000)), false)
+	assert.NotNil(t, err)
+
+	// Decoding widens values to the data type
+	for _, data := range []struct {
+		element  *InfoElement
+		encoded  []byte
+		expected interface{}
+	}{
+		{octetDeltaCount, []byte{0, 1, 134, 160}, uint64(100000)},
+		{mibObjectValueInteger, []byte{255, 254}, int32(-2)},
+		{samplingProbability, []byte{63, 0, 0, 0}, float64(0.5)},
+	} {
+		record := NewDataRecord(uniqueTemplateID)
+		_, err = record.AddInfoElement(NewInfoElementWithValue(data.element, bytes.NewBuffer(data.encoded)), true)
+		assert.Nil(t, err)
+		assert.Equal(t, data.expected, record.GetOrderedElementList()[0].Value)
+	}
+}
+
 func TestGetInfoElementWithValue(t *testing.T) {
 	templateRec := NewTemplateRecord(1, 256)
 	templateRec.elementsMap = make(map[string]*InfoElementWithValue)
@@ -147,3 +200,99 @@ func TestGetInfoElementWithValue(t *testing.T) {
 	infoElementWithValue, _ = dataRec.GetInfoElementWithValue("destinationIPv4Address")
 	assert.Nil(t, infoElementWithValue)
 }
+
+func TestRecordValueAccessors(t *testing.T) {
+	elements := []*InfoElement{
+		NewInfoElement("sourceIPv6Address", 27, Ipv6Address, 0, 16),
+		NewInfoElement("sourceTransportPort", 7, Unsigned16, 0, 2),
+		NewInfoElement("octetDeltaCount", 1, Unsigned64, 0, 4),
+		NewInfoElement("mibObjectValueInteger", 434, Signed32, 0, 2),
+		NewInfoElement("samplingProbability", 311, Float64, 0, 8),
+		NewInfoElement("isMulticast", 206, Boolean, 0, 1),
+		NewInfoElement("interfaceName", 82, String, 0, VariableLength),
+		NewInfoElement("sourceMacAddress", 56, MacAddress, 0, 6),
+		NewInfoElement("flowStartSeconds", 150, DateTimeSeconds, 0, 4),
+		NewInfoElement("flowEndMilliseconds", 153, DateTimeMilliseconds, 0, 8),
+		NewInfoElement("flowEndMicroseconds", 155, DateTimeMicroseconds, 0, 8),
+	}
+	// Times are returned in UTC regardless of their time zones.
+	endTime := time.Date(2020, time.September, 13, 12, 26, 42, 0, time.UTC)
+	values := []interface{}{
+		net.ParseIP("2001:db8::1"), uint16(443), uint64(100000), int32(-2), 0.25, true, "eth0",
+		net.HardwareAddr{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0x0f}, uint32(1600000000), uint64(1600000001500),
+		endTime.In(time.FixedZone("UTC+1", 3600)),
+	}
+	encodedRec := NewDataRecord(uniqueTemplateID)
+	decodedRec := NewDataRecord(uniqueTemplateID)
+	for i, element := range elements {
+		offset := encodedRec.GetBuffer().Len()
+		length, err := encodedRec.AddInfoElement(NewInfoElementWithValue(element, values[i]), false)
+		assert.NoError(t, err)
+		encoded := encodedRec.GetBuffer().Bytes()[offset : offset+int(length)]
+		if element.Len == VariableLength {
+			encoded = encoded[1:]
+		}
+		_, err = decodedRec.AddInfoElement(NewInfoElementWithValue(element, bytes.NewBuffer(encoded)), true)
+		assert.NoError(t, err)
+	}
+
+	// Values are the same for encoded and decoded records.
+	for _, record := range []Record{encodedRec, decodedRec} {
+		ip, found, err := record.GetIP("sourceIPv6Address")
+		assert.True(t, found)
+		assert.NoError(t, err)
+		assert.True(t, net.ParseIP("2001:db8::1").Equal(ip))
+		port, _, err := record.GetUint64("sourceTransportPort")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(443), port)
+		count, _, err := record.GetUint64("octetDeltaCount")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(100000), count)
+		integer, _, err := record.GetInt64("mibObjectValueInteger")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-2), integer)
+		probability, _, err := record.GetFloat64("samplingProbability")
+		assert.NoError(t, err)
+		assert.Equal(t, 0.25, probability)
+		isMulticast, _, err := record.GetBool("isMulticast")
+		assert.NoError(t, err)
+		assert.True(t, isMulticast)
+		name, _, err := record.GetString("interfaceName")
+		assert.NoError(t, err)
+		assert.Equal(t, "eth0", name)
+		mac, _, err := record.GetMAC("sourceMacAddress")
+		assert.NoError(t, err)
+		assert.Equal(t, "aa:bb:cc:dd:ee:0f", mac.String())
+		startTime, _, err := record.GetTime("flowStartSeconds")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2020, time.September, 13, 12, 26, 40, 0, time.UTC), startTime)
+		endMilliseconds, _, err := record.GetTime("flowEndMilliseconds")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2020, time.September, 13, 12, 26, 41, 500*int(time.Millisecond), time.UTC), endMilliseconds)
+		endMicroseconds, _, err := record.GetTime("flowEndMicroseconds")
+		assert.NoError(t, err)
+		assert.Equal(t, endTime, endMicroseconds)
+		seconds, _, err := record.GetUint64("flowStartSeconds")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1600000000), seconds)
+
+		// Missing elements are not found, without error.
+		_, found, err = record.GetUint64("packetDeltaCount")
+		assert.False(t, found)
+		assert.NoError(t, err)
+		// Values of other data types cannot be converted.
+		_, found, err = record.GetUint64("interfaceName")
+		assert.True(t, found)
+		assert.Error(t, err)
+		_, _, err = record.GetUint64("mibObjectValueInteger")
+		assert.Error(t, err)
+		_, _, err = record.GetFloat64("octetDeltaCount")
+		assert.Error(t, err)
+		_, _, err = record.GetString("sourceMacAddress")
+		assert.Error(t, err)
+		_, _, err = record.GetIP("sourceTransportPort")
+		assert.Error(t, err)
+		_, _, err = record.GetTime("octetDeltaCount")
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entities
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"time"
+)
+
+// The typed accessors accept the values of decoded records, and the values of
+// encoded records, which are kept as returned by EncodeToIEDataType, e.g.
+// float64 values are kept as uint64 bits.
+
+// GetUint64 returns the value of an integer element, or a dateTimeSeconds or
+// dateTimeMilliseconds element, widened to uint64.
+func (b *baseRecord) GetUint64(name string) (uint64, bool, error) {
+	ie, exist := b.elementsMap[name]
+	if !exist {
+		return 0, false, nil
+	}
+	if !isIntegerDataType(ie.Element.DataType) {
+		return 0, true, fmt.Errorf("element %s is not an integer", name)
+	}
+	v, err := toUnsigned(ie.Value, 64)
+	if err != nil {
+		return 0, true, fmt.Errorf("element %s cannot be converted to uint64: %v", name, err)
+	}
+	return v, true, nil
+}
+
+// GetInt64 returns the value of an integer element, or a dateTimeSeconds or
+// dateTimeMilliseconds element, widened to int64.
+func (b *baseRecord) GetInt64(name string) (int64, bool, error) {
+	ie, exist := b.elementsMap[name]
+	if !exist {
+		return 0, false, nil
+	}
+	if !isIntegerDataType(ie.Element.DataType) {
+		return 0, true, fmt.Errorf("element %s is not an integer", name)
+	}
+	v, err := toSigned(ie.Value, 64)
+	if err != nil {
+		return 0, true, fmt.Errorf("element %s cannot be converted to int64: %v", name, err)
+	}
+	return v, true, nil
+}
+
+// GetFloat64 returns the value of a float32 or float64 element.
+func (b *baseRecord) GetFloat64(name string) (float64, bool, error) {
+	ie, exist := b.elementsMap[name]
+	if !exist {
+		return 0, false, nil
+	}
+	switch v := ie.Value.(type) {
+	case float32:
+		return float64(v), true, nil
+	case float64:
+		return v, true, nil
+	case uint32:
+		if ie.Element.DataType == Float32 {
+			return float64(math.Float32frombits(v)), true, nil
+		}
+	case uint64:
+		if ie.Element.DataType == Float64 {
+			return math.Float64frombits(v), true, nil
+		}
+	}
+	return 0, true, fmt.Errorf("element %s with value of type %T is not a float", name, ie.Value)
+}
+
+// GetBool returns the value of a boolean element.
+func (b *baseRecord) GetBool(name string) (bool, bool, error) {
+	ie, exist := b.elementsMap[name]
+	if !exist {
+		return false, false, nil
+	}
+	switch v := ie.Value.(type) {
+	case bool:
+		return v, true, nil
+	case int8:
+		// Following boolean spec from RFC7011
+		if ie.Element.DataType == Boolean && (v == 1 || v == 2) {
+			return v == 1, true, nil
+		}
+	}
+	return false, true, fmt.Errorf("element %s with value of type %T is not a boolean", name, ie.Value)
+}
+
+// GetString returns the value of a string element.
+func (b *baseRecord) GetString(name string) (string, bool, error) {
+	ie, exist := b.elementsMap[name]
+	if !exist {
+		return "", false, nil
+	}
+	switch v := ie.Value.(type) {
+	case string:
+		return v, true, nil
+	case []byte:
+		if ie.Element.DataType == String {
+			return string(v), true, nil
+		}
+	}
+	return "", true, fmt.Errorf("element %s with value of type %T is not a string", name, ie.Value)
+}
+
+// GetIP returns the value of an ipv4Address or ipv6Address element.
+func (b *baseRecord) GetIP(name string) (net.IP, bool, error) {
+	ie, exist := b.elementsMap[name]
+	if !exist {
+		return nil, false, nil
+	}
+	switch v := ie.Value.(type) {
+	case net.IP:
+		return v, true, nil
+	case []byte:
+		if len(v) == net.IPv4len || len(v) == net.IPv6len {
+			return net.IP(v), true, nil
+		}
+	}
+	return nil, true, fmt.Errorf("element %s with value of type %T is not an IP address", name, ie.Value)
+}
+
+// GetMAC returns the value of a macAddress element.
+func (b *baseRecord) GetMAC(name string) (net.HardwareAddr, bool, error) {
+	ie, exist := b.elementsMap[name]
+	if !exist {
+		return nil, false, nil
+	}
+	switch v := ie.Value.(type) {
+	case net.HardwareAddr:
+		return v, true, nil
+	case []byte:
+		if len(v) == 6 {
+			return net.HardwareAddr(v), true, nil
+		}
+	}
+	return nil, true, fmt.Errorf("element %s with value of type %T is not a MAC address", name, ie.Value)
+}
+
+// GetTime returns the value of a dateTimeSeconds, dateTimeMilliseconds,
+// dateTimeMicroseconds or dateTimeNanoseconds element in UTC.
+func (b *baseRecord) GetTime(name string) (time.Time, bool, error) {
+	ie, exist := b.elementsMap[name]
+	if !exist {
+		return time.Time{}, false, nil
+	}
+	if t, ok := ie.Value.(time.Time); ok {
+		return t.UTC(), true, nil
+	}
+	switch ie.Element.DataType {
+	case DateTimeSeconds:
+		if v, err := toUnsigned(ie.Value, 64); err == nil {
+			return time.Unix(int64(v), 0).UTC(), true, nil
+		}
+	case DateTimeMilliseconds:
+		if v, err := toUnsigned(ie.Value, 64); err == nil {
+			return time.Unix(int64(v/1000), int64(v%1000)*int64(time.Millisecond)).UTC(), true, nil
+		}
+	}
+	return time.Time{}, true, fmt.Errorf("element %s with value of type %T is not a time", name, ie.Value)
+}
+
+func isIntegerDataType(dataType IEDataType) bool {
+	return (dataType >= Unsigned8 && dataType <= Signed64) || dataType == DateTimeSeconds || dataType == DateTimeMilliseconds
+}
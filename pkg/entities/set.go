@@ -29,6 +29,13 @@ const (
 	TemplateTTL = TemplateRefreshTimeOut * 3
 	// TemplateSetID is the setID for template record
 	TemplateSetID uint16 = 2
+	// OptionsTemplateSetID is the setID for options template record
+	OptionsTemplateSetID uint16 = 3
+	// MinDataSetID is the minimum setID for data record; setIDs from 0 to 255
+	// are reserved (RFC7011 section 3.3.2)
+	MinDataSetID uint16 = 256
+	// SetHeaderLen is the length of set header (set ID and length)
+	SetHeaderLen uint16 = 4
 )
 
 type ContentType uint8
@@ -36,7 +43,7 @@ type ContentType uint8
 const (
 	Template ContentType = iota
 	Data
-	// Add OptionsTemplate too when it is supported
+	OptionsTemplate
 	Undefined = 255
 )
 
@@ -46,6 +53,7 @@ type Set interface {
 	GetSetType() ContentType
 	UpdateLenInHeader()
 	AddRecord(elements []*InfoElementWithValue, templateID uint16) error
+	AddOptionsRecord(elements []*InfoElementWithValue, scopeFieldCount uint16, templateID uint16) error
 	GetRecords() []Record
 	GetNumberOfRecords() uint32
 }
@@ -92,13 +100,40 @@ func (s *set) UpdateLenInHeader() {
 	}
 }
 
+// AddRecord adds a template record to a template set, or a data record to a
+// data set. A template withdrawal record (RFC7011 section 8.1), which has no
+// elements, can be added to a template set or an options template set.
 func (s *set) AddRecord(elements []*InfoElementWithValue, templateID uint16) error {
 	var record Record
 	if s.setType == Data {
 		record = NewDataRecord(templateID)
-	} else if s.setType == Template {
+	} else if s.setType == Template || (s.setType == OptionsTemplate && len(elements) == 0) {
 		record = NewTemplateRecord(uint16(len(elements)), templateID)
+	} else {
+		return fmt.Errorf("set type %d does not support adding records without scope fields", s.setType)
 	}
+	return s.addRecord(record, elements)
+}
+
+// AddOptionsRecord adds an options template record to an options template set,
+// or an options data record to a data set. The first scopeFieldCount elements
+// are the scope fields of the record.
+func (s *set) AddOptionsRecord(elements []*InfoElementWithValue, scopeFieldCount uint16, templateID uint16) error {
+	if scopeFieldCount == 0 || int(scopeFieldCount) > len(elements) {
+		return fmt.Errorf("scope field count %d is not valid for the record with %d fields", scopeFieldCount, len(elements))
+	}
+	var record Record
+	if s.setType == Data {
+		record = NewOptionsDataRecord(scopeFieldCount, templateID)
+	} else if s.setType == OptionsTemplate {
+		record = NewOptionsTemplateRecord(uint16(len(elements)), scopeFieldCount, templateID)
+	} else {
+		return fmt.Errorf("set type %d does not support adding options records", s.setType)
+	}
+	return s.addRecord(record, elements)
+}
+
+func (s *set) addRecord(record Record, elements []*InfoElementWithValue) error {
 	record.PrepareRecord()
 	for _, element := range elements {
 		record.AddInfoElement(element, s.isDecoding)
@@ -130,6 +165,8 @@ func (s *set) createHeader(setType ContentType, templateID uint16) {
 	header := make([]byte, 4)
 	if setType == Template {
 		binary.BigEndian.PutUint16(header[0:2], TemplateSetID)
+	} else if setType == OptionsTemplate {
+		binary.BigEndian.PutUint16(header[0:2], OptionsTemplateSetID)
 	} else if setType == Data {
 		binary.BigEndian.PutUint16(header[0:2], templateID)
 	}
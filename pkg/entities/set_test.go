@@ -58,9 +58,50 @@ func TestAddRecordIPv6Addresses(t *testing.T) {
 	assert.Equal(t, net.IP([]byte{0x20, 0x1, 0x0, 0x0, 0x32, 0x38, 0xdf, 0xe1, 0x0, 0x63, 0x0, 0x0, 0x0, 0x0, 0xfe, 0xfc}), infoElementWithValue.Value)
 }
 
+func TestAddOptionsRecord(t *testing.T) {
+	// Test with options template set
+	elements := make([]*InfoElementWithValue, 0)
+	ie1 := NewInfoElementWithValue(NewInfoElement("exportingProcessId", 144, 3, 0, 4), nil)
+	ie2 := NewInfoElementWithValue(NewInfoElement("exportedMessageTotalCount", 41, 4, 0, 8), nil)
+	elements = append(elements, ie1, ie2)
+	set := NewSet(OptionsTemplate, uint16(256), false)
+	err := set.AddOptionsRecord(elements, 1, 256)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(1), set.GetRecords()[0].GetScopeFieldCount())
+	assert.Equal(t, []byte{0, 3, 0, 0, 1, 0, 0, 2, 0, 1, 0, 144, 0, 4, 0, 41, 0, 8}, set.GetBuffer().Bytes())
+	// Scope field count should be in range of field count
+	assert.Error(t, set.AddOptionsRecord(elements, 0, 256))
+	assert.Error(t, set.AddOptionsRecord(elements, 3, 256))
+	// Options template set does not take records without scope fields
+	assert.Error(t, set.AddRecord(elements, 256))
+	// Test with data set
+	set = NewSet(Data, uint16(256), false)
+	elements = make([]*InfoElementWithValue, 0)
+	ie1 = NewInfoElementWithValue(NewInfoElement("exportingProcessId", 144, 3, 0, 4), uint32(7))
+	ie2 = NewInfoElementWithValue(NewInfoElement("exportedMessageTotalCount", 41, 4, 0, 8), uint64(100))
+	elements = append(elements, ie1, ie2)
+	err = set.AddOptionsRecord(elements, 1, 256)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(1), set.GetRecords()[0].GetScopeFieldCount())
+	// Template set does not take options records
+	set = NewSet(Template, uint16(256), false)
+	assert.Error(t, set.AddOptionsRecord(elements, 1, 256))
+}
+
+func TestAddTemplateWithdrawalRecord(t *testing.T) {
+	set := NewSet(Template, uint16(256), false)
+	assert.NoError(t, set.AddRecord(nil, 256))
+	assert.Equal(t, []byte{0, 2, 0, 0, 1, 0, 0, 0}, set.GetBuffer().Bytes())
+	set = NewSet(OptionsTemplate, uint16(256), false)
+	assert.NoError(t, set.AddRecord(nil, 256))
+	assert.Equal(t, uint16(0), set.GetRecords()[0].GetFieldCount())
+	assert.Equal(t, []byte{0, 3, 0, 0, 1, 0, 0, 0}, set.GetBuffer().Bytes())
+}
+
 func TestGetSetType(t *testing.T) {
 	assert.Equal(t, Template, NewSet(Template, uint16(256), true).GetSetType())
 	assert.Equal(t, Data, NewSet(Data, uint16(258), true).GetSetType())
+	assert.Equal(t, OptionsTemplate, NewSet(OptionsTemplate, uint16(259), true).GetSetType())
 }
 
 func TestGetBuffer(t *testing.T) {
@@ -0,0 +1,333 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entities
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/vmware/go-ipfix/pkg/util"
+)
+
+// This file contains the structured data types basicList, subTemplateList and
+// subTemplateMultiList, which follow the specification in RFC6313.
+
+// ListSemantic describes the relationship among the elements of a structured
+// data list (RFC6313 section 4.4).
+type ListSemantic uint8
+
+const (
+	NoneOf            ListSemantic = 0x00
+	ExactlyOneOf      ListSemantic = 0x01
+	OneOrMoreOf       ListSemantic = 0x02
+	AllOf             ListSemantic = 0x03
+	Ordered           ListSemantic = 0x04
+	UndefinedSemantic ListSemantic = 0xFF
+)
+
+// BasicListValue is the value of a basicList information element, which is a
+// list of zero or more values of the same information element.
+type BasicListValue struct {
+	Semantic ListSemantic
+	// Element is the information element of the values in the list. Its length
+	// may be VariableLength.
+	Element *InfoElement
+	// Values are of the Go type of the element data type, as used in
+	// EncodeToIEDataType and DecodeToIEDataType.
+	Values []interface{}
+}
+
+// SubTemplateListValue is the value of a subTemplateList information element,
+// which is a list of zero or more data records of the same template.
+type SubTemplateListValue struct {
+	Semantic   ListSemantic
+	TemplateID uint16
+	// Records are data records of the template. For encoding, the records are
+	// built with AddInfoElement in the order of the template elements.
+	Records []Record
+}
+
+// SubTemplateMultiListEntry is a list of data records of the same template in
+// a subTemplateMultiList.
+type SubTemplateMultiListEntry struct {
+	TemplateID uint16
+	Records    []Record
+}
+
+// SubTemplateMultiListValue is the value of a subTemplateMultiList information
+// element, which is a list of data records of different templates.
+type SubTemplateMultiListValue struct {
+	Semantic ListSemantic
+	Entries  []*SubTemplateMultiListEntry
+}
+
+// StructuredDataResolver resolves the information elements and templates that
+// are referred by structured data while decoding. Templates of subTemplateList
+// and subTemplateMultiList are scoped by the session that the data is received
+// on, so they are resolved by the decoder of the session.
+type StructuredDataResolver interface {
+	GetInfoElement(elementID uint16, enterpriseID uint32) (*InfoElement, error)
+	GetTemplateElements(templateID uint16) ([]*InfoElement, error)
+}
+
+// IsStructuredDataType returns true for the data types of RFC6313.
+func IsStructuredDataType(dataType IEDataType) bool {
+	return dataType == BasicList || dataType == SubTemplateList || dataType == SubTemplateMultiList
+}
+
+// DecodeStructuredData decodes the content of a basicList, subTemplateList or
+// subTemplateMultiList information element, without the variable length prefix.
+// It returns *BasicListValue, *SubTemplateListValue or *SubTemplateMultiListValue
+// respectively.
+func DecodeStructuredData(dataType IEDataType, value *bytes.Buffer, resolver StructuredDataResolver) (interface{}, error) {
+	switch dataType {
+	case BasicList:
+		return decodeBasicList(value, resolver)
+	case SubTemplateList:
+		return decodeSubTemplateList(value, resolver)
+	case SubTemplateMultiList:
+		return decodeSubTemplateMultiList(value, resolver)
+	default:
+		return nil, fmt.Errorf("data type %d is not a structured data type", dataType)
+	}
+}
+
+func decodeBasicList(value *bytes.Buffer, resolver StructuredDataResolver) (*BasicListValue, error) {
+	var semantic ListSemantic
+	var fieldID, elementLength uint16
+	var enterpriseID uint32
+	err := util.Decode(value, binary.BigEndian, &semantic, &fieldID, &elementLength)
+	if err != nil {
+		return nil, fmt.Errorf("error when decoding basicList header: %v", err)
+	}
+	// MSB of field ID is set for enterprise-specific elements
+	if fieldID>>15 == 1 {
+		if err = util.Decode(value, binary.BigEndian, &enterpriseID); err != nil {
+			return nil, fmt.Errorf("error when decoding basicList header: %v", err)
+		}
+		fieldID = fieldID & 0x7fff
+	}
+	element, err := resolver.GetInfoElement(fieldID, enterpriseID)
+	if err != nil {
+		return nil, err
+	}
+	if !IsValidElementLength(element.DataType, elementLength) {
+		return nil, fmt.Errorf("basicList element %s has invalid length %d", element.Name, elementLength)
+	}
+	if element.Len != elementLength {
+		element = element.WithLength(elementLength)
+	}
+	list := &BasicListValue{Semantic: semantic, Element: element, Values: make([]interface{}, 0)}
+	for value.Len() > 0 {
+		remaining := value.Len()
+		v, err := decodeFieldValue(element, value, resolver)
+		if err != nil {
+			return nil, err
+		}
+		// Guard against malformed lists which would never be fully consumed.
+		if value.Len() == remaining {
+			return nil, fmt.Errorf("value of basicList element %s does not have any content", element.Name)
+		}
+		if v, err = DecodeToIEDataType(element.DataType, v); err != nil {
+			return nil, err
+		}
+		list.Values = append(list.Values, v)
+	}
+	return list, nil
+}
+
+func decodeSubTemplateList(value *bytes.Buffer, resolver StructuredDataResolver) (*SubTemplateListValue, error) {
+	var semantic ListSemantic
+	var templateID uint16
+	err := util.Decode(value, binary.BigEndian, &semantic, &templateID)
+	if err != nil {
+		return nil, fmt.Errorf("error when decoding subTemplateList header: %v", err)
+	}
+	records, err := decodeRecords(templateID, value, resolver)
+	if err != nil {
+		return nil, err
+	}
+	return &SubTemplateListValue{Semantic: semantic, TemplateID: templateID, Records: records}, nil
+}
+
+func decodeSubTemplateMultiList(value *bytes.Buffer, resolver StructuredDataResolver) (*SubTemplateMultiListValue, error) {
+	var semantic ListSemantic
+	err := util.Decode(value, binary.BigEndian, &semantic)
+	if err != nil {
+		return nil, fmt.Errorf("error when decoding subTemplateMultiList header: %v", err)
+	}
+	list := &SubTemplateMultiListValue{Semantic: semantic, Entries: make([]*SubTemplateMultiListEntry, 0)}
+	for value.Len() > 0 {
+		var templateID, length uint16
+		if err = util.Decode(value, binary.BigEndian, &templateID, &length); err != nil {
+			return nil, fmt.Errorf("error when decoding subTemplateMultiList entry header: %v", err)
+		}
+		// Length includes the entry header.
+		if length < 4 || int(length-4) > value.Len() {
+			return nil, fmt.Errorf("subTemplateMultiList entry of template %d has invalid length %d", templateID, length)
+		}
+		records, err := decodeRecords(templateID, bytes.NewBuffer(value.Next(int(length-4))), resolver)
+		if err != nil {
+			return nil, err
+		}
+		list.Entries = append(list.Entries, &SubTemplateMultiListEntry{TemplateID: templateID, Records: records})
+	}
+	return list, nil
+}
+
+// decodeRecords decodes all the data records of the template in the buffer.
+func decodeRecords(templateID uint16, value *bytes.Buffer, resolver StructuredDataResolver) ([]Record, error) {
+	elements, err := resolver.GetTemplateElements(templateID)
+	if err != nil {
+		return nil, err
+	}
+	if len(elements) == 0 {
+		return nil, fmt.Errorf("template %d does not have any elements", templateID)
+	}
+	records := make([]Record, 0)
+	for value.Len() > 0 {
+		remaining := value.Len()
+		record := NewDataRecord(templateID)
+		for _, element := range elements {
+			v, err := decodeFieldValue(element, value, resolver)
+			if err != nil {
+				return nil, fmt.Errorf("error when decoding record of template %d: %v", templateID, err)
+			}
+			if _, err = record.AddInfoElement(NewInfoElementWithValue(element, v), true); err != nil {
+				return nil, err
+			}
+		}
+		if value.Len() == remaining {
+			return nil, fmt.Errorf("record of template %d does not have any content", templateID)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// decodeFieldValue reads the next value of the element in the buffer. Values of
+// structured data types are decoded into their list types, and other values are
+// returned as buffers, so that the value can be decoded by DecodeToIEDataType.
+func decodeFieldValue(element *InfoElement, value *bytes.Buffer, resolver StructuredDataResolver) (interface{}, error) {
+	length := int(element.Len)
+	if element.Len == VariableLength {
+		var err error
+		if length, err = decodeVariableLength(value); err != nil {
+			return nil, err
+		}
+	}
+	if length > value.Len() {
+		return nil, fmt.Errorf("value of %s is shorter than expected", element.Name)
+	}
+	fieldBuffer := bytes.NewBuffer(value.Next(length))
+	if IsStructuredDataType(element.DataType) {
+		return DecodeStructuredData(element.DataType, fieldBuffer, resolver)
+	}
+	return fieldBuffer, nil
+}
+
+func encodeBasicList(list *BasicListValue, buff *bytes.Buffer) error {
+	if list.Element == nil {
+		return fmt.Errorf("basicList does not have an information element")
+	}
+	content := &bytes.Buffer{}
+	fieldID := list.Element.ElementId
+	if list.Element.EnterpriseId != 0 {
+		fieldID = fieldID | 0x8000
+	}
+	err := util.Encode(content, binary.BigEndian, list.Semantic, fieldID, list.Element.Len)
+	if err != nil {
+		return err
+	}
+	if list.Element.EnterpriseId != 0 {
+		if err = util.Encode(content, binary.BigEndian, list.Element.EnterpriseId); err != nil {
+			return err
+		}
+	}
+	for _, v := range list.Values {
+		if _, err = EncodeToIEDataType(list.Element.DataType, v, content); err != nil {
+			return err
+		}
+	}
+	return encodeVariableLength(content.Bytes(), buff)
+}
+
+func encodeSubTemplateList(list *SubTemplateListValue, buff *bytes.Buffer) error {
+	content := &bytes.Buffer{}
+	err := util.Encode(content, binary.BigEndian, list.Semantic, list.TemplateID)
+	if err != nil {
+		return err
+	}
+	for _, record := range list.Records {
+		if _, err = content.Write(record.GetBuffer().Bytes()); err != nil {
+			return err
+		}
+	}
+	return encodeVariableLength(content.Bytes(), buff)
+}
+
+func encodeSubTemplateMultiList(list *SubTemplateMultiListValue, buff *bytes.Buffer) error {
+	content := &bytes.Buffer{}
+	err := util.Encode(content, binary.BigEndian, list.Semantic)
+	if err != nil {
+		return err
+	}
+	for _, entry := range list.Entries {
+		length := 4
+		for _, record := range entry.Records {
+			length = length + record.GetBuffer().Len()
+		}
+		if length > 65535 {
+			return fmt.Errorf("subTemplateMultiList entry of template %d is too long", entry.TemplateID)
+		}
+		if err = util.Encode(content, binary.BigEndian, entry.TemplateID, uint16(length)); err != nil {
+			return err
+		}
+		for _, record := range entry.Records {
+			if _, err = content.Write(record.GetBuffer().Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+	return encodeVariableLength(content.Bytes(), buff)
+}
+
+// encodeVariableLength writes the content with the variable length prefix
+// (encoding reference: https://tools.ietf.org/html/rfc7011#section-7).
+func encodeVariableLength(content []byte, buff *bytes.Buffer) error {
+	if len(content) < 255 {
+		return util.Encode(buff, binary.BigEndian, uint8(len(content)), content)
+	} else if len(content) < 65535 {
+		return util.Encode(buff, binary.BigEndian, uint8(255), uint16(len(content)), content)
+	}
+	return fmt.Errorf("value of length %d is too long for variable length encoding", len(content))
+}
+
+// decodeVariableLength returns the length in the variable length prefix.
+func decodeVariableLength(buff *bytes.Buffer) (int, error) {
+	var lengthOneByte uint8
+	if err := util.Decode(buff, binary.BigEndian, &lengthOneByte); err != nil {
+		return 0, fmt.Errorf("error when decoding variable length: %v", err)
+	}
+	if lengthOneByte < 255 {
+		return int(lengthOneByte), nil
+	}
+	var lengthTwoBytes uint16
+	if err := util.Decode(buff, binary.BigEndian, &lengthTwoBytes); err != nil {
+		return 0, fmt.Errorf("error when decoding variable length: %v", err)
+	}
+	return int(lengthTwoBytes), nil
+}
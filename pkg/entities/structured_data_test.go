@@ -0,0 +1,191 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entities
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testPortElement    = NewInfoElement("sourceTransportPort", 7, Unsigned16, 0, 2)
+	testAddressElement = NewInfoElement("sourceIPv4Address", 8, Ipv4Address, 0, 4)
+	testNameElement    = NewInfoElement("interfaceName", 82, String, 0, VariableLength)
+	testPolicyElement  = NewInfoElement("ingressNetworkPolicyName", 110, String, 56506, VariableLength)
+)
+
+type testResolver struct {
+	elements  []*InfoElement
+	templates map[uint16][]*InfoElement
+}
+
+func (r *testResolver) GetInfoElement(elementID uint16, enterpriseID uint32) (*InfoElement, error) {
+	for _, element := range r.elements {
+		if element.ElementId == elementID && element.EnterpriseId == enterpriseID {
+			return element, nil
+		}
+	}
+	return nil, fmt.Errorf("element %d with enterprise ID %d does not exist", elementID, enterpriseID)
+}
+
+func (r *testResolver) GetTemplateElements(templateID uint16) ([]*InfoElement, error) {
+	if elements, exist := r.templates[templateID]; exist {
+		return elements, nil
+	}
+	return nil, fmt.Errorf("template %d does not exist", templateID)
+}
+
+var resolver = &testResolver{
+	elements: []*InfoElement{testPortElement, testAddressElement, testNameElement, testPolicyElement},
+	templates: map[uint16][]*InfoElement{
+		300: {testAddressElement, testPortElement},
+		301: {testNameElement},
+		302: {NewInfoElement("paddingOctets", 210, OctetArray, 0, 0)},
+	},
+}
+
+func newTestDataRecord(t *testing.T, templateID uint16, values map[*InfoElement]interface{}) Record {
+	record := NewDataRecord(templateID)
+	for _, element := range resolver.templates[templateID] {
+		_, err := record.AddInfoElement(NewInfoElementWithValue(element, values[element]), false)
+		assert.Nil(t, err)
+	}
+	return record
+}
+
+func TestBasicList(t *testing.T) {
+	list := &BasicListValue{Semantic: AllOf, Element: testPortElement, Values: []interface{}{uint16(80), uint16(443)}}
+	buff := new(bytes.Buffer)
+	_, err := EncodeToIEDataType(BasicList, list, buff)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{9, 3, 0, 7, 0, 2, 0, 80, 1, 187}, buff.Bytes())
+	// Enterprise-specific elements with variable length
+	policyList := &BasicListValue{Semantic: Ordered, Element: testPolicyElement, Values: []interface{}{"allow", "deny"}}
+	policyBuff := new(bytes.Buffer)
+	_, err = EncodeToIEDataType(BasicList, policyList, policyBuff)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{20, 4, 0x80, 110, 255, 255, 0, 0, 220, 186, 5, 'a', 'l', 'l', 'o', 'w', 4, 'd', 'e', 'n', 'y'}, policyBuff.Bytes())
+
+	for _, data := range []struct {
+		buff     *bytes.Buffer
+		expected *BasicListValue
+	}{{buff, list}, {policyBuff, policyList}} {
+		length, err := decodeVariableLength(data.buff)
+		assert.Nil(t, err)
+		assert.Equal(t, data.buff.Len(), length)
+		decoded, err := DecodeStructuredData(BasicList, data.buff, resolver)
+		assert.Nil(t, err)
+		assert.Equal(t, data.expected, decoded)
+	}
+	_, err = EncodeToIEDataType(BasicList, []uint16{80}, new(bytes.Buffer))
+	assert.NotNil(t, err, "Error should be returned for value of wrong type")
+	// Element of length 0 would never consume the list
+	_, err = DecodeStructuredData(BasicList, bytes.NewBuffer([]byte{3, 0, 8, 0, 0, 1}), resolver)
+	assert.NotNil(t, err, "Error should be returned for element of length 0")
+}
+
+func TestSubTemplateList(t *testing.T) {
+	records := []Record{
+		newTestDataRecord(t, 300, map[*InfoElement]interface{}{testAddressElement: net.ParseIP("10.0.0.1"), testPortElement: uint16(80)}),
+		newTestDataRecord(t, 300, map[*InfoElement]interface{}{testAddressElement: net.ParseIP("10.0.0.2"), testPortElement: uint16(443)}),
+	}
+	list := &SubTemplateListValue{Semantic: AllOf, TemplateID: 300, Records: records}
+	buff := new(bytes.Buffer)
+	_, err := EncodeToIEDataType(SubTemplateList, list, buff)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{15, 3, 1, 44, 10, 0, 0, 1, 0, 80, 10, 0, 0, 2, 1, 187}, buff.Bytes())
+
+	buff.Next(1)
+	decoded, err := DecodeStructuredData(SubTemplateList, buff, resolver)
+	assert.Nil(t, err)
+	decodedList, ok := decoded.(*SubTemplateListValue)
+	assert.True(t, ok)
+	assert.Equal(t, AllOf, decodedList.Semantic)
+	assert.Equal(t, uint16(300), decodedList.TemplateID)
+	assert.Equal(t, 2, len(decodedList.Records))
+	port, exist := decodedList.Records[1].GetInfoElementWithValue("sourceTransportPort")
+	assert.True(t, exist)
+	assert.Equal(t, uint16(443), port.Value)
+	address, exist := decodedList.Records[1].GetInfoElementWithValue("sourceIPv4Address")
+	assert.True(t, exist)
+	assert.Equal(t, net.IP([]byte{10, 0, 0, 2}), address.Value)
+
+	// Template of the list is not known
+	_, err = DecodeStructuredData(SubTemplateList, bytes.NewBuffer([]byte{3, 1, 50, 0}), resolver)
+	assert.NotNil(t, err)
+	// Truncated record
+	_, err = DecodeStructuredData(SubTemplateList, bytes.NewBuffer([]byte{3, 1, 44, 10, 0, 0, 1, 0}), resolver)
+	assert.NotNil(t, err)
+	// Records of template 302 do not consume any content
+	_, err = DecodeStructuredData(SubTemplateList, bytes.NewBuffer([]byte{3, 1, 46, 0}), resolver)
+	assert.NotNil(t, err)
+}
+
+func TestSubTemplateMultiList(t *testing.T) {
+	list := &SubTemplateMultiListValue{
+		Semantic: OneOrMoreOf,
+		Entries: []*SubTemplateMultiListEntry{
+			{
+				TemplateID: 300,
+				Records: []Record{
+					newTestDataRecord(t, 300, map[*InfoElement]interface{}{testAddressElement: net.ParseIP("10.0.0.1"), testPortElement: uint16(80)}),
+				},
+			},
+			{
+				TemplateID: 301,
+				Records: []Record{
+					newTestDataRecord(t, 301, map[*InfoElement]interface{}{testNameElement: "eth0"}),
+					newTestDataRecord(t, 301, map[*InfoElement]interface{}{testNameElement: "eth1"}),
+				},
+			},
+		},
+	}
+	buff := new(bytes.Buffer)
+	_, err := EncodeToIEDataType(SubTemplateMultiList, list, buff)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{25, 2, 1, 44, 0, 10, 10, 0, 0, 1, 0, 80, 1, 45, 0, 14, 4, 'e', 't', 'h', '0', 4, 'e', 't', 'h', '1'}, buff.Bytes())
+
+	buff.Next(1)
+	decoded, err := DecodeStructuredData(SubTemplateMultiList, buff, resolver)
+	assert.Nil(t, err)
+	decodedList, ok := decoded.(*SubTemplateMultiListValue)
+	assert.True(t, ok)
+	assert.Equal(t, OneOrMoreOf, decodedList.Semantic)
+	assert.Equal(t, 2, len(decodedList.Entries))
+	assert.Equal(t, uint16(301), decodedList.Entries[1].TemplateID)
+	assert.Equal(t, 2, len(decodedList.Entries[1].Records))
+	name, exist := decodedList.Entries[1].Records[1].GetInfoElementWithValue("interfaceName")
+	assert.True(t, exist)
+	assert.Equal(t, "eth1", name.Value)
+
+	// Entry length longer than the list
+	_, err = DecodeStructuredData(SubTemplateMultiList, bytes.NewBuffer([]byte{2, 1, 44, 0, 20, 10, 0, 0, 1}), resolver)
+	assert.NotNil(t, err)
+}
+
+func TestDecodeToIEDataTypeWithStructuredData(t *testing.T) {
+	list := &BasicListValue{Semantic: AllOf, Element: testPortElement, Values: []interface{}{uint16(80)}}
+	v, err := DecodeToIEDataType(BasicList, list)
+	assert.Nil(t, err)
+	assert.Equal(t, list, v, "Decoded structured data should be returned as is")
+	_, err = DecodeToIEDataType(Unsigned16, list)
+	assert.NotNil(t, err)
+	_, err = DecodeToIEDataType(BasicList, bytes.NewBuffer([]byte{3, 0, 7, 0, 2, 0, 80}))
+	assert.NotNil(t, err, "Structured data should not be decoded without resolver")
+}
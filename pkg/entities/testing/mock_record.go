@@ -22,7 +22,9 @@ import (
 	bytes "bytes"
 	gomock "github.com/golang/mock/gomock"
 	entities "github.com/vmware/go-ipfix/pkg/entities"
+	net "net"
 	reflect "reflect"
+	time "time"
 )
 
 // MockRecord is a mock of Record interface
@@ -63,6 +65,22 @@ func (mr *MockRecordMockRecorder) AddInfoElement(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddInfoElement", reflect.TypeOf((*MockRecord)(nil).AddInfoElement), arg0, arg1)
 }
 
+// GetBool mocks base method
+func (m *MockRecord) GetBool(arg0 string) (bool, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBool", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBool indicates an expected call of GetBool
+func (mr *MockRecordMockRecorder) GetBool(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBool", reflect.TypeOf((*MockRecord)(nil).GetBool), arg0)
+}
+
 // GetBuffer mocks base method
 func (m *MockRecord) GetBuffer() *bytes.Buffer {
 	m.ctrl.T.Helper()
@@ -91,6 +109,38 @@ func (mr *MockRecordMockRecorder) GetFieldCount() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFieldCount", reflect.TypeOf((*MockRecord)(nil).GetFieldCount))
 }
 
+// GetFloat64 mocks base method
+func (m *MockRecord) GetFloat64(arg0 string) (float64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFloat64", arg0)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFloat64 indicates an expected call of GetFloat64
+func (mr *MockRecordMockRecorder) GetFloat64(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFloat64", reflect.TypeOf((*MockRecord)(nil).GetFloat64), arg0)
+}
+
+// GetIP mocks base method
+func (m *MockRecord) GetIP(arg0 string) (net.IP, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIP", arg0)
+	ret0, _ := ret[0].(net.IP)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetIP indicates an expected call of GetIP
+func (mr *MockRecordMockRecorder) GetIP(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIP", reflect.TypeOf((*MockRecord)(nil).GetIP), arg0)
+}
+
 // GetInfoElementWithValue mocks base method
 func (m *MockRecord) GetInfoElementWithValue(arg0 string) (*entities.InfoElementWithValue, bool) {
 	m.ctrl.T.Helper()
@@ -106,6 +156,38 @@ func (mr *MockRecordMockRecorder) GetInfoElementWithValue(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInfoElementWithValue", reflect.TypeOf((*MockRecord)(nil).GetInfoElementWithValue), arg0)
 }
 
+// GetInt64 mocks base method
+func (m *MockRecord) GetInt64(arg0 string) (int64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInt64", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetInt64 indicates an expected call of GetInt64
+func (mr *MockRecordMockRecorder) GetInt64(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInt64", reflect.TypeOf((*MockRecord)(nil).GetInt64), arg0)
+}
+
+// GetMAC mocks base method
+func (m *MockRecord) GetMAC(arg0 string) (net.HardwareAddr, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMAC", arg0)
+	ret0, _ := ret[0].(net.HardwareAddr)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMAC indicates an expected call of GetMAC
+func (mr *MockRecordMockRecorder) GetMAC(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMAC", reflect.TypeOf((*MockRecord)(nil).GetMAC), arg0)
+}
+
 // GetMinDataRecordLen mocks base method
 func (m *MockRecord) GetMinDataRecordLen() uint16 {
 	m.ctrl.T.Helper()
@@ -134,6 +216,36 @@ func (mr *MockRecordMockRecorder) GetOrderedElementList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderedElementList", reflect.TypeOf((*MockRecord)(nil).GetOrderedElementList))
 }
 
+// GetScopeFieldCount mocks base method
+func (m *MockRecord) GetScopeFieldCount() uint16 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScopeFieldCount")
+	ret0, _ := ret[0].(uint16)
+	return ret0
+}
+
+// GetScopeFieldCount indicates an expected call of GetScopeFieldCount
+func (mr *MockRecordMockRecorder) GetScopeFieldCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScopeFieldCount", reflect.TypeOf((*MockRecord)(nil).GetScopeFieldCount))
+}
+
+// GetString mocks base method
+func (m *MockRecord) GetString(arg0 string) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetString", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetString indicates an expected call of GetString
+func (mr *MockRecordMockRecorder) GetString(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetString", reflect.TypeOf((*MockRecord)(nil).GetString), arg0)
+}
+
 // GetTemplateID mocks base method
 func (m *MockRecord) GetTemplateID() uint16 {
 	m.ctrl.T.Helper()
@@ -148,6 +260,38 @@ func (mr *MockRecordMockRecorder) GetTemplateID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateID", reflect.TypeOf((*MockRecord)(nil).GetTemplateID))
 }
 
+// GetTime mocks base method
+func (m *MockRecord) GetTime(arg0 string) (time.Time, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTime", arg0)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTime indicates an expected call of GetTime
+func (mr *MockRecordMockRecorder) GetTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTime", reflect.TypeOf((*MockRecord)(nil).GetTime), arg0)
+}
+
+// GetUint64 mocks base method
+func (m *MockRecord) GetUint64(arg0 string) (uint64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUint64", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUint64 indicates an expected call of GetUint64
+func (mr *MockRecordMockRecorder) GetUint64(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUint64", reflect.TypeOf((*MockRecord)(nil).GetUint64), arg0)
+}
+
 // PrepareRecord mocks base method
 func (m *MockRecord) PrepareRecord() (uint16, error) {
 	m.ctrl.T.Helper()
@@ -48,6 +48,20 @@ func (m *MockSet) EXPECT() *MockSetMockRecorder {
 	return m.recorder
 }
 
+// AddOptionsRecord mocks base method
+func (m *MockSet) AddOptionsRecord(arg0 []*entities.InfoElementWithValue, arg1 uint16, arg2 uint16) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOptionsRecord", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOptionsRecord indicates an expected call of AddOptionsRecord
+func (mr *MockSetMockRecorder) AddOptionsRecord(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOptionsRecord", reflect.TypeOf((*MockSet)(nil).AddOptionsRecord), arg0, arg1, arg2)
+}
+
 // AddRecord mocks base method
 func (m *MockSet) AddRecord(arg0 []*entities.InfoElementWithValue, arg1 uint16) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,237 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/klog"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+)
+
+// OverflowPolicy decides how SendSet handles data sets when the send queue is
+// full.
+type OverflowPolicy int
+
+const (
+	// Block waits for space in the queue.
+	Block OverflowPolicy = iota
+	// DropNewest drops the data set passed to SendSet.
+	DropNewest
+	// DropOldest drops the oldest data set in the queue to make space for the
+	// data set passed to SendSet. It waits for space in the queue if there are
+	// only template sets in the queue.
+	DropOldest
+)
+
+const defaultSendQueueSize = 1024
+
+// AsyncSendStats are the statistics of the send queue when async send is
+// enabled.
+type AsyncSendStats struct {
+	// QueuedSets is the number of sets in the queue.
+	QueuedSets int
+	// DroppedSets and DroppedRecords are the numbers of data sets and their
+	// records which are dropped because the queue is full, or because the
+	// exporting process is closed before they are sent.
+	DroppedSets    uint64
+	DroppedRecords uint64
+	// FailedSets is the number of sets which the writer fails to send.
+	FailedSets uint64
+}
+
+// sendQueue is the bounded queue of sets in front of the connection, which
+// are sent by the writer goroutine.
+type sendQueue struct {
+	// cond is broadcast when sets are added or removed, and when the queue is
+	// closed.
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	sets    []pendingSet
+	size    int
+	policy  OverflowPolicy
+	sending bool
+	closed  bool
+	stats   AsyncSendStats
+	// writerDone is closed when the writer goroutine exits.
+	writerDone chan struct{}
+}
+
+func newSendQueue(size int, policy OverflowPolicy) *sendQueue {
+	if size <= 0 {
+		size = defaultSendQueueSize
+	}
+	q := &sendQueue{
+		sets:       make([]pendingSet, 0, size),
+		size:       size,
+		policy:     policy,
+		writerDone: make(chan struct{}),
+	}
+	q.cond = sync.NewCond(&q.mutex)
+	return q
+}
+
+// add adds the set to the queue based on the overflow policy.
+func (q *sendQueue) add(s pendingSet) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	isData := s.set.GetSetType() == entities.Data
+	for !q.closed && len(q.sets) >= q.size {
+		if isData && q.policy == DropNewest {
+			q.drop(s)
+			return nil
+		}
+		if isData && q.policy == DropOldest {
+			if i := q.getOldestDataSet(); i >= 0 {
+				q.drop(q.sets[i])
+				q.sets = append(q.sets[:i], q.sets[i+1:]...)
+				break
+			}
+		}
+		q.cond.Wait()
+	}
+	if q.closed {
+		return fmt.Errorf("exporting process is closed")
+	}
+	q.sets = append(q.sets, s)
+	q.cond.Broadcast()
+	return nil
+}
+
+// getOldestDataSet returns the index of the oldest data set in the queue, or
+// -1 if there are only template sets. The caller must hold mutex.
+func (q *sendQueue) getOldestDataSet() int {
+	for i, s := range q.sets {
+		if s.set.GetSetType() == entities.Data {
+			return i
+		}
+	}
+	return -1
+}
+
+// drop counts the dropped set. The caller must hold mutex.
+func (q *sendQueue) drop(s pendingSet) {
+	if s.set.GetSetType() != entities.Data {
+		return
+	}
+	q.stats.DroppedSets++
+	q.stats.DroppedRecords += uint64(s.set.GetNumberOfRecords())
+}
+
+// next removes the first set from the queue. It waits for sets to be added,
+// and returns false when the queue is closed.
+func (q *sendQueue) next() (pendingSet, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.sending = false
+	q.cond.Broadcast()
+	for !q.closed && len(q.sets) == 0 {
+		q.cond.Wait()
+	}
+	if q.closed {
+		return pendingSet{}, false
+	}
+	s := q.sets[0]
+	q.sets = q.sets[1:]
+	q.sending = true
+	q.cond.Broadcast()
+	return s, true
+}
+
+// drain waits until all queued sets are sent, or ctx is done.
+func (q *sendQueue) drain(ctx context.Context) error {
+	drained := make(chan struct{})
+	go func() {
+		q.mutex.Lock()
+		defer q.mutex.Unlock()
+		for !q.closed && (len(q.sets) > 0 || q.sending) {
+			q.cond.Wait()
+		}
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close drops the queued sets, and waits for the writer goroutine to exit.
+func (q *sendQueue) close() {
+	q.mutex.Lock()
+	if !q.closed {
+		if len(q.sets) > 0 {
+			klog.Warningf("Dropping %d queued sets as the exporting process is closed", len(q.sets))
+		}
+		for _, s := range q.sets {
+			q.drop(s)
+		}
+		q.sets = nil
+		q.closed = true
+		q.cond.Broadcast()
+	}
+	q.mutex.Unlock()
+	<-q.writerDone
+}
+
+func (q *sendQueue) getStats() AsyncSendStats {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	stats := q.stats
+	stats.QueuedSets = len(q.sets)
+	return stats
+}
+
+// runWriter sends the queued sets until the queue is closed.
+func (ep *ExportingProcess) runWriter() {
+	defer close(ep.sendQueue.writerDone)
+	for {
+		s, ok := ep.sendQueue.next()
+		if !ok {
+			return
+		}
+		if _, err := ep.writeSet(s.domain, s.set); err != nil {
+			klog.Errorf("Error when sending set to collector %s: %v", ep.collectorAddr.String(), err)
+			ep.sendQueue.mutex.Lock()
+			ep.sendQueue.stats.FailedSets++
+			ep.sendQueue.mutex.Unlock()
+		}
+	}
+}
+
+// GetAsyncSendStats returns the statistics of the send queue. All statistics
+// are 0 if async send is not enabled.
+func (ep *ExportingProcess) GetAsyncSendStats() AsyncSendStats {
+	if ep.sendQueue == nil {
+		return AsyncSendStats{}
+	}
+	return ep.sendQueue.getStats()
+}
+
+// Close sends the queued sets if async send is enabled, and closes the
+// connection to the collector. If ctx is done before the queued sets are
+// sent, the remaining sets are dropped and ctx.Err() is returned.
+func (ep *ExportingProcess) Close(ctx context.Context) error {
+	var err error
+	if ep.sendQueue != nil {
+		err = ep.sendQueue.drain(ctx)
+	}
+	ep.CloseConnToCollector()
+	return err
+}
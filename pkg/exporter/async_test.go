@@ -0,0 +1,136 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+	"github.com/vmware/go-ipfix/pkg/registry"
+)
+
+func createDataSetWithAddress(templateID uint16, address string) entities.Set {
+	sourceAddress, _ := registry.GetInfoElement("sourceIPv4Address", registry.IANAEnterpriseID)
+	dataSet := entities.NewSet(entities.Data, templateID, false)
+	dataSet.AddRecord([]*entities.InfoElementWithValue{entities.NewInfoElementWithValue(sourceAddress, net.ParseIP(address))}, templateID)
+	return dataSet
+}
+
+// initAsyncExportingProcess initializes an exporting process with async send
+// to a local TCP server, and sends a template set, which is held by the writer
+// until msgMutex is unlocked.
+func initAsyncExportingProcess(t *testing.T, policy OverflowPolicy) (*ExportingProcess, uint16, chan []byte) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Got error when creating a local server: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	_, msgCh := acceptTCPMessages(listener)
+	exporter, err := InitExportingProcess(ExporterInput{
+		CollectorAddr:       listener.Addr(),
+		ObservationDomainID: 1,
+		EnableAsyncSend:     true,
+		SendQueueSize:       2,
+		OverflowPolicy:      policy,
+	})
+	if err != nil {
+		t.Fatalf("Got error when connecting to local server %s: %v", listener.Addr().String(), err)
+	}
+	templateID := exporter.NewTemplateID()
+	templateSet, _ := createTemplateAndDataSet(templateID)
+	exporter.msgMutex.Lock()
+	_, err = exporter.SendSet(templateSet)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return exporter.GetAsyncSendStats().QueuedSets == 0
+	}, time.Second, time.Millisecond)
+	return exporter, templateID, msgCh
+}
+
+func assertReceivedAddresses(t *testing.T, msgCh chan []byte, addresses ...string) {
+	assert.Equal(t, entities.TemplateSetID, binary.BigEndian.Uint16((<-msgCh)[16:18]))
+	for _, address := range addresses {
+		msg := <-msgCh
+		assert.Equal(t, net.ParseIP(address).To4(), net.IP(msg[20:24]))
+	}
+}
+
+func TestExportingProcess_AsyncSendWithOverflowPolicy(t *testing.T) {
+	t.Run("Block", func(t *testing.T) {
+		exporter, templateID, msgCh := initAsyncExportingProcess(t, Block)
+		for _, address := range []string{"10.0.0.1", "10.0.0.2"} {
+			_, err := exporter.SendSet(createDataSetWithAddress(templateID, address))
+			assert.NoError(t, err)
+		}
+		sent := make(chan error)
+		go func() {
+			_, err := exporter.SendSet(createDataSetWithAddress(templateID, "10.0.0.3"))
+			sent <- err
+		}()
+		select {
+		case <-sent:
+			t.Errorf("SendSet should block when the queue is full")
+		case <-time.After(50 * time.Millisecond):
+		}
+		exporter.msgMutex.Unlock()
+		assert.NoError(t, <-sent)
+		assert.NoError(t, exporter.Close(context.Background()))
+		assertReceivedAddresses(t, msgCh, "10.0.0.1", "10.0.0.2", "10.0.0.3")
+		assert.Equal(t, AsyncSendStats{}, exporter.GetAsyncSendStats())
+	})
+	t.Run("DropNewest", func(t *testing.T) {
+		exporter, templateID, msgCh := initAsyncExportingProcess(t, DropNewest)
+		for _, address := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+			_, err := exporter.SendSet(createDataSetWithAddress(templateID, address))
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, AsyncSendStats{QueuedSets: 2, DroppedSets: 1, DroppedRecords: 1}, exporter.GetAsyncSendStats())
+		exporter.msgMutex.Unlock()
+		assert.NoError(t, exporter.Close(context.Background()))
+		assertReceivedAddresses(t, msgCh, "10.0.0.1", "10.0.0.2")
+	})
+	t.Run("DropOldest", func(t *testing.T) {
+		exporter, templateID, msgCh := initAsyncExportingProcess(t, DropOldest)
+		for _, address := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+			_, err := exporter.SendSet(createDataSetWithAddress(templateID, address))
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, AsyncSendStats{QueuedSets: 2, DroppedSets: 1, DroppedRecords: 1}, exporter.GetAsyncSendStats())
+		exporter.msgMutex.Unlock()
+		assert.NoError(t, exporter.Close(context.Background()))
+		assertReceivedAddresses(t, msgCh, "10.0.0.2", "10.0.0.3")
+	})
+}
+
+func TestExportingProcess_AsyncSendCloseWithTimeout(t *testing.T) {
+	exporter, templateID, msgCh := initAsyncExportingProcess(t, Block)
+	_, err := exporter.SendSet(createDataSetWithAddress(templateID, "10.0.0.1"))
+	assert.NoError(t, err)
+	// The writer is unblocked after the queued sets are dropped.
+	time.AfterFunc(100*time.Millisecond, exporter.msgMutex.Unlock)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, exporter.Close(ctx))
+	assert.Equal(t, AsyncSendStats{DroppedSets: 1, DroppedRecords: 1}, exporter.GetAsyncSendStats())
+	assertReceivedAddresses(t, msgCh)
+	_, err = exporter.SendSet(createDataSetWithAddress(templateID, "10.0.0.2"))
+	assert.Error(t, err)
+}
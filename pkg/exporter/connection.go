@@ -0,0 +1,238 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"k8s.io/klog"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+)
+
+// ConnectionState is the state of the connection to the collector, which is
+// reported to ExporterInput.ConnectionStateHandler.
+type ConnectionState int
+
+const (
+	// Connected means the connection is reestablished, and the templates are
+	// sent again.
+	Connected ConnectionState = iota
+	// Disconnected means the connection is broken, and the exporting process is
+	// reconnecting to the collector.
+	Disconnected
+)
+
+const (
+	defaultReconnectBaseDelay = time.Second
+	defaultReconnectMaxDelay  = time.Minute
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case Connected:
+		return "Connected"
+	case Disconnected:
+		return "Disconnected"
+	}
+	return fmt.Sprintf("ConnectionState(%d)", int(s))
+}
+
+// monitorConnection detects that a TCP connection is closed by the collector.
+// Collectors do not send data to exporters, so reading from the connection only
+// returns when the connection is broken. Broken UDP connections are detected
+// when sending messages.
+func (ep *ExportingProcess) monitorConnection(conn net.Conn) {
+	if ep.collectorAddr.Network() != "tcp" {
+		return
+	}
+	buff := make([]byte, 1)
+	for {
+		if _, err := conn.Read(buff); err != nil {
+			ep.msgMutex.Lock()
+			ep.handleConnError(conn, err)
+			ep.msgMutex.Unlock()
+			return
+		}
+	}
+}
+
+// handleConnError closes the broken connection and starts reconnecting to the
+// collector. The caller must hold msgMutex.
+func (ep *ExportingProcess) handleConnError(conn net.Conn, connErr error) {
+	// The connection is closed by CloseConnToCollector, or is already replaced.
+	if ep.isClosed || ep.connToCollector != conn {
+		return
+	}
+	conn.Close()
+	ep.connToCollector = nil
+	if ep.isReconnecting {
+		return
+	}
+	klog.Warningf("Connection to collector %s is broken: %v. Reconnecting to the collector", ep.collectorAddr.String(), connErr)
+	ep.isReconnecting = true
+	go ep.reconnectToCollector(connErr)
+}
+
+// reconnectToCollector creates a new connection to the collector with
+// exponential backoff until it succeeds or the exporting process is closed.
+func (ep *ExportingProcess) reconnectToCollector(connErr error) {
+	ep.notifyConnectionState(Disconnected, connErr)
+	delay := ep.reconnectBaseDelay
+	for {
+		select {
+		case <-ep.templateRefCh:
+			return
+		case <-time.After(delay):
+		}
+		conn, err := ep.dial()
+		if err == nil {
+			if err = ep.resumeConnection(conn); err == nil {
+				klog.Infof("Reconnected to collector %s", ep.collectorAddr.String())
+				ep.notifyConnectionState(Connected, nil)
+				return
+			}
+		}
+		delay = delay * 2
+		if delay > ep.reconnectMaxDelay {
+			delay = ep.reconnectMaxDelay
+		}
+		klog.Warningf("Cannot reconnect to collector %s: %v. Retrying in %v", ep.collectorAddr.String(), err, delay)
+	}
+}
+
+// resumeConnection sends all templates of every observation domain with their
+// type information, followed by the pending data sets, on the new connection.
+func (ep *ExportingProcess) resumeConnection(conn net.Conn) error {
+	domains := ep.getObservationDomains()
+	templateSets := make([][]entities.Set, len(domains))
+	for i, d := range domains {
+		templateSets[i] = d.getTemplateSets()
+	}
+
+	ep.msgMutex.Lock()
+	defer ep.msgMutex.Unlock()
+	if ep.isClosed {
+		conn.Close()
+		return fmt.Errorf("exporting process is closed")
+	}
+	ep.connToCollector = conn
+	for i, d := range domains {
+		for _, templateSet := range templateSets[i] {
+			if err := ep.resendTemplateSetLocked(d, templateSet); err != nil {
+				ep.handleConnError(conn, err)
+				return err
+			}
+		}
+	}
+	if err := ep.sendPendingSetsLocked(); err != nil {
+		ep.handleConnError(conn, err)
+		return err
+	}
+	ep.isReconnecting = false
+	go ep.monitorConnection(conn)
+	return nil
+}
+
+// resendTemplateSetLocked writes the template set of the observation domain on
+// the new connection, after the type information of its elements. The caller
+// must hold msgMutex.
+func (ep *ExportingProcess) resendTemplateSetLocked(d *ObservationDomain, templateSet entities.Set) error {
+	if ep.sendTypeInfo {
+		typeInfoSets, err := d.getTypeInformationSets(templateSet)
+		if err != nil {
+			return err
+		}
+		for _, typeInfoSet := range typeInfoSets {
+			// The type information template is new if no type information
+			// was sent before the connection was broken.
+			if typeInfoSet.GetSetType() == entities.OptionsTemplate {
+				record := typeInfoSet.GetRecords()[0]
+				d.updateTemplate(record.GetTemplateID(), record.GetOrderedElementList(), record.GetMinDataRecordLen(), record.GetScopeFieldCount())
+			}
+			typeInfoSet.UpdateLenInHeader()
+			if _, err := ep.writeSetLocked(d, typeInfoSet); err != nil {
+				return err
+			}
+		}
+		d.setElementsDescribed(templateSet)
+	}
+	templateSet.UpdateLenInHeader()
+	_, err := ep.writeSetLocked(d, templateSet)
+	return err
+}
+
+// sendPendingSetsLocked sends the pending data sets on the new connection. Sets
+// are removed from pendingSets once the messages which contain them are sent,
+// so that they are not sent again on the next connection if the new connection
+// is broken as well. A set which is split into multiple messages is kept until
+// its last message is sent. The caller must hold msgMutex.
+func (ep *ExportingProcess) sendPendingSetsLocked() error {
+	// Sets before index sent are known to be sent.
+	sent := 0
+	for i, s := range ep.pendingSets {
+		msgCount := ep.sentMsgCount
+		s.set.UpdateLenInHeader()
+		_, err := ep.writeSetLocked(s.domain, s.set)
+		if err == nil && ep.msgBuffer.Len() == 0 {
+			sent = i + 1
+		} else if ep.sentMsgCount != msgCount {
+			// The sets buffered before this set were sent.
+			sent = i
+		}
+		if err != nil {
+			ep.pendingSets = ep.pendingSets[sent:]
+			return err
+		}
+	}
+	if _, err := ep.flushMsg(); err != nil {
+		ep.pendingSets = ep.pendingSets[sent:]
+		return err
+	}
+	ep.pendingSets = nil
+	return nil
+}
+
+// addPendingSet keeps the data set of the observation domain to be sent after
+// reconnecting. Template sets are not kept, as all templates are sent again
+// after reconnecting. The caller must hold msgMutex.
+func (ep *ExportingProcess) addPendingSet(d *ObservationDomain, set entities.Set) error {
+	if ep.isClosed {
+		return fmt.Errorf("exporting process is closed")
+	}
+	if set.GetSetType() != entities.Data {
+		return nil
+	}
+	if len(ep.pendingSets) >= ep.maxPendingSets {
+		return fmt.Errorf("connection to collector %s is broken, and the data set is dropped", ep.collectorAddr.String())
+	}
+	ep.pendingSets = append(ep.pendingSets, pendingSet{d, set})
+	return nil
+}
+
+// isConnected returns false while reconnecting to the collector.
+func (ep *ExportingProcess) isConnected() bool {
+	ep.msgMutex.Lock()
+	defer ep.msgMutex.Unlock()
+	return ep.connToCollector != nil
+}
+
+func (ep *ExportingProcess) notifyConnectionState(state ConnectionState, err error) {
+	if ep.stateHandler != nil {
+		ep.stateHandler(state, err)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+)
+
+// ObservationDomain is the handle to send sets in an observation domain of the
+// exporting process. Observation domains share the connection to the
+// collector, while templates, template IDs and sequence numbers are maintained
+// for each observation domain (RFC7011 section 3.1 and 8).
+type ObservationDomain struct {
+	ep          *ExportingProcess
+	obsDomainID uint32
+	templateIDs *templateIDAllocator
+	// mutex protects the templates and the described elements.
+	mutex              sync.Mutex
+	templatesMap       map[uint16]templateValue
+	typeInfoTemplateID uint16
+	describedElements  map[elementKey]bool
+	// seqNumber is protected by msgMutex of the exporting process.
+	seqNumber uint32
+}
+
+// pendingSet is a data set kept while reconnecting to the collector.
+type pendingSet struct {
+	domain *ObservationDomain
+	set    entities.Set
+}
+
+// GetObservationDomain returns the handle of the observation domain, which is
+// created if it does not exist.
+func (ep *ExportingProcess) GetObservationDomain(obsDomainID uint32) *ObservationDomain {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	if d, exist := ep.domains[obsDomainID]; exist {
+		return d
+	}
+	templateIDs := newTemplateIDAllocator()
+	if ep.getTemplateIDAllocator != nil {
+		templateIDs = ep.getTemplateIDAllocator(obsDomainID)
+	}
+	d := &ObservationDomain{
+		ep:                ep,
+		obsDomainID:       obsDomainID,
+		templateIDs:       templateIDs,
+		templatesMap:      make(map[uint16]templateValue),
+		describedElements: make(map[elementKey]bool),
+	}
+	ep.domains[obsDomainID] = d
+	return d
+}
+
+// getObservationDomains returns all observation domains ordered by ID.
+func (ep *ExportingProcess) getObservationDomains() []*ObservationDomain {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	domains := make([]*ObservationDomain, 0, len(ep.domains))
+	for _, d := range ep.domains {
+		domains = append(domains, d)
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		return domains[i].obsDomainID < domains[j].obsDomainID
+	})
+	return domains
+}
+
+// GetObservationDomainID returns the ID of the observation domain.
+func (d *ObservationDomain) GetObservationDomainID() uint32 {
+	return d.obsDomainID
+}
+
+// NewTemplateID is called to get ID when creating new template record in the
+// observation domain. It returns 0 if all template IDs are in use.
+func (d *ObservationDomain) NewTemplateID() uint16 {
+	return d.templateIDs.newTemplateID()
+}
+
+// SendSet sends the set to the collector in the observation domain. Sets of
+// different observation domains are never sent in the same message. Templates
+// which are redefined by a template set are withdrawn first over TCP.
+func (d *ObservationDomain) SendSet(set entities.Set) (int, error) {
+	setType := set.GetSetType()
+	if d.ep.isWithdrawalAllowed() && (setType == entities.Template || setType == entities.OptionsTemplate) {
+		for _, withdrawalSet := range d.getRedefinitionWithdrawalSets(set) {
+			if _, err := d.sendSet(withdrawalSet); err != nil {
+				return 0, fmt.Errorf("error when sending template withdrawal: %v", err)
+			}
+		}
+	}
+	if d.ep.sendTypeInfo && (setType == entities.Template || setType == entities.OptionsTemplate) {
+		// Type information needs to be received before the templates.
+		typeInfoSets, err := d.getTypeInformationSets(set)
+		if err != nil {
+			return 0, err
+		}
+		for _, typeInfoSet := range typeInfoSets {
+			if _, err := d.sendSet(typeInfoSet); err != nil {
+				return 0, fmt.Errorf("error when sending type information: %v", err)
+			}
+		}
+		d.setElementsDescribed(set)
+	}
+	return d.sendSet(set)
+}
+
+func (d *ObservationDomain) sendSet(set entities.Set) (int, error) {
+	if err := d.prepareSet(set); err != nil {
+		return 0, err
+	}
+	if d.ep.sendQueue != nil {
+		return 0, d.ep.sendQueue.add(pendingSet{d, set})
+	}
+	return d.ep.writeSet(d, set)
+}
+
+// prepareSet updates the template map with template sets, and checks the
+// records of data sets against their templates.
+func (d *ObservationDomain) prepareSet(set entities.Set) error {
+	// Iterate over all records in the set.
+	setType := set.GetSetType()
+	for _, record := range set.GetRecords() {
+		if setType == entities.Template || setType == entities.OptionsTemplate {
+			// Withdrawal records are sent by WithdrawTemplate.
+			if record.GetFieldCount() == 0 {
+				continue
+			}
+			if record.GetTemplateID() < entities.MinDataSetID {
+				return fmt.Errorf("template ID %d is not valid", record.GetTemplateID())
+			}
+			d.updateTemplate(record.GetTemplateID(), record.GetOrderedElementList(), record.GetMinDataRecordLen(), record.GetScopeFieldCount())
+		} else if setType == entities.Data {
+			err := d.dataRecSanityCheck(record)
+			if err != nil {
+				return fmt.Errorf("error when doing sanity check:%v", err)
+			}
+		}
+	}
+
+	// Update the length in set header before sending the message.
+	set.UpdateLenInHeader()
+	return nil
+}
+
+// getMinDataRecLen returns the minimum data record length of the template.
+func (d *ObservationDomain) getMinDataRecLen(templateID uint16) int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return int(d.templatesMap[templateID].minDataRecLen)
+}
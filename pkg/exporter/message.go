@@ -0,0 +1,206 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"k8s.io/klog"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+)
+
+const (
+	setHeaderLen = 4
+	// defaultMaxDelay is the default maximum time a set is buffered before it
+	// is sent.
+	defaultMaxDelay = time.Second
+)
+
+// Flush sends the buffered sets in a message. It returns the number of bytes
+// sent, which is 0 if there are no buffered sets.
+func (ep *ExportingProcess) Flush() (int, error) {
+	ep.msgMutex.Lock()
+	defer ep.msgMutex.Unlock()
+	return ep.flushMsg()
+}
+
+// writeSet writes the set to the message buffer. Sets which do not fit in a
+// message are split by records into multiple sets. Messages are sent when they
+// are full, and after the set if buffering is disabled. It returns the number
+// of bytes sent.
+func (ep *ExportingProcess) writeSet(d *ObservationDomain, set entities.Set) (int, error) {
+	ep.msgMutex.Lock()
+	defer ep.msgMutex.Unlock()
+	return ep.writeSetLocked(d, set)
+}
+
+// writeSetLocked writes the set of the observation domain to the message
+// buffer. The caller must hold msgMutex.
+func (ep *ExportingProcess) writeSetLocked(d *ObservationDomain, set entities.Set) (int, error) {
+	if ep.connToCollector == nil {
+		return 0, ep.addPendingSet(d, set)
+	}
+	setBytes := set.GetBuffer().Bytes()
+	setID := binary.BigEndian.Uint16(setBytes[0:2])
+	maxSetLen := ep.GetMsgSizeLimit() - entities.MsgHeaderLength
+	bytesSent := 0
+	if len(setBytes) <= maxSetLen {
+		n, err := ep.appendSet(d, set, setID, setBytes[setHeaderLen:], set.GetNumberOfRecords())
+		bytesSent += n
+		if err != nil {
+			return bytesSent, err
+		}
+	} else {
+		content := make([]byte, 0, maxSetLen)
+		var numRecords uint32
+		for _, record := range set.GetRecords() {
+			recordBytes := record.GetBuffer().Bytes()
+			if setHeaderLen+len(recordBytes) > maxSetLen {
+				return bytesSent, fmt.Errorf("record of length %d exceeds the message size limit %d", len(recordBytes), ep.GetMsgSizeLimit())
+			}
+			// Fill the remaining space of the message before starting a new one.
+			if numRecords > 0 && ep.msgBuffer.Len()+setHeaderLen+len(content)+len(recordBytes) > maxSetLen {
+				n, err := ep.appendSet(d, set, setID, content, numRecords)
+				bytesSent += n
+				if err != nil {
+					return bytesSent, err
+				}
+				content = content[:0]
+				numRecords = 0
+			}
+			if numRecords == 0 && ep.msgBuffer.Len()+setHeaderLen+len(recordBytes) > maxSetLen {
+				n, err := ep.flushMsg()
+				bytesSent += n
+				if err != nil {
+					return bytesSent, err
+				}
+			}
+			content = append(content, recordBytes...)
+			numRecords++
+		}
+		if numRecords > 0 {
+			n, err := ep.appendSet(d, set, setID, content, numRecords)
+			bytesSent += n
+			if err != nil {
+				return bytesSent, err
+			}
+		}
+	}
+	if !ep.isBuffered {
+		n, err := ep.flushMsg()
+		bytesSent += n
+		if err != nil {
+			return bytesSent, err
+		}
+	}
+	return bytesSent, nil
+}
+
+// appendSet appends a set with the given records to the message buffer. The
+// buffered message is sent first if the set does not fit in it, or if it
+// belongs to another observation domain. Buffered sets are padded to 4-byte
+// boundaries (RFC7011 section 3.3.1).
+func (ep *ExportingProcess) appendSet(d *ObservationDomain, set entities.Set, setID uint16, content []byte, numRecords uint32) (int, error) {
+	bytesSent := 0
+	maxSetLen := ep.GetMsgSizeLimit() - entities.MsgHeaderLength
+	setLen := setHeaderLen + len(content)
+	if ep.msgBuffer.Len() > 0 && (ep.msgBuffer.Len()+setLen > maxSetLen || ep.msgDomain != d) {
+		n, err := ep.flushMsg()
+		bytesSent += n
+		if err != nil {
+			return bytesSent, err
+		}
+	}
+	paddingLen := 0
+	if ep.isBuffered {
+		paddingLen = (4 - setLen%4) % 4
+		// Padding must be shorter than any data record, and must fit in the
+		// message.
+		if ep.msgBuffer.Len()+setLen+paddingLen > maxSetLen || (set.GetSetType() == entities.Data && paddingLen >= d.getMinDataRecLen(setID)) {
+			paddingLen = 0
+		}
+	}
+	ep.msgDomain = d
+	header := make([]byte, setHeaderLen)
+	binary.BigEndian.PutUint16(header[0:2], setID)
+	binary.BigEndian.PutUint16(header[2:4], uint16(setLen+paddingLen))
+	ep.msgBuffer.Write(header)
+	ep.msgBuffer.Write(content)
+	ep.msgBuffer.Write(make([]byte, paddingLen))
+	if set.GetSetType() == entities.Data {
+		ep.msgDataRecords += numRecords
+	}
+	if ep.isBuffered && ep.flushTimer == nil && ep.maxDelay > 0 {
+		ep.flushTimer = time.AfterFunc(ep.maxDelay, func() {
+			if _, err := ep.Flush(); err != nil {
+				klog.Errorf("Error when sending buffered sets: %v", err)
+			}
+		})
+	}
+	return bytesSent, nil
+}
+
+// flushMsg sends the buffered sets in a message. The caller must hold msgMutex.
+func (ep *ExportingProcess) flushMsg() (int, error) {
+	if ep.flushTimer != nil {
+		ep.flushTimer.Stop()
+		ep.flushTimer = nil
+	}
+	if ep.msgBuffer.Len() == 0 || ep.connToCollector == nil {
+		return 0, nil
+	}
+	// The buffered sets are dropped if the message cannot be sent.
+	defer func() {
+		ep.msgBuffer.Reset()
+		ep.msgDataRecords = 0
+	}()
+
+	msg := entities.NewMessage(false)
+	// Create the header in the IPFIX message.
+	_, err := msg.CreateHeader()
+	if err != nil {
+		return 0, fmt.Errorf("error when creating header: %v", err)
+	}
+	msgLen := msg.GetMsgBufferLen() + ep.msgBuffer.Len()
+	// Set the fields in the message header.
+	// IPFIX version number is 10.
+	// https://www.iana.org/assignments/ipfix/ipfix.xhtml#ipfix-version-numbers
+	msg.SetVersion(10)
+	msg.SetObsDomainID(ep.msgDomain.obsDomainID)
+	msg.SetMessageLen(uint16(msgLen))
+	msg.SetExportTime(uint32(time.Now().Unix()))
+	// Sequence number is the number of data records sent before this message
+	// in the observation domain (RFC7011 section 3.1).
+	msg.SetSequenceNum(ep.msgDomain.seqNumber)
+
+	bytesSlice := append(msg.GetMsgBuffer().Bytes(), ep.msgBuffer.Bytes()...)
+	// Send the message on the exporter connection.
+	bytesSent, err := ep.connToCollector.Write(bytesSlice)
+	if err != nil {
+		if ep.reconnect {
+			ep.handleConnError(ep.connToCollector, err)
+		}
+		return bytesSent, fmt.Errorf("error when sending message on the connection: %v", err)
+	} else if bytesSent != msgLen {
+		return bytesSent, fmt.Errorf("could not send the complete message on the connection")
+	}
+	// Data records of the message are counted only once it has been sent.
+	ep.msgDomain.seqNumber = ep.msgDomain.seqNumber + ep.msgDataRecords
+	ep.sentMsgCount++
+	return bytesSent, nil
+}
@@ -0,0 +1,350 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/klog"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+)
+
+// DestinationMode is how a MultiExportingProcess sends sets to its
+// destinations.
+type DestinationMode int
+
+const (
+	// ReplicateToAll sends every set to all destinations.
+	ReplicateToAll DestinationMode = iota
+	// Failover sends sets to the active destination, which is the first
+	// destination initially. When sending to the active destination fails, the
+	// next destination becomes active, and all templates are sent to it before
+	// the set. Failures of UDP destinations are only detected when sending
+	// returns error, e.g. when the collector is unreachable. Async send cannot
+	// be enabled for the destinations, as failures need to be detected by
+	// SendSet.
+	Failover
+)
+
+type MultiExporterInput struct {
+	// Destinations are the inputs of the exporting processes of the collectors,
+	// e.g. the primary collector followed by the backup collectors in Failover
+	// mode. Transport, encryption, observation domain and template refresh
+	// timeout are configured for each destination.
+	Destinations []ExporterInput
+	Mode         DestinationMode
+}
+
+// MultiExportingProcess exports sets to multiple collectors. Template IDs are
+// allocated for all destinations, so templates have the same IDs in every
+// destination. The observation domains of ExporterInput share the template IDs
+// allocated by NewTemplateID, and the other observation domains share the
+// template IDs with the observation domains of the same ID in the other
+// destinations. Sequence numbers and template refresh are maintained by the
+// exporting process of each destination.
+type MultiExportingProcess struct {
+	destinations []*ExportingProcess
+	mode         DestinationMode
+	templateIDs  *templateIDAllocator
+	// domainTemplateIDs are the template ID allocators of the observation
+	// domains other than the ones of ExporterInput, protected by
+	// templateIDsMutex.
+	templateIDsMutex  sync.Mutex
+	domainTemplateIDs map[uint32]*templateIDAllocator
+	// mutex protects the active destination. sendTemplates is true if the
+	// templates need to be sent to the active destination after failover.
+	mutex         sync.Mutex
+	active        int
+	sendTemplates bool
+}
+
+// InitMultiExportingProcess connects to all destinations. It returns error if
+// any destination cannot be connected.
+func InitMultiExportingProcess(input MultiExporterInput) (*MultiExportingProcess, error) {
+	if len(input.Destinations) == 0 {
+		return nil, fmt.Errorf("no destination is given")
+	}
+	if input.Mode != ReplicateToAll && input.Mode != Failover {
+		return nil, fmt.Errorf("destination mode %d is not supported", input.Mode)
+	}
+	if input.Mode == Failover {
+		for _, destination := range input.Destinations {
+			if destination.EnableAsyncSend {
+				return nil, fmt.Errorf("async send is not supported in failover mode")
+			}
+		}
+	}
+	m := &MultiExportingProcess{
+		destinations:      make([]*ExportingProcess, 0, len(input.Destinations)),
+		mode:              input.Mode,
+		templateIDs:       newTemplateIDAllocator(),
+		domainTemplateIDs: make(map[uint32]*templateIDAllocator),
+	}
+	for _, destination := range input.Destinations {
+		defaultDomainID := destination.ObservationDomainID
+		ep, err := initExportingProcess(destination, func(obsDomainID uint32) *templateIDAllocator {
+			if obsDomainID == defaultDomainID {
+				return m.templateIDs
+			}
+			return m.getDomainTemplateIDs(obsDomainID)
+		})
+		if err != nil {
+			m.CloseConnToCollector()
+			return nil, fmt.Errorf("error when connecting to collector %s: %v", destination.CollectorAddr.String(), err)
+		}
+		m.destinations = append(m.destinations, ep)
+	}
+	return m, nil
+}
+
+// getDomainTemplateIDs returns the template ID allocator shared by the
+// observation domains of the ID in all destinations, unless it is the
+// observation domain of ExporterInput.
+func (m *MultiExportingProcess) getDomainTemplateIDs(obsDomainID uint32) *templateIDAllocator {
+	m.templateIDsMutex.Lock()
+	defer m.templateIDsMutex.Unlock()
+	templateIDs, exist := m.domainTemplateIDs[obsDomainID]
+	if !exist {
+		templateIDs = newTemplateIDAllocator()
+		m.domainTemplateIDs[obsDomainID] = templateIDs
+	}
+	return templateIDs
+}
+
+// NewTemplateID is called to get ID when creating new template record. The ID
+// is used for the template in all destinations. It returns 0 if all template
+// IDs are in use.
+func (m *MultiExportingProcess) NewTemplateID() uint16 {
+	return m.templateIDs.newTemplateID()
+}
+
+// GetDestinations returns the exporting processes of the destinations, in the
+// order of MultiExporterInput.Destinations.
+func (m *MultiExportingProcess) GetDestinations() []*ExportingProcess {
+	return m.destinations
+}
+
+// GetActiveDestination returns the index of the active destination in Failover
+// mode.
+func (m *MultiExportingProcess) GetActiveDestination() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.active
+}
+
+// SendSet sends the set to the destinations based on the destination mode. It
+// returns the number of bytes sent to all destinations.
+func (m *MultiExportingProcess) SendSet(set entities.Set) (int, error) {
+	if m.mode == Failover {
+		return m.sendSetWithFailover(set)
+	}
+	bytesSent := 0
+	errs := make([]string, 0)
+	for _, ep := range m.destinations {
+		n, err := ep.SendSet(set)
+		bytesSent += n
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", ep.collectorAddr.String(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return bytesSent, fmt.Errorf("error when sending set to collectors: %s", strings.Join(errs, "; "))
+	}
+	return bytesSent, nil
+}
+
+// sendSetWithFailover sends the set to the active destination. Templates are
+// kept by all destinations, so that they can be sent after failover.
+func (m *MultiExportingProcess) sendSetWithFailover(set entities.Set) (int, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	setType := set.GetSetType()
+	if setType == entities.Template || setType == entities.OptionsTemplate {
+		for i, ep := range m.destinations {
+			if i == m.active {
+				continue
+			}
+			if err := ep.defaultDomain.prepareSet(set); err != nil {
+				return 0, err
+			}
+		}
+	}
+	var err error
+	for attempt := 0; attempt < len(m.destinations); attempt++ {
+		ep := m.destinations[m.active]
+		if !ep.isConnected() {
+			err = fmt.Errorf("connection to collector %s is broken", ep.collectorAddr.String())
+			m.failover(err)
+			continue
+		}
+		if m.sendTemplates {
+			// Type information is also sent again with the templates.
+			if err = ep.sendRefreshedTemplates(); err != nil {
+				m.failover(err)
+				continue
+			}
+			m.sendTemplates = false
+		}
+		var bytesSent int
+		if setType == entities.Data {
+			if err = ep.defaultDomain.prepareSet(set); err != nil {
+				// The set is not valid, and it cannot be sent to any destination.
+				return 0, err
+			}
+			bytesSent, err = ep.writeSet(ep.defaultDomain, set)
+		} else {
+			bytesSent, err = ep.SendSet(set)
+		}
+		if err == nil {
+			return bytesSent, nil
+		}
+		m.failover(err)
+	}
+	return 0, fmt.Errorf("error when sending set to all collectors, last error: %v", err)
+}
+
+// failover makes the next destination active. The caller must hold mutex.
+func (m *MultiExportingProcess) failover(err error) {
+	previous := m.destinations[m.active]
+	m.active = (m.active + 1) % len(m.destinations)
+	m.sendTemplates = true
+	klog.Warningf("Failing over from collector %s to collector %s: %v", previous.collectorAddr.String(), m.destinations[m.active].collectorAddr.String(), err)
+}
+
+// ListTemplates returns the templates of all observation domains of all
+// destinations, ordered by obsDomainID and template ID. Templates which are
+// kept by several destinations are listed once.
+func (m *MultiExportingProcess) ListTemplates() []*TemplateInfo {
+	type templateKey struct {
+		obsDomainID uint32
+		templateID  uint16
+	}
+	templates := make([]*TemplateInfo, 0)
+	isListed := make(map[templateKey]bool)
+	for _, ep := range m.destinations {
+		for _, template := range ep.ListTemplates() {
+			key := templateKey{template.ObsDomainID, template.TemplateID}
+			if !isListed[key] {
+				isListed[key] = true
+				templates = append(templates, template)
+			}
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].ObsDomainID != templates[j].ObsDomainID {
+			return templates[i].ObsDomainID < templates[j].ObsDomainID
+		}
+		return templates[i].TemplateID < templates[j].TemplateID
+	})
+	return templates
+}
+
+// WithdrawTemplate withdraws the template of the observation domains of
+// ExporterInput in all destinations. The template ID is released once the
+// template is removed from every destination, so that it is not allocated
+// again while a destination still uses it. Templates must not be withdrawn by
+// the exporting processes of the destinations for the same reason. In Failover
+// mode, the withdrawal is only sent to the active destination.
+func (m *MultiExportingProcess) WithdrawTemplate(templateID uint16) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	exist := false
+	errs := make([]string, 0)
+	for i, ep := range m.destinations {
+		template, ok := ep.defaultDomain.removeTemplate(templateID)
+		if !ok {
+			continue
+		}
+		exist = true
+		if m.mode == Failover && i != m.active {
+			continue
+		}
+		if err := ep.defaultDomain.sendTemplateWithdrawal(template.getSetType(), templateID); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", ep.collectorAddr.String(), err))
+		}
+	}
+	if !exist {
+		return fmt.Errorf("template %d does not exist in any destination", templateID)
+	}
+	m.templateIDs.releaseTemplateID(templateID)
+	if len(errs) > 0 {
+		return fmt.Errorf("error when withdrawing template from collectors: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// WithdrawAllTemplates withdraws the templates of all observation domains in
+// all destinations. The template IDs are released once the templates are
+// removed from every destination. In Failover mode, the withdrawal is only
+// sent to the active destination.
+func (m *MultiExportingProcess) WithdrawAllTemplates() error {
+	type releasedID struct {
+		templateIDs *templateIDAllocator
+		templateID  uint16
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	releasedIDs := make([]releasedID, 0)
+	errs := make([]string, 0)
+	for i, ep := range m.destinations {
+		for _, d := range ep.getObservationDomains() {
+			setTypes := make(map[entities.ContentType]bool)
+			for templateID, template := range d.removeAllTemplates() {
+				setTypes[template.getSetType()] = true
+				releasedIDs = append(releasedIDs, releasedID{d.templateIDs, templateID})
+			}
+			if m.mode == Failover && i != m.active {
+				continue
+			}
+			if err := d.sendAllTemplatesWithdrawal(setTypes); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", ep.collectorAddr.String(), err))
+			}
+		}
+	}
+	// IDs shared by several destinations are released once.
+	for _, id := range releasedIDs {
+		id.templateIDs.releaseTemplateID(id.templateID)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("error when withdrawing templates from collectors: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Flush sends the buffered sets of all destinations.
+func (m *MultiExportingProcess) Flush() error {
+	errs := make([]string, 0)
+	for _, ep := range m.destinations {
+		if _, err := ep.Flush(); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", ep.collectorAddr.String(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("error when sending buffered sets to collectors: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// CloseConnToCollector closes the connections to all destinations.
+func (m *MultiExportingProcess) CloseConnToCollector() {
+	for _, ep := range m.destinations {
+		ep.CloseConnToCollector()
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+	"github.com/vmware/go-ipfix/pkg/registry"
+)
+
+func createTemplateAndDataSet(templateID uint16) (entities.Set, entities.Set) {
+	sourceAddress, _ := registry.GetInfoElement("sourceIPv4Address", registry.IANAEnterpriseID)
+	templateSet := entities.NewSet(entities.Template, templateID, false)
+	templateSet.AddRecord([]*entities.InfoElementWithValue{entities.NewInfoElementWithValue(sourceAddress, nil)}, templateID)
+	dataSet := entities.NewSet(entities.Data, templateID, false)
+	dataSet.AddRecord([]*entities.InfoElementWithValue{entities.NewInfoElementWithValue(sourceAddress, net.ParseIP("1.2.3.4"))}, templateID)
+	return templateSet, dataSet
+}
+
+func TestMultiExportingProcess_ReplicateToAll(t *testing.T) {
+	udpConn, udpMsgCh := startUDPServer(t)
+	defer udpConn.Close()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Got error when creating a local server: %v", err)
+	}
+	defer listener.Close()
+	_, tcpMsgCh := acceptTCPMessages(listener)
+
+	m, err := InitMultiExportingProcess(MultiExporterInput{
+		Destinations: []ExporterInput{
+			{CollectorAddr: udpConn.LocalAddr(), ObservationDomainID: 1},
+			{CollectorAddr: listener.Addr(), ObservationDomainID: 2},
+		},
+		Mode: ReplicateToAll,
+	})
+	if err != nil {
+		t.Fatalf("Got error when initializing multi exporting process: %v", err)
+	}
+	defer m.CloseConnToCollector()
+
+	// Template IDs are allocated for all destinations.
+	templateID := m.NewTemplateID()
+	assert.Equal(t, templateID+1, m.GetDestinations()[0].NewTemplateID())
+	assert.Equal(t, templateID+2, m.GetDestinations()[1].NewTemplateID())
+
+	templateSet, dataSet := createTemplateAndDataSet(templateID)
+	_, err = m.SendSet(templateSet)
+	assert.NoError(t, err)
+	_, err = m.SendSet(dataSet)
+	assert.NoError(t, err)
+	for i, msgCh := range []chan []byte{udpMsgCh, tcpMsgCh} {
+		msg := <-msgCh
+		assert.Equal(t, uint32(i+1), binary.BigEndian.Uint32(msg[12:16]))
+		assert.Equal(t, entities.TemplateSetID, binary.BigEndian.Uint16(msg[16:18]))
+		assert.Equal(t, templateID, binary.BigEndian.Uint16(msg[20:22]))
+		msg = <-msgCh
+		assert.Equal(t, templateID, binary.BigEndian.Uint16(msg[16:18]))
+		assert.Equal(t, uint32(0), binary.BigEndian.Uint32(msg[8:12]))
+	}
+	// Sequence numbers are maintained for each destination.
+	_, err = m.GetDestinations()[0].SendSet(dataSet)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32((<-udpMsgCh)[8:12]))
+	_, err = m.SendSet(dataSet)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), binary.BigEndian.Uint32((<-udpMsgCh)[8:12]))
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32((<-tcpMsgCh)[8:12]))
+}
+
+func TestMultiExportingProcess_ObservationDomainTemplateIDs(t *testing.T) {
+	udpConn1, _ := startUDPServer(t)
+	defer udpConn1.Close()
+	udpConn2, _ := startUDPServer(t)
+	defer udpConn2.Close()
+
+	m, err := InitMultiExportingProcess(MultiExporterInput{
+		Destinations: []ExporterInput{
+			{CollectorAddr: udpConn1.LocalAddr(), ObservationDomainID: 1},
+			{CollectorAddr: udpConn2.LocalAddr(), ObservationDomainID: 2},
+		},
+		Mode: ReplicateToAll,
+	})
+	if err != nil {
+		t.Fatalf("Got error when initializing multi exporting process: %v", err)
+	}
+	defer m.CloseConnToCollector()
+
+	// Template IDs of other observation domains are allocated for the
+	// observation domains of the same ID in all destinations.
+	destinations := m.GetDestinations()
+	templateID := destinations[0].GetObservationDomain(5).NewTemplateID()
+	assert.Equal(t, templateID+1, destinations[1].GetObservationDomain(5).NewTemplateID())
+	assert.Equal(t, templateID, destinations[1].GetObservationDomain(6).NewTemplateID(), "Template IDs should be allocated for each observation domain.")
+	assert.Equal(t, templateID, m.NewTemplateID(), "Template IDs of the observation domains of ExporterInput should be allocated separately.")
+	// The observation domain of ExporterInput of a destination shares the
+	// template IDs of NewTemplateID.
+	assert.Equal(t, templateID+1, destinations[0].GetObservationDomain(1).NewTemplateID())
+	assert.Equal(t, templateID+2, destinations[1].GetObservationDomain(2).NewTemplateID())
+}
+
+func TestMultiExportingProcess_WithdrawTemplate(t *testing.T) {
+	msgChs := make([]chan []byte, 2)
+	destinations := make([]ExporterInput, 2)
+	for i := range destinations {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Got error when creating a local server: %v", err)
+		}
+		defer listener.Close()
+		_, msgChs[i] = acceptTCPMessages(listener)
+		destinations[i] = ExporterInput{CollectorAddr: listener.Addr(), ObservationDomainID: 1}
+	}
+	m, err := InitMultiExportingProcess(MultiExporterInput{Destinations: destinations, Mode: ReplicateToAll})
+	if err != nil {
+		t.Fatalf("Got error when initializing multi exporting process: %v", err)
+	}
+	defer m.CloseConnToCollector()
+
+	templateID := m.NewTemplateID()
+	templateSet, _ := createTemplateAndDataSet(templateID)
+	_, err = m.SendSet(templateSet)
+	assert.NoError(t, err)
+	domainTemplateID := m.GetDestinations()[0].GetObservationDomain(5).NewTemplateID()
+	domainTemplateSet, _ := createTemplateAndDataSet(domainTemplateID)
+	for i, ep := range m.GetDestinations() {
+		_, err = ep.GetObservationDomain(5).SendSet(domainTemplateSet)
+		assert.NoError(t, err)
+		<-msgChs[i]
+		<-msgChs[i]
+	}
+	templates := m.ListTemplates()
+	if assert.Equal(t, 2, len(templates), "Templates kept by all destinations should be listed once.") {
+		assert.Equal(t, uint32(1), templates[0].ObsDomainID)
+		assert.Equal(t, templateID, templates[0].TemplateID)
+		assert.Equal(t, uint32(5), templates[1].ObsDomainID)
+		assert.Equal(t, domainTemplateID, templates[1].TemplateID)
+	}
+
+	// Template is withdrawn from all destinations before its ID is released.
+	assert.NoError(t, m.WithdrawTemplate(templateID))
+	for _, msgCh := range msgChs {
+		msg := <-msgCh
+		assert.Equal(t, entities.TemplateSetID, binary.BigEndian.Uint16(msg[16:18]))
+		assert.Equal(t, templateID, binary.BigEndian.Uint16(msg[20:22]))
+		assert.Equal(t, uint16(0), binary.BigEndian.Uint16(msg[22:24]), "Withdrawal record should not have fields.")
+	}
+	for _, ep := range m.GetDestinations() {
+		assert.Equal(t, 1, len(ep.ListTemplates()))
+	}
+	assert.True(t, m.templateIDs.isReleased[templateID])
+	assert.Error(t, m.WithdrawTemplate(templateID))
+
+	assert.NoError(t, m.WithdrawAllTemplates())
+	for _, msgCh := range msgChs {
+		msg := <-msgCh
+		assert.Equal(t, uint32(5), binary.BigEndian.Uint32(msg[12:16]))
+		assert.Equal(t, entities.TemplateSetID, binary.BigEndian.Uint16(msg[20:22]))
+	}
+	assert.Empty(t, m.ListTemplates())
+	assert.True(t, m.getDomainTemplateIDs(5).isReleased[domainTemplateID])
+}
+
+func TestMultiExportingProcess_Failover(t *testing.T) {
+	primary, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Got error when creating a local server: %v", err)
+	}
+	defer primary.Close()
+	_, primaryMsgCh := acceptTCPMessages(primary)
+	backup, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Got error when creating a local server: %v", err)
+	}
+	defer backup.Close()
+	_, backupMsgCh := acceptTCPMessages(backup)
+
+	m, err := InitMultiExportingProcess(MultiExporterInput{
+		Destinations: []ExporterInput{
+			{CollectorAddr: primary.Addr(), ObservationDomainID: 1},
+			{CollectorAddr: backup.Addr(), ObservationDomainID: 1},
+		},
+		Mode: Failover,
+	})
+	if err != nil {
+		t.Fatalf("Got error when initializing multi exporting process: %v", err)
+	}
+	defer m.CloseConnToCollector()
+
+	templateID := m.NewTemplateID()
+	templateSet, dataSet := createTemplateAndDataSet(templateID)
+	_, err = m.SendSet(templateSet)
+	assert.NoError(t, err)
+	_, err = m.SendSet(dataSet)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.TemplateSetID, binary.BigEndian.Uint16((<-primaryMsgCh)[16:18]))
+	assert.Equal(t, templateID, binary.BigEndian.Uint16((<-primaryMsgCh)[16:18]))
+	assert.Equal(t, 0, len(backupMsgCh), "Sets should only be sent to the active destination.")
+
+	// Invalid data set is not sent to any destination.
+	_, invalidDataSet := createTemplateAndDataSet(templateID + 1)
+	_, err = m.SendSet(invalidDataSet)
+	assert.Error(t, err)
+	assert.Equal(t, 0, m.GetActiveDestination())
+
+	// Templates are sent to the backup destination before the data set.
+	m.GetDestinations()[0].connToCollector.Close()
+	_, err = m.SendSet(dataSet)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, m.GetActiveDestination())
+	assert.Equal(t, entities.TemplateSetID, binary.BigEndian.Uint16((<-backupMsgCh)[16:18]))
+	msg := <-backupMsgCh
+	assert.Equal(t, templateID, binary.BigEndian.Uint16(msg[16:18]))
+	assert.Equal(t, []byte{1, 2, 3, 4}, msg[20:24])
+
+	// Error is returned if all destinations fail.
+	m.GetDestinations()[1].connToCollector.Close()
+	_, err = m.SendSet(dataSet)
+	assert.Error(t, err)
+}
@@ -15,9 +15,11 @@
 package exporter
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"math"
 	"net"
 	"sync"
 	"time"
@@ -26,30 +28,122 @@ import (
 	"k8s.io/klog"
 
 	"github.com/vmware/go-ipfix/pkg/entities"
+	"github.com/vmware/go-ipfix/pkg/registry"
 )
 
 const startTemplateID uint16 = 255
 
+// templateIDAllocator allocates template IDs, which can be shared by the
+// exporting processes of a MultiExportingProcess. IDs are allocated in
+// increasing order. The IDs of withdrawn templates are reused in the order
+// they are released once all IDs are allocated, so that collectors are
+// unlikely to still have the withdrawn templates.
+type templateIDAllocator struct {
+	mutex       sync.Mutex
+	templateID  uint16
+	releasedIDs []uint16
+	isReleased  map[uint16]bool
+}
+
+func newTemplateIDAllocator() *templateIDAllocator {
+	return &templateIDAllocator{
+		templateID: startTemplateID,
+		isReleased: make(map[uint16]bool),
+	}
+}
+
+// newTemplateID returns 0, which is not a valid template ID, if all IDs are in
+// use.
+func (a *templateIDAllocator) newTemplateID() uint16 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.templateID < math.MaxUint16 {
+		a.templateID++
+		return a.templateID
+	}
+	if len(a.releasedIDs) == 0 {
+		return 0
+	}
+	id := a.releasedIDs[0]
+	a.releasedIDs = a.releasedIDs[1:]
+	delete(a.isReleased, id)
+	return id
+}
+
+func (a *templateIDAllocator) releaseTemplateID(id uint16) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.isReleased[id] {
+		return
+	}
+	a.releasedIDs = append(a.releasedIDs, id)
+	a.isReleased[id] = true
+}
+
 type templateValue struct {
 	elements      []*entities.InfoElement
 	minDataRecLen uint16
+	// scopeFieldCount is non-zero only for options templates.
+	scopeFieldCount uint16
 }
 
-// 1. Tested one exportingProcess process per exporter. Can support multiple collector scenario by
-//    creating different instances of exporting process. Need to be tested
+// 1. One exportingProcess process per collector. Multiple collectors are supported by
+//    MultiExportingProcess, which creates an exporting process for each collector.
 // 2. Only one observation point per observation domain is supported,
-//    so observation point ID not defined.
+//    so observation point ID not defined. Multiple observation domains can
+//    share the session, see GetObservationDomain.
 // 3. Supports only TCP and UDP; one session at a time. SCTP is not supported.
-// TODO:UDP needs to send MTU size packets as per RFC7011
+// 4. Each set is sent in its own message unless buffering is enabled, in which
+//    case sets are packed into messages up to the message size limit.
+// 5. Sets are sent on the caller's goroutine unless async send is enabled, in
+//    which case they are queued and sent by a writer goroutine.
 type ExportingProcess struct {
 	connToCollector net.Conn
-	obsDomainID     uint32
-	seqNumber       uint32
-	templateID      uint16
+	collectorAddr   net.Addr
 	pathMTU         int
-	templatesMap    map[uint16]templateValue
 	templateRefCh   chan struct{}
-	mutex           sync.Mutex
+	registry        *registry.Registry
+	// defaultDomain is the observation domain of ExporterInput, which is used
+	// by SendSet and NewTemplateID. mutex protects the map of all observation
+	// domains.
+	defaultDomain *ObservationDomain
+	mutex         sync.Mutex
+	domains       map[uint32]*ObservationDomain
+	// getTemplateIDAllocator returns the template ID allocator of a new
+	// observation domain. It is nil unless the exporting process is a
+	// destination of a MultiExportingProcess.
+	getTemplateIDAllocator func(obsDomainID uint32) *templateIDAllocator
+	// sendTypeInfo enables sending type information (RFC5610) of the
+	// enterprise-specific elements used in templates.
+	sendTypeInfo bool
+	// msgMutex protects the message buffer and the writes to the connection.
+	msgMutex sync.Mutex
+	// msgBuffer holds the sets of the message which is not sent yet. The
+	// message header is created when the message is sent. All sets of the
+	// message belong to msgDomain.
+	msgBuffer      bytes.Buffer
+	msgDomain      *ObservationDomain
+	msgDataRecords uint32
+	isBuffered     bool
+	maxDelay       time.Duration
+	flushTimer     *time.Timer
+	// sentMsgCount is the number of messages sent, which tells whether the
+	// buffered sets are sent.
+	sentMsgCount uint64
+	// connToCollector is nil while reconnecting to the collector. Data sets
+	// sent in the meantime are kept in pendingSets up to maxPendingSets.
+	// dial creates the new connection.
+	reconnect          bool
+	dial               func() (net.Conn, error)
+	reconnectBaseDelay time.Duration
+	reconnectMaxDelay  time.Duration
+	isReconnecting     bool
+	isClosed           bool
+	maxPendingSets     int
+	pendingSets        []pendingSet
+	stateHandler       func(state ConnectionState, err error)
+	// sendQueue is nil unless async send is enabled.
+	sendQueue *sendQueue
 }
 
 type ExporterInput struct {
@@ -61,6 +155,45 @@ type ExporterInput struct {
 	CACert              []byte
 	ClientCert          []byte
 	ClientKey           []byte
+	// Registry is the registry of Information Elements of exported records. The
+	// default registry is used if it is not given.
+	Registry *registry.Registry
+	// SendTypeInformation enables sending type information records (RFC5610)
+	// of the enterprise-specific elements used in templates, so that collectors
+	// can decode the elements without knowing them beforehand.
+	SendTypeInformation bool
+	// EnableBuffering enables packing multiple sets into one message up to
+	// the message size limit. Buffered sets are sent when the message is full,
+	// when Flush is called, or after MaxDelay.
+	EnableBuffering bool
+	// MaxDelay is the maximum time a set is buffered before it is sent when
+	// buffering is enabled. If 0 is passed, consider 1s as default.
+	MaxDelay time.Duration
+	// EnableReconnect enables reconnecting to the collector with exponential
+	// backoff when the connection is broken. All templates are sent again
+	// after reconnecting, before any data sets.
+	EnableReconnect bool
+	// ReconnectBaseDelay and ReconnectMaxDelay are the initial and maximum
+	// delays between reconnection attempts. If 0 is passed, consider 1s and
+	// 1min as default.
+	ReconnectBaseDelay time.Duration
+	ReconnectMaxDelay  time.Duration
+	// MaxPendingDataSets is the maximum number of data sets kept while
+	// reconnecting, which are sent after the templates. Other data sets are
+	// dropped with an error.
+	MaxPendingDataSets int
+	// ConnectionStateHandler is called when the connection to the collector
+	// is broken or reestablished if EnableReconnect is true.
+	ConnectionStateHandler func(state ConnectionState, err error)
+	// EnableAsyncSend enables sending sets on a writer goroutine, so that a
+	// slow collector does not block SendSet. Sets are checked by SendSet, and
+	// added to a queue of SendQueueSize sets, which must not be modified after
+	// being passed to SendSet. If 0 is passed, consider 1024 as default.
+	EnableAsyncSend bool
+	SendQueueSize   int
+	// OverflowPolicy decides how SendSet handles data sets when the queue is
+	// full. Template sets always wait for space in the queue.
+	OverflowPolicy OverflowPolicy
 }
 
 // InitExportingProcess takes in collector address(net.Addr format), obsID(observation ID)
@@ -73,52 +206,56 @@ type ExporterInput struct {
 // PathMTU is optional for TCP as we use max socket buffer size of 65535. It can
 // be provided as 0.
 func InitExportingProcess(input ExporterInput) (*ExportingProcess, error) {
-	var conn net.Conn
-	var err error
-	if input.IsEncrypted {
-		if input.CollectorAddr.Network() == "tcp" { // use TLS
-			config, err := createClientConfig(input.CACert, input.ClientCert, input.ClientKey)
-			if err != nil {
-				return nil, err
-			}
-			conn, err = tls.Dial(input.CollectorAddr.Network(), input.CollectorAddr.String(), config)
-			if err != nil {
-				klog.Errorf("Cannot the create the tls connection to configured ExportingProcess %s: %v", input.CollectorAddr.String(), err)
-				return nil, err
-			}
-		} else if input.CollectorAddr.Network() == "udp" { // use DTLS
-			roots := x509.NewCertPool()
-			ok := roots.AppendCertsFromPEM(input.CACert)
-			if !ok {
-				return nil, fmt.Errorf("Failed to parse root certificate")
-			}
-			config := &dtls.Config{RootCAs: roots,
-				ExtendedMasterSecret: dtls.RequireExtendedMasterSecret}
-			address, err := net.ResolveUDPAddr(input.CollectorAddr.Network(), input.CollectorAddr.String())
-			if err != nil {
-				return nil, fmt.Errorf("Cannot resolve udp address %s", input.CollectorAddr.String())
-			}
-			conn, err = dtls.Dial(address.Network(), address, config)
-			if err != nil {
-				klog.Errorf("Cannot the create the dtls connection to configured ExportingProcess %s: %v", address.String(), err)
-				return nil, err
-			}
+	return initExportingProcess(input, nil)
+}
+
+// initExportingProcess creates the exporting process, whose observation
+// domains get their template ID allocators from getTemplateIDAllocator if it is
+// not nil.
+func initExportingProcess(input ExporterInput, getTemplateIDAllocator func(obsDomainID uint32) *templateIDAllocator) (*ExportingProcess, error) {
+	conn, err := dialCollector(input)
+	if err != nil {
+		return nil, err
+	}
+	expProc := &ExportingProcess{
+		connToCollector:        conn,
+		collectorAddr:          input.CollectorAddr,
+		pathMTU:                input.PathMTU,
+		templateRefCh:          make(chan struct{}),
+		registry:               input.Registry,
+		domains:                make(map[uint32]*ObservationDomain),
+		sendTypeInfo:           input.SendTypeInformation,
+		isBuffered:             input.EnableBuffering,
+		maxDelay:               input.MaxDelay,
+		reconnect:              input.EnableReconnect,
+		maxPendingSets:         input.MaxPendingDataSets,
+		stateHandler:           input.ConnectionStateHandler,
+		getTemplateIDAllocator: getTemplateIDAllocator,
+	}
+	expProc.defaultDomain = expProc.GetObservationDomain(input.ObservationDomainID)
+	expProc.dial = func() (net.Conn, error) {
+		return dialCollector(input)
+	}
+	if expProc.reconnect {
+		expProc.reconnectBaseDelay = input.ReconnectBaseDelay
+		if expProc.reconnectBaseDelay == 0 {
+			expProc.reconnectBaseDelay = defaultReconnectBaseDelay
 		}
-	} else {
-		conn, err = net.Dial(input.CollectorAddr.Network(), input.CollectorAddr.String())
-		if err != nil {
-			klog.Errorf("Cannot the create the connection to configured ExportingProcess %s: %v", input.CollectorAddr.String(), err)
-			return nil, err
+		expProc.reconnectMaxDelay = input.ReconnectMaxDelay
+		if expProc.reconnectMaxDelay == 0 {
+			expProc.reconnectMaxDelay = defaultReconnectMaxDelay
 		}
+		go expProc.monitorConnection(conn)
 	}
-	expProc := &ExportingProcess{
-		connToCollector: conn,
-		obsDomainID:     input.ObservationDomainID,
-		seqNumber:       0,
-		templateID:      startTemplateID,
-		pathMTU:         input.PathMTU,
-		templatesMap:    make(map[uint16]templateValue),
-		templateRefCh:   make(chan struct{}),
+	if expProc.isBuffered && expProc.maxDelay == 0 {
+		expProc.maxDelay = defaultMaxDelay
+	}
+	if expProc.registry == nil {
+		expProc.registry = registry.Default()
+	}
+	if input.EnableAsyncSend {
+		expProc.sendQueue = newSendQueue(input.SendQueueSize, input.OverflowPolicy)
+		go expProc.runWriter()
 	}
 
 	// Template refresh logic is only for UDP transport.
@@ -136,10 +273,13 @@ func InitExportingProcess(input ExporterInput) (*ExportingProcess, error) {
 			for {
 				select {
 				case <-expProc.templateRefCh:
-					break
+					return
 				case <-ticker.C:
 					err := expProc.sendRefreshedTemplates()
-					if err != nil {
+					if err != nil && expProc.reconnect {
+						// Templates are sent again after reconnecting.
+						klog.Errorf("Error when sending refreshed templates: %v", err)
+					} else if err != nil {
 						// Other option is sending messages through channel to library consumers
 						klog.Errorf("Error when sending refreshed templates: %v. Closing the connection to IPFIX controller", err)
 						expProc.CloseConnToCollector()
@@ -151,44 +291,51 @@ func InitExportingProcess(input ExporterInput) (*ExportingProcess, error) {
 	return expProc, nil
 }
 
+// SendSet sends the set to the collector in the observation domain of
+// ExporterInput. Sets which do not fit in a message are split by records into
+// multiple messages. If buffering is enabled, the set is added to the message
+// buffer, and it returns the number of bytes of the messages that are sent
+// because the buffer is full. If async send is enabled, the set is added to the
+// send queue, and it returns 0.
 func (ep *ExportingProcess) SendSet(set entities.Set) (int, error) {
-	// Iterate over all records in the set.
-	setType := set.GetSetType()
-	for _, record := range set.GetRecords() {
-		if setType == entities.Template {
-			ep.updateTemplate(record.GetTemplateID(), record.GetOrderedElementList(), record.GetMinDataRecordLen())
-		} else if setType == entities.Data {
-			err := ep.dataRecSanityCheck(record)
-			if err != nil {
-				return 0, fmt.Errorf("error when doing sanity check:%v", err)
-			}
-		}
-	}
-
-	// Update the length in set header before sending the message.
-	set.UpdateLenInHeader()
-	bytesSent, err := ep.createAndSendMsg(set)
-	if err != nil {
-		return bytesSent, err
-	}
-
-	return bytesSent, nil
+	return ep.defaultDomain.SendSet(set)
 }
 
 func (ep *ExportingProcess) GetMsgSizeLimit() int {
-	if ep.connToCollector.LocalAddr().Network() == "tcp" {
+	if ep.collectorAddr.Network() == "tcp" {
 		return entities.MaxTcpSocketMsgSize
 	} else {
 		return ep.pathMTU
 	}
 }
 
+// GetRegistry returns the registry of Information Elements of the exporting
+// process, which can be used to look up the elements of exported records.
+func (ep *ExportingProcess) GetRegistry() *registry.Registry {
+	return ep.registry
+}
+
+// CloseConnToCollector closes the connection to the collector. Queued sets are
+// dropped if async send is enabled; use Close to send them before closing.
 func (ep *ExportingProcess) CloseConnToCollector() {
 	if !isChanClosed(ep.templateRefCh) {
 		close(ep.templateRefCh) // Close template refresh channel
 	}
+	if ep.sendQueue != nil {
+		ep.sendQueue.close()
+	}
+	if _, err := ep.Flush(); err != nil {
+		klog.Errorf("Error when sending buffered sets: %v", err)
+	}
 
-	err := ep.connToCollector.Close()
+	ep.msgMutex.Lock()
+	ep.isClosed = true
+	conn := ep.connToCollector
+	ep.msgMutex.Unlock()
+	if conn == nil { // reconnecting to the collector
+		return
+	}
+	err := conn.Close()
 	// Just log the error that happened when closing the connection. Not returning error as we do not expect library
 	// consumers to exit their programs with this error.
 	if err != nil {
@@ -196,129 +343,89 @@ func (ep *ExportingProcess) CloseConnToCollector() {
 	}
 }
 
-// NewTemplateID is called to get ID when creating new template record.
+// NewTemplateID is called to get ID when creating new template record in the
+// observation domain of ExporterInput. It returns 0 if all template IDs are in
+// use.
 func (ep *ExportingProcess) NewTemplateID() uint16 {
-	ep.templateID++
-	return ep.templateID
+	return ep.defaultDomain.NewTemplateID()
 }
 
-// createAndSendMsg takes in a set as input, creates the message, and sends it out.
-// TODO: This method will change when we support sending multiple sets.
-func (ep *ExportingProcess) createAndSendMsg(set entities.Set) (int, error) {
-	// Create a new message and use it to send the set.
-	msg := entities.NewMessage(false)
-	// Create the header in the IPFIX message.
-	_, err := msg.CreateHeader()
-	if err != nil {
-		return 0, fmt.Errorf("error when creating header: %v", err)
-	}
+// updateTemplate adds the template to the template map. The existing template
+// with the same ID is replaced if it is defined differently.
+func (d *ObservationDomain) updateTemplate(id uint16, elements []*entities.InfoElementWithValue, minDataRecLen uint16, scopeFieldCount uint16) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 
-	// Check if message is exceeding the limit after adding the set. Include message
-	// header length too.
-	msgLen := msg.GetMsgBufferLen() + set.GetBuffLen()
-	if ep.connToCollector.LocalAddr().Network() == "tcp" {
-		if msgLen > entities.MaxTcpSocketMsgSize {
-			return 0, fmt.Errorf("TCP transport: message size exceeds max socket buffer size")
+	newTemplate := newTemplateValue(elements, minDataRecLen, scopeFieldCount)
+	if oldTemplate, exist := d.templatesMap[id]; exist {
+		if oldTemplate.isEqual(newTemplate) {
+			return
 		}
-	} else {
-		if msgLen > ep.pathMTU {
-			return 0, fmt.Errorf("UDP transport: message size exceeds max pathMTU (set as %v)", ep.pathMTU)
-		}
-	}
-
-	// Set the fields in the message header.
-	// IPFIX version number is 10.
-	// https://www.iana.org/assignments/ipfix/ipfix.xhtml#ipfix-version-numbers
-	msg.SetVersion(10)
-	msg.SetObsDomainID(ep.obsDomainID)
-	msg.SetMessageLen(uint16(msgLen))
-	msg.SetExportTime(uint32(time.Now().Unix()))
-	if set.GetSetType() == entities.Data {
-		ep.seqNumber = ep.seqNumber + set.GetNumberOfRecords()
-	}
-	msg.SetSequenceNum(ep.seqNumber)
-
-	// Append the byte slices together to send on the exporter connection rather
-	// than copying the set buffer to message buffer again.
-	bytesSlice := append(msg.GetMsgBuffer().Bytes(), set.GetBuffer().Bytes()...)
-	// Send the message on the exporter connection.
-	bytesSent, err := ep.connToCollector.Write(bytesSlice)
-	if err != nil {
-		return bytesSent, fmt.Errorf("error when sending message on the connection: %v", err)
-	} else if bytesSent != int(msg.GetMessageLen()) {
-		return bytesSent, fmt.Errorf("could not send the complete message on the connection")
-	}
-
-	return bytesSent, nil
-}
-
-func (ep *ExportingProcess) updateTemplate(id uint16, elements []*entities.InfoElementWithValue, minDataRecLen uint16) {
-	ep.mutex.Lock()
-	defer ep.mutex.Unlock()
-
-	if _, exist := ep.templatesMap[id]; exist {
-		return
-	}
-	ep.templatesMap[id] = templateValue{
-		make([]*entities.InfoElement, len(elements)),
-		minDataRecLen,
-	}
-	for i, elem := range elements {
-		ep.templatesMap[id].elements[i] = elem.Element
+		klog.Infof("Template with id %d, and obsDomainID %d is redefined.", id, d.obsDomainID)
 	}
+	d.templatesMap[id] = newTemplate
 	return
 }
 
-func (ep *ExportingProcess) deleteTemplate(id uint16) error {
-	ep.mutex.Lock()
-	defer ep.mutex.Unlock()
-
-	if _, exist := ep.templatesMap[id]; !exist {
-		return fmt.Errorf("process: template %d does not exist in exporting process", id)
+func (ep *ExportingProcess) sendRefreshedTemplates() error {
+	// Send refreshed template for every template in every observation domain
+	for _, d := range ep.getObservationDomains() {
+		for _, templateSet := range d.getTemplateSets() {
+			if _, err := d.SendSet(templateSet); err != nil {
+				return err
+			}
+		}
 	}
-	delete(ep.templatesMap, id)
 	return nil
 }
 
-func (ep *ExportingProcess) sendRefreshedTemplates() error {
-	// Send refreshed template for every template in template map
+// getTemplateSets returns the template sets of all templates in the template
+// map. The type information options template is the first one, as its records
+// are sent before the other templates.
+func (d *ObservationDomain) getTemplateSets() []entities.Set {
 	templateSets := make([]entities.Set, 0)
 
-	ep.mutex.Lock()
-	for templateID, tempValue := range ep.templatesMap {
-		tempSet := entities.NewSet(entities.Template, templateID, false)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	// Type information is sent again with the templates.
+	d.describedElements = make(map[elementKey]bool)
+	for templateID, tempValue := range d.templatesMap {
 		elements := make([]*entities.InfoElementWithValue, 0)
 		for _, element := range tempValue.elements {
 			ie := entities.NewInfoElementWithValue(element, nil)
 			elements = append(elements, ie)
 		}
-		tempSet.AddRecord(elements, templateID)
-		templateSets = append(templateSets, tempSet)
-	}
-	ep.mutex.Unlock()
-
-	for _, templateSet := range templateSets {
-		if _, err := ep.SendSet(templateSet); err != nil {
-			return err
+		var tempSet entities.Set
+		if tempValue.scopeFieldCount > 0 {
+			tempSet = entities.NewSet(entities.OptionsTemplate, templateID, false)
+			tempSet.AddOptionsRecord(elements, tempValue.scopeFieldCount, templateID)
+		} else {
+			tempSet = entities.NewSet(entities.Template, templateID, false)
+			tempSet.AddRecord(elements, templateID)
+		}
+		if templateID == d.typeInfoTemplateID {
+			templateSets = append([]entities.Set{tempSet}, templateSets...)
+		} else {
+			templateSets = append(templateSets, tempSet)
 		}
 	}
-	return nil
+	return templateSets
 }
 
-func (ep *ExportingProcess) dataRecSanityCheck(rec entities.Record) error {
+func (d *ObservationDomain) dataRecSanityCheck(rec entities.Record) error {
 	templateID := rec.GetTemplateID()
 
-	ep.mutex.Lock()
-	defer ep.mutex.Unlock()
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 
-	if _, exist := ep.templatesMap[templateID]; !exist {
+	if _, exist := d.templatesMap[templateID]; !exist {
 		return fmt.Errorf("process: templateID %d does not exist in exporting process", templateID)
 	}
-	if rec.GetFieldCount() != uint16(len(ep.templatesMap[templateID].elements)) {
+	if rec.GetFieldCount() != uint16(len(d.templatesMap[templateID].elements)) {
 		return fmt.Errorf("process: field count of data does not match templateID %d", templateID)
 	}
-	if rec.GetBuffer().Len() < int(ep.templatesMap[templateID].minDataRecLen) {
-		return fmt.Errorf("process: Data Record does not pass the min required length (%d) check for template ID %d", ep.templatesMap[templateID].minDataRecLen, templateID)
+	if rec.GetBuffer().Len() < int(d.templatesMap[templateID].minDataRecLen) {
+		return fmt.Errorf("process: Data Record does not pass the min required length (%d) check for template ID %d", d.templatesMap[templateID].minDataRecLen, templateID)
 	}
 	return nil
 }
@@ -332,6 +439,50 @@ func isChanClosed(ch <-chan struct{}) bool {
 	return false
 }
 
+// dialCollector creates the connection to the collector, which uses TLS or DTLS
+// if the exporting process is encrypted.
+func dialCollector(input ExporterInput) (net.Conn, error) {
+	var conn net.Conn
+	var err error
+	if input.IsEncrypted {
+		if input.CollectorAddr.Network() == "tcp" { // use TLS
+			config, err := createClientConfig(input.CACert, input.ClientCert, input.ClientKey)
+			if err != nil {
+				return nil, err
+			}
+			conn, err = tls.Dial(input.CollectorAddr.Network(), input.CollectorAddr.String(), config)
+			if err != nil {
+				klog.Errorf("Cannot the create the tls connection to configured ExportingProcess %s: %v", input.CollectorAddr.String(), err)
+				return nil, err
+			}
+		} else if input.CollectorAddr.Network() == "udp" { // use DTLS
+			roots := x509.NewCertPool()
+			ok := roots.AppendCertsFromPEM(input.CACert)
+			if !ok {
+				return nil, fmt.Errorf("Failed to parse root certificate")
+			}
+			config := &dtls.Config{RootCAs: roots,
+				ExtendedMasterSecret: dtls.RequireExtendedMasterSecret}
+			address, err := net.ResolveUDPAddr(input.CollectorAddr.Network(), input.CollectorAddr.String())
+			if err != nil {
+				return nil, fmt.Errorf("Cannot resolve udp address %s", input.CollectorAddr.String())
+			}
+			conn, err = dtls.Dial(address.Network(), address, config)
+			if err != nil {
+				klog.Errorf("Cannot the create the dtls connection to configured ExportingProcess %s: %v", address.String(), err)
+				return nil, err
+			}
+		}
+	} else {
+		conn, err = net.Dial(input.CollectorAddr.Network(), input.CollectorAddr.String())
+		if err != nil {
+			klog.Errorf("Cannot the create the connection to configured ExportingProcess %s: %v", input.CollectorAddr.String(), err)
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
 func createClientConfig(caCert, clientCert, clientKey []byte) (*tls.Config, error) {
 	roots := x509.NewCertPool()
 	ok := roots.AppendCertsFromPEM(caCert)
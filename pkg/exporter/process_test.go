@@ -16,7 +16,12 @@ package exporter
 
 import (
 	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
 	"net"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -182,7 +187,77 @@ func TestExportingProcess_SendingTemplateRecordToLocalTCPServer(t *testing.T) {
 	}
 	// 32 is the size of the IPFIX message including all headers
 	assert.Equal(t, 32, bytesSent)
-	assert.Equal(t, uint32(0), exporter.seqNumber)
+	assert.Equal(t, uint32(0), exporter.defaultDomain.seqNumber)
+	exporter.CloseConnToCollector()
+}
+
+func TestExportingProcess_SendingOptionsTemplateRecordToLocalTCPServer(t *testing.T) {
+	// Create local server for testing
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Got error when creating a local server: %v", err)
+	}
+	t.Log("Created local server on random available port for testing")
+
+	buffCh := make(chan []byte)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		t.Log("Accept the connection from exporter")
+		buff := make([]byte, 34)
+		_, err = conn.Read(buff)
+		if err != nil {
+			t.Error(err)
+		}
+		// Remove message header.
+		buffCh <- buff[16:]
+		return
+	}()
+
+	// Create exporter using local server info
+	input := ExporterInput{
+		CollectorAddr:       listener.Addr(),
+		ObservationDomainID: 1,
+		TempRefTimeout:      0,
+		PathMTU:             0,
+	}
+	exporter, err := InitExportingProcess(input)
+	if err != nil {
+		t.Fatalf("Got error when connecting to local server %s: %v", listener.Addr().String(), err)
+	}
+	t.Logf("Created exporter connecting to local server with address: %s", listener.Addr().String())
+
+	// Create options template record with one scope field and one field
+	templateID := exporter.NewTemplateID()
+	optionsTemplateSet := entities.NewSet(entities.OptionsTemplate, templateID, false)
+	elements := make([]*entities.InfoElementWithValue, 0)
+	element, err := registry.GetInfoElement("exportingProcessId", registry.IANAEnterpriseID)
+	if err != nil {
+		t.Errorf("Did not find the element with name exportingProcessId")
+	}
+	ie := entities.NewInfoElementWithValue(element, nil)
+	elements = append(elements, ie)
+	element, err = registry.GetInfoElement("exportedMessageTotalCount", registry.IANAEnterpriseID)
+	if err != nil {
+		t.Errorf("Did not find the element with name exportedMessageTotalCount")
+	}
+	ie = entities.NewInfoElementWithValue(element, nil)
+	elements = append(elements, ie)
+	optionsTemplateSet.AddOptionsRecord(elements, 1, templateID)
+
+	bytesSent, err := exporter.SendSet(optionsTemplateSet)
+	if err != nil {
+		t.Fatalf("Got error when sending record: %v", err)
+	}
+	// 34 is the size of the IPFIX message including all headers
+	assert.Equal(t, 34, bytesSent)
+	setBytes := <-buffCh
+	assert.Equal(t, []byte{0, 3, 0, 18, 1, 0, 0, 2, 0, 1, 0, 144, 0, 4, 0, 41, 0, 8}, setBytes)
+	assert.Equal(t, uint16(1), exporter.defaultDomain.templatesMap[templateID].scopeFieldCount)
 	exporter.CloseConnToCollector()
 }
 
@@ -265,7 +340,10 @@ func TestExportingProcess_SendingTemplateRecordToLocalUDPServer(t *testing.T) {
 	assert.Equal(t, bytesAtServer[20:32], bytesAtServer[52:], "both template messages should be same")
 	// 32 is the size of the IPFIX message including all headers
 	assert.Equal(t, 32, bytesSent)
-	assert.Equal(t, uint32(0), exporter.seqNumber)
+	// Template refresh goroutine may still be sending the message.
+	exporter.msgMutex.Lock()
+	assert.Equal(t, uint32(0), exporter.defaultDomain.seqNumber)
+	exporter.msgMutex.Unlock()
 
 	exporter.CloseConnToCollector()
 
@@ -328,7 +406,7 @@ func TestExportingProcess_SendingDataRecordToLocalTCPServer(t *testing.T) {
 	}
 	element2 := entities.NewInfoElementWithValue(element, nil)
 	// Hardcoding 8-bytes min data record length for testing purposes instead of creating template record
-	exporter.updateTemplate(templateID, []*entities.InfoElementWithValue{element1, element2}, 8)
+	exporter.defaultDomain.updateTemplate(templateID, []*entities.InfoElementWithValue{element1, element2}, 8, 0)
 
 	// Create data set with 1 data record
 	dataSet := entities.NewSet(entities.Data, templateID, false)
@@ -356,7 +434,7 @@ func TestExportingProcess_SendingDataRecordToLocalTCPServer(t *testing.T) {
 	// 28 is the size of the IPFIX message including all headers (20 bytes)
 	assert.Equal(t, 28, bytesSent)
 	assert.Equal(t, dataRecBytes, <-buffCh)
-	assert.Equal(t, uint32(1), exporter.seqNumber)
+	assert.Equal(t, uint32(1), exporter.defaultDomain.seqNumber)
 
 	// Create data set with multiple data records to test invalid message length
 	// logic for TCP transport.
@@ -424,7 +502,7 @@ func TestExportingProcess_SendingDataRecordToLocalUDPServer(t *testing.T) {
 	}
 	element2 := entities.NewInfoElementWithValue(element, nil)
 	// Hardcoding 8-bytes min data record length for testing purposes instead of creating template record
-	exporter.updateTemplate(templateID, []*entities.InfoElementWithValue{element1, element2}, 8)
+	exporter.defaultDomain.updateTemplate(templateID, []*entities.InfoElementWithValue{element1, element2}, 8, 0)
 
 	// Create data set with 1 data record
 	dataSet := entities.NewSet(entities.Data, templateID, false)
@@ -452,18 +530,462 @@ func TestExportingProcess_SendingDataRecordToLocalUDPServer(t *testing.T) {
 	// 28 is the size of the IPFIX message including all headers (20 bytes)
 	assert.Equal(t, 28, bytesSent)
 	assert.Equal(t, dataRecBytes, <-buffCh)
-	assert.Equal(t, uint32(1), exporter.seqNumber)
+	assert.Equal(t, uint32(1), exporter.defaultDomain.seqNumber)
 
-	// Create data set with multiple data records to test invalid message length
-	// logic for UDP transport.
-	dataSet = entities.NewSet(entities.Data, templateID, false)
-	for i := 0; i < 100; i++ {
+	exporter.CloseConnToCollector()
+}
+
+// startUDPServer starts a local UDP server, which sends the received messages
+// to the returned channel.
+func startUDPServer(t *testing.T) (*net.UDPConn, chan []byte) {
+	udpAddr, err := net.ResolveUDPAddr("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Got error when resolving UDP address: %v", err)
+	}
+	conn, err := net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		t.Fatalf("Got error when creating a local server: %v", err)
+	}
+	msgCh := make(chan []byte, 100)
+	go func() {
+		defer close(msgCh)
+		for {
+			buff := make([]byte, entities.MaxUDPMsgSize)
+			n, err := conn.Read(buff)
+			if err != nil {
+				return
+			}
+			msgCh <- buff[:n]
+		}
+	}()
+	return conn, msgCh
+}
+
+// createDataSetForBuffering creates a data set with the given number of records
+// of 5 bytes, and adds the template of the records to the exporting process.
+func createDataSetForBuffering(t *testing.T, exporter *ExportingProcess, templateID uint16, numRecords int) entities.Set {
+	sourceAddress, err := registry.GetInfoElement("sourceIPv4Address", registry.IANAEnterpriseID)
+	if err != nil {
+		t.Fatalf("Did not find the element with name sourceIPv4Address")
+	}
+	protocol, err := registry.GetInfoElement("protocolIdentifier", registry.IANAEnterpriseID)
+	if err != nil {
+		t.Fatalf("Did not find the element with name protocolIdentifier")
+	}
+	exporter.defaultDomain.updateTemplate(templateID, []*entities.InfoElementWithValue{entities.NewInfoElementWithValue(sourceAddress, nil), entities.NewInfoElementWithValue(protocol, nil)}, 5, 0)
+	dataSet := entities.NewSet(entities.Data, templateID, false)
+	for i := 0; i < numRecords; i++ {
+		elements := []*entities.InfoElementWithValue{
+			entities.NewInfoElementWithValue(sourceAddress, net.ParseIP("1.2.3.4")),
+			entities.NewInfoElementWithValue(protocol, uint8(6)),
+		}
 		dataSet.AddRecord(elements, templateID)
 	}
+	return dataSet
+}
+
+func TestExportingProcess_SplitDataSetToLocalUDPServer(t *testing.T) {
+	conn, msgCh := startUDPServer(t)
+	defer conn.Close()
+	exporter, err := InitExportingProcess(ExporterInput{
+		CollectorAddr:       conn.LocalAddr(),
+		ObservationDomainID: 1,
+	})
+	if err != nil {
+		t.Fatalf("Got error when connecting to local server %s: %v", conn.LocalAddr().String(), err)
+	}
+	defer exporter.CloseConnToCollector()
+
+	// Data set with 200 records does not fit in a message of 512 bytes, so it
+	// is split into 3 messages with 98, 98 and 4 records.
+	templateID := exporter.NewTemplateID()
+	dataSet := createDataSetForBuffering(t, exporter, templateID, 200)
+	bytesSent, err := exporter.SendSet(dataSet)
+	assert.NoError(t, err)
+	assert.Equal(t, 3*(16+4)+200*5, bytesSent)
+	expectedRecords := []int{98, 98, 4}
+	expectedSeqNumbers := []uint32{0, 98, 196}
+	for i := range expectedRecords {
+		msg := <-msgCh
+		assert.LessOrEqual(t, len(msg), entities.DefaultUDPMsgSize)
+		assert.Equal(t, uint16(len(msg)), binary.BigEndian.Uint16(msg[2:4]))
+		assert.Equal(t, expectedSeqNumbers[i], binary.BigEndian.Uint32(msg[8:12]))
+		assert.Equal(t, templateID, binary.BigEndian.Uint16(msg[16:18]))
+		assert.Equal(t, uint16(4+expectedRecords[i]*5), binary.BigEndian.Uint16(msg[18:20]))
+	}
+
+	// Sending a record larger than the message size limit returns error.
+	ie := entities.NewInfoElementWithValue(entities.NewInfoElement("interfaceDescription", 83, entities.String, registry.IANAEnterpriseID, entities.VariableLength), nil)
+	exporter.defaultDomain.updateTemplate(templateID+1, []*entities.InfoElementWithValue{ie}, 1, 0)
+	dataSet = entities.NewSet(entities.Data, templateID+1, false)
+	ie = entities.NewInfoElementWithValue(ie.Element, strings.Repeat("a", 600))
+	dataSet.AddRecord([]*entities.InfoElementWithValue{ie}, templateID+1)
 	_, err = exporter.SendSet(dataSet)
 	assert.Error(t, err)
+}
 
-	exporter.CloseConnToCollector()
+func TestExportingProcess_BufferingToLocalUDPServer(t *testing.T) {
+	conn, msgCh := startUDPServer(t)
+	defer conn.Close()
+	exporter, err := InitExportingProcess(ExporterInput{
+		CollectorAddr:       conn.LocalAddr(),
+		ObservationDomainID: 1,
+		EnableBuffering:     true,
+		MaxDelay:            100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Got error when connecting to local server %s: %v", conn.LocalAddr().String(), err)
+	}
+	defer exporter.CloseConnToCollector()
+	templateID := exporter.NewTemplateID()
+
+	// Sets are buffered until Flush is called. Each set with one record of 5
+	// bytes is padded to 12 bytes.
+	for i := 0; i < 3; i++ {
+		bytesSent, err := exporter.SendSet(createDataSetForBuffering(t, exporter, templateID, 1))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, bytesSent)
+	}
+	bytesSent, err := exporter.Flush()
+	assert.NoError(t, err)
+	assert.Equal(t, 16+3*12, bytesSent)
+	msg := <-msgCh
+	assert.Equal(t, 16+3*12, len(msg))
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(msg[8:12]))
+	for i := 0; i < 3; i++ {
+		setHeader := msg[16+i*12:]
+		assert.Equal(t, templateID, binary.BigEndian.Uint16(setHeader[0:2]))
+		assert.Equal(t, uint16(12), binary.BigEndian.Uint16(setHeader[2:4]))
+	}
+	bytesSent, err = exporter.Flush()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, bytesSent, "Nothing should be sent when there are no buffered sets.")
+
+	// The message is sent when the next set does not fit in it.
+	totalBytesSent := 0
+	for i := 0; i < 42; i++ {
+		bytesSent, err := exporter.SendSet(createDataSetForBuffering(t, exporter, templateID, 1))
+		assert.NoError(t, err)
+		totalBytesSent += bytesSent
+	}
+	assert.Equal(t, 16+41*12, totalBytesSent)
+	msg = <-msgCh
+	assert.Equal(t, 16+41*12, len(msg))
+	assert.Equal(t, uint32(3), binary.BigEndian.Uint32(msg[8:12]))
+
+	// Remaining set is sent after MaxDelay.
+	select {
+	case msg = <-msgCh:
+		assert.Equal(t, 16+12, len(msg))
+		assert.Equal(t, uint32(44), binary.BigEndian.Uint32(msg[8:12]))
+	case <-time.After(time.Second):
+		t.Errorf("Buffered set is not sent after MaxDelay")
+	}
+}
+
+// acceptTCPMessages accepts one connection on the listener, and sends the
+// messages received on the connection to the returned channel.
+func acceptTCPMessages(listener net.Listener) (chan net.Conn, chan []byte) {
+	connCh := make(chan net.Conn, 1)
+	msgCh := make(chan []byte, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		connCh <- conn
+		for {
+			header := make([]byte, entities.MsgHeaderLength)
+			if _, err := io.ReadFull(conn, header); err != nil {
+				return
+			}
+			msg := make([]byte, binary.BigEndian.Uint16(header[2:4]))
+			copy(msg, header)
+			if _, err := io.ReadFull(conn, msg[entities.MsgHeaderLength:]); err != nil {
+				return
+			}
+			msgCh <- msg
+		}
+	}()
+	return connCh, msgCh
+}
+
+func TestExportingProcess_ReconnectToLocalTCPServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Got error when creating a local server: %v", err)
+	}
+	connCh, msgCh := acceptTCPMessages(listener)
+
+	stateCh := make(chan ConnectionState, 2)
+	exporter, err := InitExportingProcess(ExporterInput{
+		CollectorAddr:       listener.Addr(),
+		ObservationDomainID: 1,
+		EnableReconnect:     true,
+		ReconnectBaseDelay:  10 * time.Millisecond,
+		ReconnectMaxDelay:   50 * time.Millisecond,
+		MaxPendingDataSets:  1,
+		ConnectionStateHandler: func(state ConnectionState, err error) {
+			stateCh <- state
+		},
+	})
+	if err != nil {
+		t.Fatalf("Got error when connecting to local server %s: %v", listener.Addr().String(), err)
+	}
+	defer exporter.CloseConnToCollector()
+
+	sourceAddress, _ := registry.GetInfoElement("sourceIPv4Address", registry.IANAEnterpriseID)
+	templateID := exporter.NewTemplateID()
+	templateSet := entities.NewSet(entities.Template, templateID, false)
+	templateSet.AddRecord([]*entities.InfoElementWithValue{entities.NewInfoElementWithValue(sourceAddress, nil)}, templateID)
+	_, err = exporter.SendSet(templateSet)
+	assert.NoError(t, err)
+	msg := <-msgCh
+	assert.Equal(t, entities.TemplateSetID, binary.BigEndian.Uint16(msg[16:18]))
+
+	// Server is stopped, so the exporting process cannot reconnect.
+	listener.Close()
+	conn := <-connCh
+	conn.Close()
+	assert.Equal(t, Disconnected, <-stateCh)
+	// Data sets are kept up to MaxPendingDataSets while reconnecting.
+	createDataSet := func(ip string) entities.Set {
+		dataSet := entities.NewSet(entities.Data, templateID, false)
+		dataSet.AddRecord([]*entities.InfoElementWithValue{entities.NewInfoElementWithValue(sourceAddress, net.ParseIP(ip))}, templateID)
+		return dataSet
+	}
+	_, err = exporter.SendSet(createDataSet("1.2.3.4"))
+	assert.NoError(t, err)
+	_, err = exporter.SendSet(createDataSet("5.6.7.8"))
+	assert.Error(t, err)
+
+	// Templates are sent on the new connection before the pending data set.
+	listener, err = net.Listen("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Got error when restarting the local server: %v", err)
+	}
+	defer listener.Close()
+	_, msgCh = acceptTCPMessages(listener)
+	assert.Equal(t, Connected, <-stateCh)
+	msg = <-msgCh
+	assert.Equal(t, entities.TemplateSetID, binary.BigEndian.Uint16(msg[16:18]))
+	msg = <-msgCh
+	assert.Equal(t, templateID, binary.BigEndian.Uint16(msg[16:18]))
+	assert.Equal(t, []byte{1, 2, 3, 4}, msg[20:24])
+	_, err = exporter.SendSet(createDataSet("5.6.7.8"))
+	assert.NoError(t, err)
+	msg = <-msgCh
+	assert.Equal(t, []byte{5, 6, 7, 8}, msg[20:24])
+}
+
+// fakeConn is a connection to the collector which sends the written messages
+// to msgCh, and fails to write after maxWrites messages if maxWrites is not
+// negative.
+type fakeConn struct {
+	net.Conn
+	maxWrites int
+	msgCh     chan []byte
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+func newFakeConn(maxWrites int) *fakeConn {
+	return &fakeConn{maxWrites: maxWrites, msgCh: make(chan []byte, 10), closeCh: make(chan struct{})}
+}
+
+func (c *fakeConn) Write(b []byte) (int, error) {
+	if c.maxWrites == 0 {
+		return 0, fmt.Errorf("connection is broken")
+	}
+	c.maxWrites--
+	c.msgCh <- append([]byte(nil), b...)
+	return len(b), nil
+}
+
+func (c *fakeConn) Read(b []byte) (int, error) {
+	<-c.closeCh
+	return 0, io.EOF
+}
+
+func (c *fakeConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closeCh) })
+	return nil
+}
+
+func TestExportingProcess_ResumeConnectionAfterPartialSend(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Got error when creating a local server: %v", err)
+	}
+	defer listener.Close()
+	connCh, msgCh := acceptTCPMessages(listener)
+
+	stateCh := make(chan ConnectionState, 3)
+	exporter, err := InitExportingProcess(ExporterInput{
+		CollectorAddr:       listener.Addr(),
+		ObservationDomainID: 1,
+		EnableReconnect:     true,
+		ReconnectBaseDelay:  10 * time.Millisecond,
+		ReconnectMaxDelay:   10 * time.Millisecond,
+		MaxPendingDataSets:  3,
+		ConnectionStateHandler: func(state ConnectionState, err error) {
+			stateCh <- state
+		},
+	})
+	if err != nil {
+		t.Fatalf("Got error when connecting to local server %s: %v", listener.Addr().String(), err)
+	}
+	defer exporter.CloseConnToCollector()
+	conns := make(chan net.Conn, 2)
+	exporter.dial = func() (net.Conn, error) {
+		return <-conns, nil
+	}
+
+	sourceAddress, _ := registry.GetInfoElement("sourceIPv4Address", registry.IANAEnterpriseID)
+	templateID := exporter.NewTemplateID()
+	templateSet := entities.NewSet(entities.Template, templateID, false)
+	templateSet.AddRecord([]*entities.InfoElementWithValue{entities.NewInfoElementWithValue(sourceAddress, nil)}, templateID)
+	_, err = exporter.SendSet(templateSet)
+	assert.NoError(t, err)
+	<-msgCh
+	conn := <-connCh
+	conn.Close()
+	assert.Equal(t, Disconnected, <-stateCh)
+	for _, ip := range []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"} {
+		dataSet := entities.NewSet(entities.Data, templateID, false)
+		dataSet.AddRecord([]*entities.InfoElementWithValue{entities.NewInfoElementWithValue(sourceAddress, net.ParseIP(ip))}, templateID)
+		_, err = exporter.SendSet(dataSet)
+		assert.NoError(t, err)
+	}
+
+	// The new connection is broken after the template and the first pending
+	// data set are sent.
+	brokenConn := newFakeConn(2)
+	conns <- brokenConn
+	msg := <-brokenConn.msgCh
+	assert.Equal(t, entities.TemplateSetID, binary.BigEndian.Uint16(msg[16:18]))
+	msg = <-brokenConn.msgCh
+	assert.Equal(t, []byte{1, 1, 1, 1}, msg[20:24])
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(msg[8:12]))
+
+	// Only the data sets which are not sent are sent on the next connection.
+	resumedConn := newFakeConn(-1)
+	conns <- resumedConn
+	assert.Equal(t, Connected, <-stateCh)
+	msg = <-resumedConn.msgCh
+	assert.Equal(t, entities.TemplateSetID, binary.BigEndian.Uint16(msg[16:18]))
+	// Data records of messages which are not sent are not counted in the
+	// sequence number.
+	msg = <-resumedConn.msgCh
+	assert.Equal(t, []byte{2, 2, 2, 2}, msg[20:24])
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(msg[8:12]))
+	msg = <-resumedConn.msgCh
+	assert.Equal(t, []byte{3, 3, 3, 3}, msg[20:24])
+	assert.Equal(t, uint32(2), binary.BigEndian.Uint32(msg[8:12]))
+	assert.Empty(t, resumedConn.msgCh)
+}
+
+func TestExportingProcess_TypeInformationAfterFailedSend(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Got error when creating a local server: %v", err)
+	}
+	defer listener.Close()
+	acceptTCPMessages(listener)
+
+	exporter, err := InitExportingProcess(ExporterInput{
+		CollectorAddr:       listener.Addr(),
+		ObservationDomainID: 1,
+		SendTypeInformation: true,
+	})
+	if err != nil {
+		t.Fatalf("Got error when connecting to local server %s: %v", listener.Addr().String(), err)
+	}
+	defer exporter.CloseConnToCollector()
+	exporter.msgMutex.Lock()
+	exporter.connToCollector = newFakeConn(0)
+	exporter.msgMutex.Unlock()
+
+	vendorCounter := entities.NewInfoElement("vendorCounter", 1, entities.Unsigned32, 12345, 4)
+	templateID := exporter.NewTemplateID()
+	templateSet := entities.NewSet(entities.Template, templateID, false)
+	templateSet.AddRecord([]*entities.InfoElementWithValue{entities.NewInfoElementWithValue(vendorCounter, nil)}, templateID)
+	_, err = exporter.SendSet(templateSet)
+	assert.Error(t, err)
+	domain := exporter.GetObservationDomain(1)
+	assert.Empty(t, domain.describedElements, "Element should not be described if type information is not sent.")
+
+	// Type information records are sent again with the template.
+	conn := newFakeConn(-1)
+	exporter.msgMutex.Lock()
+	exporter.connToCollector = conn
+	exporter.msgMutex.Unlock()
+	_, err = exporter.SendSet(templateSet)
+	assert.NoError(t, err)
+	msg := <-conn.msgCh
+	assert.Equal(t, domain.typeInfoTemplateID, binary.BigEndian.Uint16(msg[16:18]))
+	msg = <-conn.msgCh
+	assert.Equal(t, entities.TemplateSetID, binary.BigEndian.Uint16(msg[16:18]))
+	assert.True(t, domain.describedElements[elementKey{12345, 1}])
+}
+
+func TestExportingProcess_ObservationDomainsToLocalTCPServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Got error when creating a local server: %v", err)
+	}
+	defer listener.Close()
+	_, msgCh := acceptTCPMessages(listener)
+
+	exporter, err := InitExportingProcess(ExporterInput{
+		CollectorAddr:       listener.Addr(),
+		ObservationDomainID: 1,
+		EnableBuffering:     true,
+		MaxDelay:            time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Got error when connecting to local server %s: %v", listener.Addr().String(), err)
+	}
+	defer exporter.CloseConnToCollector()
+	domain1 := exporter.GetObservationDomain(1)
+	domain2 := exporter.GetObservationDomain(2)
+	assert.Equal(t, domain1, exporter.GetObservationDomain(1))
+	assert.Equal(t, uint32(2), domain2.GetObservationDomainID())
+
+	// Template IDs are allocated for each observation domain.
+	templateID := exporter.NewTemplateID()
+	assert.Equal(t, templateID, domain2.NewTemplateID())
+	templateSet, dataSet := createTemplateAndDataSet(templateID)
+	_, err = domain1.SendSet(templateSet)
+	assert.NoError(t, err)
+	_, err = exporter.SendSet(dataSet)
+	assert.NoError(t, err)
+	_, err = exporter.GetObservationDomain(3).SendSet(dataSet)
+	assert.Error(t, err, "Template should not exist in other observation domains.")
+	_, err = domain2.SendSet(templateSet)
+	assert.NoError(t, err)
+	_, err = domain2.SendSet(dataSet)
+	assert.NoError(t, err)
+	_, err = domain2.SendSet(dataSet)
+	assert.NoError(t, err)
+	_, err = exporter.Flush()
+	assert.NoError(t, err)
+
+	// Sets of different observation domains are sent in different messages,
+	// with the sequence numbers of the observation domains.
+	msg := <-msgCh
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(msg[12:16]))
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(msg[8:12]))
+	msg = <-msgCh
+	assert.Equal(t, uint32(2), binary.BigEndian.Uint32(msg[12:16]))
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(msg[8:12]))
+	_, err = exporter.SendSet(dataSet)
+	assert.NoError(t, err)
+	_, err = exporter.Flush()
+	assert.NoError(t, err)
+	msg = <-msgCh
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(msg[12:16]))
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(msg[8:12]))
 }
 
 func TestExportingProcessWithTLS(t *testing.T) {
@@ -541,7 +1063,7 @@ func TestExportingProcessWithTLS(t *testing.T) {
 	}
 	// 32 is the size of the IPFIX message including all headers
 	assert.Equal(t, 32, bytesSent)
-	assert.Equal(t, uint32(0), exporter.seqNumber)
+	assert.Equal(t, uint32(0), exporter.defaultDomain.seqNumber)
 	exporter.CloseConnToCollector()
 }
 
@@ -623,7 +1145,7 @@ func TestExportingProcessWithDTLS(t *testing.T) {
 	}
 	// 32 is the size of the IPFIX message including all headers
 	assert.Equal(t, 32, bytesSent)
-	assert.Equal(t, uint32(0), exporter.seqNumber)
+	assert.Equal(t, uint32(0), exporter.defaultDomain.seqNumber)
 	exporter.CloseConnToCollector()
 }
 
@@ -0,0 +1,238 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+)
+
+// TemplateInfo describes a (options) template of the exporting process.
+type TemplateInfo struct {
+	ObsDomainID uint32
+	TemplateID  uint16
+	// ScopeFieldCount is non-zero only for options templates.
+	ScopeFieldCount uint16
+	Elements        []*entities.InfoElement
+}
+
+func newTemplateValue(elements []*entities.InfoElementWithValue, minDataRecLen uint16, scopeFieldCount uint16) templateValue {
+	template := templateValue{
+		make([]*entities.InfoElement, len(elements)),
+		minDataRecLen,
+		scopeFieldCount,
+	}
+	for i, elem := range elements {
+		template.elements[i] = elem.Element
+	}
+	return template
+}
+
+// isEqual returns true if both templates have the same fields.
+func (t templateValue) isEqual(other templateValue) bool {
+	if t.scopeFieldCount != other.scopeFieldCount || len(t.elements) != len(other.elements) {
+		return false
+	}
+	for i, element := range t.elements {
+		otherElement := other.elements[i]
+		if element.ElementId != otherElement.ElementId || element.EnterpriseId != otherElement.EnterpriseId || element.Len != otherElement.Len {
+			return false
+		}
+	}
+	return true
+}
+
+func (t templateValue) getSetType() entities.ContentType {
+	if t.scopeFieldCount > 0 {
+		return entities.OptionsTemplate
+	}
+	return entities.Template
+}
+
+// ListTemplates returns the templates of all observation domains, ordered by
+// obsDomainID and template ID.
+func (ep *ExportingProcess) ListTemplates() []*TemplateInfo {
+	templates := make([]*TemplateInfo, 0)
+	for _, d := range ep.getObservationDomains() {
+		templates = append(templates, d.ListTemplates()...)
+	}
+	return templates
+}
+
+// ListTemplates returns the templates of the observation domain, ordered by
+// template ID. The options template of type information records is included
+// if type information is sent.
+func (d *ObservationDomain) ListTemplates() []*TemplateInfo {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	templates := make([]*TemplateInfo, 0, len(d.templatesMap))
+	for templateID, template := range d.templatesMap {
+		templates = append(templates, &TemplateInfo{
+			ObsDomainID:     d.obsDomainID,
+			TemplateID:      templateID,
+			ScopeFieldCount: template.scopeFieldCount,
+			Elements:        template.elements,
+		})
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].TemplateID < templates[j].TemplateID
+	})
+	return templates
+}
+
+// WithdrawTemplate withdraws the template of the observation domain of
+// ExporterInput.
+func (ep *ExportingProcess) WithdrawTemplate(templateID uint16) error {
+	return ep.defaultDomain.WithdrawTemplate(templateID)
+}
+
+// WithdrawAllTemplates withdraws the templates of all observation domains.
+func (ep *ExportingProcess) WithdrawAllTemplates() error {
+	for _, d := range ep.getObservationDomains() {
+		if err := d.WithdrawAllTemplates(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithdrawTemplate withdraws the template, which is no longer refreshed, and
+// whose ID can be allocated again. Template withdrawal is sent to collectors
+// over TCP. It is not allowed over UDP (RFC7011 section 8.1), where collectors
+// expire the template instead.
+func (d *ObservationDomain) WithdrawTemplate(templateID uint16) error {
+	template, exist := d.removeTemplate(templateID)
+	if !exist {
+		return fmt.Errorf("template %d does not exist in observation domain %d", templateID, d.obsDomainID)
+	}
+	d.templateIDs.releaseTemplateID(templateID)
+	return d.sendTemplateWithdrawal(template.getSetType(), templateID)
+}
+
+// WithdrawAllTemplates withdraws all templates of the observation domain,
+// including the options template of type information records.
+func (d *ObservationDomain) WithdrawAllTemplates() error {
+	templates := d.removeAllTemplates()
+	setTypes := make(map[entities.ContentType]bool)
+	for templateID, template := range templates {
+		setTypes[template.getSetType()] = true
+		d.templateIDs.releaseTemplateID(templateID)
+	}
+	return d.sendAllTemplatesWithdrawal(setTypes)
+}
+
+// removeTemplate removes the template without releasing its ID. It returns
+// false if the template does not exist.
+func (d *ObservationDomain) removeTemplate(templateID uint16) (templateValue, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	template, exist := d.templatesMap[templateID]
+	if !exist {
+		return templateValue{}, false
+	}
+	delete(d.templatesMap, templateID)
+	if templateID == d.typeInfoTemplateID {
+		// Type information is sent again with a new template.
+		d.typeInfoTemplateID = 0
+		d.describedElements = make(map[elementKey]bool)
+	}
+	return template, true
+}
+
+// removeAllTemplates removes all templates without releasing their IDs, and
+// returns the removed templates.
+func (d *ObservationDomain) removeAllTemplates() map[uint16]templateValue {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	templates := d.templatesMap
+	d.templatesMap = make(map[uint16]templateValue)
+	d.typeInfoTemplateID = 0
+	d.describedElements = make(map[elementKey]bool)
+	return templates
+}
+
+// sendTemplateWithdrawal sends the withdrawal of the template if withdrawal is
+// allowed.
+func (d *ObservationDomain) sendTemplateWithdrawal(setType entities.ContentType, templateID uint16) error {
+	if !d.ep.isWithdrawalAllowed() {
+		return nil
+	}
+	if _, err := d.sendSet(newWithdrawalSet(setType, templateID)); err != nil {
+		return fmt.Errorf("error when sending template withdrawal: %v", err)
+	}
+	return nil
+}
+
+// sendAllTemplatesWithdrawal sends the withdrawal of all templates of the set
+// types if withdrawal is allowed. Withdrawal with the set ID as template ID
+// withdraws all templates of the set type.
+func (d *ObservationDomain) sendAllTemplatesWithdrawal(setTypes map[entities.ContentType]bool) error {
+	if setTypes[entities.Template] {
+		if err := d.sendTemplateWithdrawal(entities.Template, entities.TemplateSetID); err != nil {
+			return err
+		}
+	}
+	if setTypes[entities.OptionsTemplate] {
+		if err := d.sendTemplateWithdrawal(entities.OptionsTemplate, entities.OptionsTemplateSetID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getRedefinitionWithdrawalSets returns the withdrawal sets of the templates
+// which are redefined by the template set. Templates are withdrawn before
+// being redefined (RFC7011 section 8.1).
+func (d *ObservationDomain) getRedefinitionWithdrawalSets(templateSet entities.Set) []entities.Set {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	withdrawnIDs := make(map[entities.ContentType][]uint16)
+	for _, record := range templateSet.GetRecords() {
+		oldTemplate, exist := d.templatesMap[record.GetTemplateID()]
+		if !exist || record.GetFieldCount() == 0 {
+			continue
+		}
+		newTemplate := newTemplateValue(record.GetOrderedElementList(), record.GetMinDataRecordLen(), record.GetScopeFieldCount())
+		if !oldTemplate.isEqual(newTemplate) {
+			setType := oldTemplate.getSetType()
+			withdrawnIDs[setType] = append(withdrawnIDs[setType], record.GetTemplateID())
+		}
+	}
+	sets := make([]entities.Set, 0)
+	for _, setType := range []entities.ContentType{entities.Template, entities.OptionsTemplate} {
+		if len(withdrawnIDs[setType]) > 0 {
+			sets = append(sets, newWithdrawalSet(setType, withdrawnIDs[setType]...))
+		}
+	}
+	return sets
+}
+
+// newWithdrawalSet returns the (options) template set of withdrawal records
+// of the templates.
+func newWithdrawalSet(setType entities.ContentType, templateIDs ...uint16) entities.Set {
+	set := entities.NewSet(setType, templateIDs[0], false)
+	for _, templateID := range templateIDs {
+		set.AddRecord(nil, templateID)
+	}
+	return set
+}
+
+// isWithdrawalAllowed returns true if template withdrawal can be sent to the
+// collector, which is not allowed over UDP.
+func (ep *ExportingProcess) isWithdrawalAllowed() bool {
+	return ep.collectorAddr.Network() == "tcp"
+}
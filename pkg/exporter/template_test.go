@@ -0,0 +1,143 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"encoding/binary"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+	"github.com/vmware/go-ipfix/pkg/registry"
+)
+
+func TestTemplateIDAllocator(t *testing.T) {
+	allocator := newTemplateIDAllocator()
+	assert.Equal(t, uint16(256), allocator.newTemplateID())
+	allocator.releaseTemplateID(256)
+	assert.Equal(t, uint16(257), allocator.newTemplateID())
+	allocator.templateID = math.MaxUint16 - 1
+	assert.Equal(t, uint16(math.MaxUint16), allocator.newTemplateID())
+	// Released IDs are reused in order once all IDs are allocated.
+	allocator.releaseTemplateID(300)
+	allocator.releaseTemplateID(300)
+	assert.Equal(t, uint16(256), allocator.newTemplateID())
+	assert.Equal(t, uint16(300), allocator.newTemplateID())
+	assert.Equal(t, uint16(0), allocator.newTemplateID())
+}
+
+func TestExportingProcess_WithdrawTemplateToLocalTCPServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Got error when creating a local server: %v", err)
+	}
+	defer listener.Close()
+	_, msgCh := acceptTCPMessages(listener)
+	exporter, err := InitExportingProcess(ExporterInput{
+		CollectorAddr:       listener.Addr(),
+		ObservationDomainID: 1,
+	})
+	if err != nil {
+		t.Fatalf("Got error when connecting to local server %s: %v", listener.Addr().String(), err)
+	}
+	defer exporter.CloseConnToCollector()
+
+	templateID := exporter.NewTemplateID()
+	templateSet, dataSet := createTemplateAndDataSet(templateID)
+	_, err = exporter.SendSet(templateSet)
+	assert.NoError(t, err)
+	<-msgCh
+	templates := exporter.ListTemplates()
+	assert.Equal(t, 1, len(templates))
+	assert.Equal(t, uint32(1), templates[0].ObsDomainID)
+	assert.Equal(t, templateID, templates[0].TemplateID)
+	assert.Equal(t, "sourceIPv4Address", templates[0].Elements[0].Name)
+
+	// Template is withdrawn before it is redefined, and sent again as is.
+	destinationAddress, _ := registry.GetInfoElement("destinationIPv4Address", registry.IANAEnterpriseID)
+	newTemplateSet := entities.NewSet(entities.Template, templateID, false)
+	newTemplateSet.AddRecord([]*entities.InfoElementWithValue{entities.NewInfoElementWithValue(destinationAddress, nil)}, templateID)
+	_, err = exporter.SendSet(newTemplateSet)
+	assert.NoError(t, err)
+	msg := <-msgCh
+	assert.Equal(t, entities.TemplateSetID, binary.BigEndian.Uint16(msg[16:18]))
+	assert.Equal(t, []byte{1, 0, 0, 0}, msg[20:24])
+	msg = <-msgCh
+	assert.Equal(t, destinationAddress.ElementId, binary.BigEndian.Uint16(msg[24:26]))
+	assert.Equal(t, "destinationIPv4Address", exporter.ListTemplates()[0].Elements[0].Name)
+	_, err = exporter.SendSet(newTemplateSet)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(1), binary.BigEndian.Uint16((<-msgCh)[22:24]))
+
+	assert.NoError(t, exporter.WithdrawTemplate(templateID))
+	msg = <-msgCh
+	assert.Equal(t, entities.TemplateSetID, binary.BigEndian.Uint16(msg[16:18]))
+	assert.Equal(t, []byte{1, 0, 0, 0}, msg[20:24])
+	assert.Equal(t, 0, len(exporter.ListTemplates()))
+	assert.Error(t, exporter.WithdrawTemplate(templateID))
+	_, err = exporter.SendSet(dataSet)
+	assert.Error(t, err)
+
+	// All templates are withdrawn with the set ID.
+	_, err = exporter.SendSet(templateSet)
+	assert.NoError(t, err)
+	<-msgCh
+	_, err = exporter.GetObservationDomain(2).SendSet(templateSet)
+	assert.NoError(t, err)
+	<-msgCh
+	assert.Equal(t, 2, len(exporter.ListTemplates()))
+	assert.NoError(t, exporter.WithdrawAllTemplates())
+	for _, obsDomainID := range []uint32{1, 2} {
+		msg = <-msgCh
+		assert.Equal(t, obsDomainID, binary.BigEndian.Uint32(msg[12:16]))
+		assert.Equal(t, []byte{0, 2, 0, 0}, msg[20:24])
+	}
+	assert.Equal(t, 0, len(exporter.ListTemplates()))
+
+	// Template IDs less than 256 are not valid.
+	invalidTemplateSet, _ := createTemplateAndDataSet(entities.OptionsTemplateSetID)
+	_, err = exporter.SendSet(invalidTemplateSet)
+	assert.Error(t, err)
+}
+
+func TestExportingProcess_WithdrawTemplateToLocalUDPServer(t *testing.T) {
+	conn, msgCh := startUDPServer(t)
+	defer conn.Close()
+	exporter, err := InitExportingProcess(ExporterInput{
+		CollectorAddr:       conn.LocalAddr(),
+		ObservationDomainID: 1,
+	})
+	if err != nil {
+		t.Fatalf("Got error when connecting to local server %s: %v", conn.LocalAddr().String(), err)
+	}
+	defer exporter.CloseConnToCollector()
+
+	templateID := exporter.NewTemplateID()
+	templateSet, _ := createTemplateAndDataSet(templateID)
+	_, err = exporter.SendSet(templateSet)
+	assert.NoError(t, err)
+	<-msgCh
+	// Template withdrawal is not sent over UDP.
+	assert.NoError(t, exporter.WithdrawTemplate(templateID))
+	assert.Equal(t, 0, len(exporter.ListTemplates()))
+	templateID = exporter.NewTemplateID()
+	templateSet, _ = createTemplateAndDataSet(templateID)
+	_, err = exporter.SendSet(templateSet)
+	assert.NoError(t, err)
+	assert.Equal(t, templateID, binary.BigEndian.Uint16((<-msgCh)[20:22]))
+}
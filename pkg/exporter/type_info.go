@@ -0,0 +1,145 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"fmt"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+	"github.com/vmware/go-ipfix/pkg/registry"
+)
+
+// typeInformationFields are the fields of type information records (RFC5610).
+// informationElementId and privateEnterpriseNumber are the scope fields.
+var typeInformationFields = []string{
+	"informationElementId",
+	"privateEnterpriseNumber",
+	"informationElementDataType",
+	"informationElementSemantics",
+	"informationElementName",
+}
+
+const typeInformationScopeFieldCount uint16 = 2
+
+// elementKey identifies an Information Element.
+type elementKey struct {
+	enterpriseID uint32
+	elementID    uint16
+}
+
+// isDescribedElement returns true if collectors may not know the element, so
+// it needs to be described with type information. IANA and reverse IANA
+// elements are known to collectors.
+func isDescribedElement(element *entities.InfoElement) bool {
+	return element.EnterpriseId != registry.IANAEnterpriseID && element.EnterpriseId != registry.IANAReversedEnterpriseID
+}
+
+// getTypeInformationSets returns the sets of type information records of the
+// enterprise-specific elements of the (options) template set which have not
+// been described yet in the observation domain. The type information options
+// template set is returned before the first records. The elements are marked
+// as described by setElementsDescribed once the sets are sent.
+func (d *ObservationDomain) getTypeInformationSets(templateSet entities.Set) ([]entities.Set, error) {
+	d.mutex.Lock()
+	elements := make([]*entities.InfoElement, 0)
+	elementKeys := make(map[elementKey]bool)
+	for _, record := range templateSet.GetRecords() {
+		for _, ie := range record.GetOrderedElementList() {
+			key := elementKey{ie.Element.EnterpriseId, ie.Element.ElementId}
+			if !isDescribedElement(ie.Element) || d.describedElements[key] || elementKeys[key] {
+				continue
+			}
+			elementKeys[key] = true
+			elements = append(elements, ie.Element)
+		}
+	}
+	typeInfoTemplateID := d.typeInfoTemplateID
+	isNewTemplate := false
+	if len(elements) > 0 && typeInfoTemplateID == 0 {
+		typeInfoTemplateID = d.NewTemplateID()
+		d.typeInfoTemplateID = typeInfoTemplateID
+		isNewTemplate = true
+	}
+	d.mutex.Unlock()
+	if len(elements) == 0 {
+		return nil, nil
+	}
+
+	typeInfoElements := make([]*entities.InfoElement, len(typeInformationFields))
+	for i, name := range typeInformationFields {
+		element, err := d.ep.registry.LookupByName(name, registry.IANAEnterpriseID)
+		if err != nil {
+			return nil, fmt.Errorf("error when getting type information elements: %v", err)
+		}
+		typeInfoElements[i] = element
+	}
+	sets := make([]entities.Set, 0)
+	if isNewTemplate {
+		elementsWithValue := make([]*entities.InfoElementWithValue, len(typeInfoElements))
+		for i, element := range typeInfoElements {
+			elementsWithValue[i] = entities.NewInfoElementWithValue(element, nil)
+		}
+		templateSet := entities.NewSet(entities.OptionsTemplate, typeInfoTemplateID, false)
+		if err := templateSet.AddOptionsRecord(elementsWithValue, typeInformationScopeFieldCount, typeInfoTemplateID); err != nil {
+			return nil, err
+		}
+		sets = append(sets, templateSet)
+	}
+
+	// Records are split into multiple sets if they do not fit in a message.
+	maxSetLen := d.ep.GetMsgSizeLimit() - entities.MsgHeaderLength
+	dataSet := entities.NewSet(entities.Data, typeInfoTemplateID, false)
+	for _, element := range elements {
+		if dataSet.GetNumberOfRecords() > 0 && dataSet.GetBuffLen()+getTypeInformationRecordLen(element) > maxSetLen {
+			sets = append(sets, dataSet)
+			dataSet = entities.NewSet(entities.Data, typeInfoTemplateID, false)
+		}
+		values := []interface{}{element.ElementId, element.EnterpriseId, uint8(element.DataType), uint8(element.Semantics), element.Name}
+		elementsWithValue := make([]*entities.InfoElementWithValue, len(typeInfoElements))
+		for i, typeInfoElement := range typeInfoElements {
+			elementsWithValue[i] = entities.NewInfoElementWithValue(typeInfoElement, values[i])
+		}
+		if err := dataSet.AddOptionsRecord(elementsWithValue, typeInformationScopeFieldCount, typeInfoTemplateID); err != nil {
+			return nil, err
+		}
+	}
+	return append(sets, dataSet), nil
+}
+
+// setElementsDescribed marks the enterprise-specific elements of the (options)
+// template set as described, after their type information is sent.
+func (d *ObservationDomain) setElementsDescribed(templateSet entities.Set) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for _, record := range templateSet.GetRecords() {
+		for _, ie := range record.GetOrderedElementList() {
+			if isDescribedElement(ie.Element) {
+				d.describedElements[elementKey{ie.Element.EnterpriseId, ie.Element.ElementId}] = true
+			}
+		}
+	}
+}
+
+// getTypeInformationRecordLen returns the length of the type information record
+// of the element.
+func getTypeInformationRecordLen(element *entities.InfoElement) int {
+	// informationElementId, privateEnterpriseNumber, informationElementDataType
+	// and informationElementSemantics, followed by the name in variable length.
+	length := 2 + 4 + 1 + 1 + len(element.Name)
+	if len(element.Name) < 255 {
+		return length + 1
+	}
+	return length + 3
+}
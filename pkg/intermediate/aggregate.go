@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"net"
 	"strings"
 	"sync"
@@ -51,6 +52,8 @@ type AggregationProcess struct {
 	aggregateElements *AggregationElements
 	// stopChan is the channel to receive stop message
 	stopChan chan bool
+	// registry is used to look up Information Elements added to records
+	registry *registry.Registry
 }
 
 type AggregationInput struct {
@@ -58,6 +61,9 @@ type AggregationInput struct {
 	WorkerNum         int
 	CorrelateFields   []string
 	AggregateElements *AggregationElements
+	// Registry is the registry of Information Elements. The default registry
+	// is used if it is not given.
+	Registry *registry.Registry
 }
 
 // InitAggregationProcess takes in message channel (e.g. from collector) as input channel, workerNum(number of workers to process message)
@@ -68,6 +74,9 @@ func InitAggregationProcess(input AggregationInput) (*AggregationProcess, error)
 	} else if input.WorkerNum <= 0 {
 		return nil, fmt.Errorf("worker number cannot be <= 0")
 	}
+	if input.Registry == nil {
+		input.Registry = registry.Default()
+	}
 	return &AggregationProcess{
 		make(map[FlowKey]AggregationFlowRecord),
 		sync.RWMutex{},
@@ -77,6 +86,7 @@ func InitAggregationProcess(input AggregationInput) (*AggregationProcess, error)
 		input.CorrelateFields,
 		input.AggregateElements,
 		make(chan bool),
+		input.Registry,
 	}, nil
 }
 
@@ -102,21 +112,27 @@ func (a *AggregationProcess) Stop() {
 
 // AggregateMsgByFlowKey gets flow key from records in message and stores in cache
 func (a *AggregationProcess) AggregateMsgByFlowKey(message *entities.Message) error {
-	if err := addOriginalExporterInfo(message); err != nil {
+	if err := addOriginalExporterInfo(message, a.registry); err != nil {
 		return err
 	}
-	set := message.GetSet()
-	if set.GetSetType() == entities.Template { // skip template records
-		return nil
-	}
-	records := set.GetRecords()
-	for _, record := range records {
-		flowKey, err := getFlowKeyFromRecord(record)
-		if err != nil {
-			return err
+	for _, set := range message.GetSets() {
+		// skip template and options template records
+		if set.GetSetType() == entities.Template || set.GetSetType() == entities.OptionsTemplate {
+			continue
 		}
-		if err = a.addOrUpdateRecordInMap(flowKey, record); err != nil {
-			return err
+		records := set.GetRecords()
+		for _, record := range records {
+			// Options data records carry exporter metadata rather than flows.
+			if record.GetScopeFieldCount() > 0 {
+				continue
+			}
+			flowKey, err := getFlowKeyFromRecord(record)
+			if err != nil {
+				return err
+			}
+			if err = a.addOrUpdateRecordInMap(flowKey, record); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -220,48 +236,48 @@ func (a *AggregationProcess) addOrUpdateRecordInMap(flowKey *FlowKey, record ent
 // fields.
 func (a *AggregationProcess) correlateRecords(incomingRecord, existingRecord entities.Record) {
 	for _, field := range a.correlateFields {
-		if ieWithValue, exist := incomingRecord.GetInfoElementWithValue(field); exist {
-			switch ieWithValue.Element.DataType {
-			case entities.String:
-				if ieWithValue.Value != "" {
-					existingIeWithValue, _ := existingRecord.GetInfoElementWithValue(field)
-					if existingIeWithValue.Value != "" {
-						klog.Warningf("This field with name %v should not have been filled with value %v in existing record.", field, existingIeWithValue.Value)
-					}
-					existingIeWithValue.Value = ieWithValue.Value
-				}
-			case entities.Unsigned16:
-				if ieWithValue.Value != uint16(0) {
-					existingIeWithValue, _ := existingRecord.GetInfoElementWithValue(field)
-					if existingIeWithValue.Value != uint16(0) {
-						klog.Warningf("This field with name %v should not have been filled with value %v in existing record.", field, existingIeWithValue.Value)
-					}
-					existingIeWithValue.Value = ieWithValue.Value
-				}
-			case entities.Ipv4Address:
-				ipInString := ieWithValue.Value.(net.IP).To4().String()
-				if ipInString != "0.0.0.0" {
-					existingIeWithValue, _ := existingRecord.GetInfoElementWithValue(field)
-					ipInString := existingIeWithValue.Value.(net.IP).To4().String()
-					if ipInString != "0.0.0.0" {
-						klog.Warningf("This field with name %v should not have been filled with value %v in existing record.", field, existingIeWithValue.Value)
-					}
-					existingIeWithValue.Value = ieWithValue.Value
-				}
-			case entities.Ipv6Address:
-				ipInString := ieWithValue.Value.(net.IP).To16().String()
-				if ipInString != net.ParseIP("::0").To16().String() {
-					existingIeWithValue, _ := existingRecord.GetInfoElementWithValue(field)
-					ipInString := existingIeWithValue.Value.(net.IP).To16().String()
-					if ipInString != net.ParseIP("::0").To16().String() {
-						klog.Warningf("This field with name %v should not have been filled with value %v in existing record.", field, existingIeWithValue.Value)
-					}
-					existingIeWithValue.Value = ieWithValue.Value
-				}
-			default:
-				klog.Errorf("Fields with dataType %v is not supported in correlation fields list.", ieWithValue.Element.DataType)
-			}
+		ieWithValue, exist := incomingRecord.GetInfoElementWithValue(field)
+		if !exist {
+			continue
+		}
+		isFilled, err := isCorrelateFieldFilled(incomingRecord, ieWithValue)
+		if err != nil {
+			klog.Errorf("Error when correlating field with name %v: %v", field, err)
+			continue
+		}
+		if !isFilled {
+			continue
+		}
+		existingIeWithValue, exist := existingRecord.GetInfoElementWithValue(field)
+		if !exist {
+			klog.Warningf("This field with name %v is not present in existing record.", field)
+			continue
+		}
+		if isFilled, err = isCorrelateFieldFilled(existingRecord, existingIeWithValue); err != nil {
+			klog.Warningf("Error when correlating field with name %v in existing record: %v", field, err)
+		} else if isFilled {
+			klog.Warningf("This field with name %v should not have been filled with value %v in existing record.", field, existingIeWithValue.Value)
 		}
+		existingIeWithValue.Value = ieWithValue.Value
+	}
+}
+
+// isCorrelateFieldFilled returns true if the correlation field of the record
+// has a value other than the zero value of its data type.
+func isCorrelateFieldFilled(record entities.Record, ieWithValue *entities.InfoElementWithValue) (bool, error) {
+	name := ieWithValue.Element.Name
+	switch ieWithValue.Element.DataType {
+	case entities.String:
+		value, _, err := record.GetString(name)
+		return value != "", err
+	case entities.Unsigned16:
+		value, _, err := record.GetUint64(name)
+		return value != 0, err
+	case entities.Ipv4Address, entities.Ipv6Address:
+		ip, _, err := record.GetIP(name)
+		return ip != nil && !ip.IsUnspecified(), err
+	default:
+		return false, fmt.Errorf("fields with dataType %v is not supported in correlation fields list", ieWithValue.Element.DataType)
 	}
 }
 
@@ -277,8 +293,18 @@ func (a *AggregationProcess) aggregateRecords(incomingRecord, existingRecord ent
 			switch ieWithValue.Element.Name {
 			case "flowEndSeconds":
 				existingIeWithValue, _ := existingRecord.GetInfoElementWithValue(element)
+				incomingTime, _, err := incomingRecord.GetUint64(element)
+				if err != nil {
+					klog.Warningf("Error when aggregating %s: %v", element, err)
+					break
+				}
+				existingTime, _, err := existingRecord.GetUint64(element)
+				if err != nil {
+					klog.Warningf("Error when aggregating %s: %v", element, err)
+					break
+				}
 				// Update flow end timestamp if it is latest.
-				if ieWithValue.Value.(uint32) > existingIeWithValue.Value.(uint32) {
+				if incomingTime > existingTime {
 					existingIeWithValue.Value = ieWithValue.Value
 				}
 			default:
@@ -293,40 +319,30 @@ func (a *AggregationProcess) aggregateRecords(incomingRecord, existingRecord ent
 	antreaSourceStatsElements := a.aggregateElements.AggregatedSourceStatsElements
 	antreaDestinationStatsElements := a.aggregateElements.AggregatedDestinationStatsElements
 	for i, element := range statsElementList {
-		isDelta := false
-		if strings.Contains(element, "Delta") {
-			isDelta = true
-		}
 		if ieWithValue, exist := incomingRecord.GetInfoElementWithValue(element); exist {
 			existingIeWithValue, _ := existingRecord.GetInfoElementWithValue(element)
 			// Update the corresponding element in existing record.
-			if !isDelta {
-				existingIeWithValue.Value = ieWithValue.Value
-			} else {
-				// We are simply adding the delta stats now. We expect delta stats to be
-				// reset after sending the record from flowKeyMap in aggregation process.
-				// Delta stats from source and destination nodes are added, so we will have
-				// two times the stats approximately.
-				// For delta stats, it is better to use source and destination specific
-				// stats.
-				existingIeWithValue.Value = existingIeWithValue.Value.(uint64) + ieWithValue.Value.(uint64)
+			// We are simply adding the delta stats now. We expect delta stats to be
+			// reset after sending the record from flowKeyMap in aggregation process.
+			// Delta stats from source and destination nodes are added, so we will have
+			// two times the stats approximately.
+			// For delta stats, it is better to use source and destination specific
+			// stats.
+			if err := aggregateElementValue(existingIeWithValue, ieWithValue.Value); err != nil {
+				return err
 			}
 			// Update the corresponding source element in antreaStatsElement list.
 			if fillSrcStats {
 				existingIeWithValue, _ := existingRecord.GetInfoElementWithValue(antreaSourceStatsElements[i])
-				if !isDelta {
-					existingIeWithValue.Value = ieWithValue.Value
-				} else {
-					existingIeWithValue.Value = existingIeWithValue.Value.(uint64) + ieWithValue.Value.(uint64)
+				if err := aggregateElementValue(existingIeWithValue, ieWithValue.Value); err != nil {
+					return err
 				}
 			}
 			// Update the corresponding destination element in antreaStatsElement list.
 			if fillDstStats {
 				existingIeWithValue, _ := existingRecord.GetInfoElementWithValue(antreaDestinationStatsElements[i])
-				if !isDelta {
-					existingIeWithValue.Value = ieWithValue.Value
-				} else {
-					existingIeWithValue.Value = existingIeWithValue.Value.(uint64) + ieWithValue.Value.(uint64)
+				if err := aggregateElementValue(existingIeWithValue, ieWithValue.Value); err != nil {
+					return err
 				}
 			}
 		} else {
@@ -348,7 +364,7 @@ func (a *AggregationProcess) addFieldsForStatsAggregation(record entities.Record
 	for _, element := range antreaElements {
 		// Get the new info element from Antrea registry.
 		// TODO: Take antrea registry enterpriseID as input to make this generic.
-		ie, err := registry.GetInfoElement(element, registry.AntreaEnterpriseID)
+		ie, err := a.registry.LookupByName(element, registry.AntreaEnterpriseID)
 		if err != nil {
 			return err
 		}
@@ -380,6 +396,58 @@ func (a *AggregationProcess) addFieldsForStatsAggregation(record entities.Record
 	return nil
 }
 
+// aggregateElementValue updates the value of the existing element with the
+// incoming value based on the semantics of the element. Delta counters are
+// added. Total counters are monotonically increasing, so the maximum value is
+// kept regardless of the order of records. Other values, including the values
+// of elements without semantics in the registry, are replaced.
+func aggregateElementValue(existingIeWithValue *entities.InfoElementWithValue, value interface{}) error {
+	semantics := existingIeWithValue.Element.Semantics
+	if semantics != entities.DeltaCounter && semantics != entities.TotalCounter && semantics != entities.SNMPCounter {
+		existingIeWithValue.Value = value
+		return nil
+	}
+	existingValue, ok1 := getUnsignedValue(existingIeWithValue.Value)
+	incomingValue, ok2 := getUnsignedValue(value)
+	if !ok1 || !ok2 {
+		return fmt.Errorf("values of counter %s are not unsigned integers", existingIeWithValue.Element.Name)
+	}
+	var result uint64
+	if semantics == entities.DeltaCounter {
+		result = existingValue + incomingValue
+	} else if incomingValue > existingValue {
+		result = incomingValue
+	} else {
+		result = existingValue
+	}
+	// Keep the type of the value for the data type of the element.
+	switch existingIeWithValue.Value.(type) {
+	case uint8:
+		existingIeWithValue.Value = uint8(result)
+	case uint16:
+		existingIeWithValue.Value = uint16(result)
+	case uint32:
+		existingIeWithValue.Value = uint32(result)
+	default:
+		existingIeWithValue.Value = result
+	}
+	return nil
+}
+
+func getUnsignedValue(value interface{}) (uint64, bool) {
+	switch v := value.(type) {
+	case uint8:
+		return uint64(v), true
+	case uint16:
+		return uint64(v), true
+	case uint32:
+		return uint64(v), true
+	case uint64:
+		return v, true
+	}
+	return 0, false
+}
+
 // isRecordIntraNode returns true if record belongs to intra-node flow.
 func isRecordIntraNode(record entities.Record) bool {
 	if srcIEWithValue, exist := record.GetInfoElementWithValue("sourcePodName"); exist && srcIEWithValue.Value != "" {
@@ -448,27 +516,28 @@ func getFlowKeyFromRecord(record entities.Record) (*FlowKey, error) {
 	for _, name := range elementList {
 		switch name {
 		case "sourceTransportPort", "destinationTransportPort":
-			element, exist := record.GetInfoElementWithValue(name)
+			port, exist, err := record.GetUint64(name)
 			if !exist {
 				return nil, fmt.Errorf("%s does not exist", name)
 			}
-			port, ok := element.Value.(uint16)
-			if !ok {
-				return nil, fmt.Errorf("%s is not in correct format", name)
+			if err != nil {
+				return nil, fmt.Errorf("%s is not in correct format: %v", name, err)
+			}
+			if port > math.MaxUint16 {
+				return nil, fmt.Errorf("%s is out of range: %d", name, port)
 			}
 			if name == "sourceTransportPort" {
-				flowKey.SourcePort = port
+				flowKey.SourcePort = uint16(port)
 			} else {
-				flowKey.DestinationPort = port
+				flowKey.DestinationPort = uint16(port)
 			}
 		case "sourceIPv4Address", "destinationIPv4Address":
-			element, exist := record.GetInfoElementWithValue(name)
+			addr, exist, err := record.GetIP(name)
 			if !exist {
 				break
 			}
-			addr, ok := element.Value.(net.IP)
-			if !ok {
-				return nil, fmt.Errorf("%s is not in correct format", name)
+			if err != nil {
+				return nil, fmt.Errorf("%s is not in correct format: %v", name, err)
 			}
 
 			if strings.Contains(name, "source") {
@@ -479,7 +548,7 @@ func getFlowKeyFromRecord(record entities.Record) (*FlowKey, error) {
 				flowKey.DestinationAddress = addr.String()
 			}
 		case "sourceIPv6Address", "destinationIPv6Address":
-			element, exist := record.GetInfoElementWithValue(name)
+			addr, exist, err := record.GetIP(name)
 			if (isSrcIPv4Filled && strings.Contains(name, "source")) || (isDstIPv4Filled && strings.Contains(name, "destination")) {
 				if exist {
 					klog.Warning("Two ip versions (IPv4 and IPv6) are not supported for flow key.")
@@ -489,9 +558,8 @@ func getFlowKeyFromRecord(record entities.Record) (*FlowKey, error) {
 			if !exist {
 				return nil, fmt.Errorf("%s does not exist", name)
 			}
-			addr, ok := element.Value.(net.IP)
-			if !ok {
-				return nil, fmt.Errorf("%s is not in correct format", name)
+			if err != nil {
+				return nil, fmt.Errorf("%s is not in correct format: %v", name, err)
 			}
 			if strings.Contains(name, "source") {
 				flowKey.SourceAddress = addr.String()
@@ -499,38 +567,55 @@ func getFlowKeyFromRecord(record entities.Record) (*FlowKey, error) {
 				flowKey.DestinationAddress = addr.String()
 			}
 		case "protocolIdentifier":
-			element, exist := record.GetInfoElementWithValue(name)
+			proto, exist, err := record.GetUint64(name)
 			if !exist {
 				return nil, fmt.Errorf("%s does not exist", name)
 			}
-			proto, ok := element.Value.(uint8)
-			if !ok {
-				return nil, fmt.Errorf("%s is not in correct format: %v", name, proto)
+			if err != nil {
+				return nil, fmt.Errorf("%s is not in correct format: %v", name, err)
 			}
-			flowKey.Protocol = proto
+			if proto > math.MaxUint8 {
+				return nil, fmt.Errorf("%s is out of range: %d", name, proto)
+			}
+			flowKey.Protocol = uint8(proto)
 		}
 	}
 	return flowKey, nil
 }
 
-// addOriginalExporterInfo adds originalExporterIP and originalObservationDomainId to records in message set
-func addOriginalExporterInfo(message *entities.Message) error {
+// addOriginalExporterInfo adds originalExporterIP and originalObservationDomainId to records in message sets
+func addOriginalExporterInfo(message *entities.Message, reg *registry.Registry) error {
+	for _, set := range message.GetSets() {
+		// Options templates are not aggregated, so they are left untouched.
+		if set.GetSetType() == entities.OptionsTemplate {
+			continue
+		}
+		if err := addOriginalExporterInfoToSet(message, set, reg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addOriginalExporterInfoToSet(message *entities.Message, set entities.Set, reg *registry.Registry) error {
 	isIPv4 := false
 	exporterIP := net.ParseIP(message.GetExportAddress())
 	if exporterIP.To4() != nil {
 		isIPv4 = true
 	}
-	set := message.GetSet()
 	records := set.GetRecords()
 	for _, record := range records {
+		if record.GetScopeFieldCount() > 0 {
+			continue
+		}
 		var originalExporterIP, originalObservationDomainId *entities.InfoElementWithValue
 		var ie *entities.InfoElement
 		var err error
 		// Add originalExporterIP. Supports both IPv4 and IPv6.
 		if isIPv4 {
-			ie, err = registry.GetInfoElement("originalExporterIPv4Address", registry.IANAEnterpriseID)
+			ie, err = reg.LookupByName("originalExporterIPv4Address", registry.IANAEnterpriseID)
 		} else {
-			ie, err = registry.GetInfoElement("originalExporterIPv6Address", registry.IANAEnterpriseID)
+			ie, err = reg.LookupByName("originalExporterIPv6Address", registry.IANAEnterpriseID)
 		}
 		if err != nil {
 			return err
@@ -549,7 +634,7 @@ func addOriginalExporterInfo(message *entities.Message) error {
 		}
 
 		// Add originalObservationDomainId
-		ie, err = registry.GetInfoElement("originalObservationDomainId", registry.IANAEnterpriseID)
+		ie, err = reg.LookupByName("originalObservationDomainId", registry.IANAEnterpriseID)
 		if err != nil {
 			return fmt.Errorf("IANA Registry is not loaded correctly with originalObservationDomainId")
 		}
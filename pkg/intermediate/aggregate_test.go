@@ -305,6 +305,11 @@ func TestInitAggregationProcess(t *testing.T) {
 	aggregationProcess, err = InitAggregationProcess(input)
 	assert.Nil(t, err)
 	assert.Equal(t, 2, aggregationProcess.workerNum)
+	assert.Equal(t, registry.Default(), aggregationProcess.registry, "Default registry should be used if registry is not given.")
+	input.Registry = registry.New()
+	aggregationProcess, err = InitAggregationProcess(input)
+	assert.Nil(t, err)
+	assert.Equal(t, input.Registry, aggregationProcess.registry)
 }
 
 func TestGetTupleRecordMap(t *testing.T) {
@@ -342,7 +347,7 @@ func TestAggregateMsgByFlowKey(t *testing.T) {
 	ieWithValue, exist := aggRecord.Record.GetInfoElementWithValue("sourceIPv4Address")
 	assert.Equal(t, true, exist)
 	assert.Equal(t, net.IP{0xa, 0x0, 0x0, 0x1}, ieWithValue.Value)
-	assert.Equal(t, message.GetSet().GetRecords()[0], aggRecord.Record)
+	assert.Equal(t, message.GetSets()[0].GetRecords()[0], aggRecord.Record)
 
 	// Template records with IPv6 fields should be ignored
 	message = createMsgwithTemplateSet(true)
@@ -361,7 +366,7 @@ func TestAggregateMsgByFlowKey(t *testing.T) {
 	ieWithValue, exist = aggRecord.Record.GetInfoElementWithValue("sourceIPv6Address")
 	assert.Equal(t, true, exist)
 	assert.Equal(t, net.IP{0x20, 0x1, 0x0, 0x0, 0x32, 0x38, 0xdf, 0xe1, 0x0, 0x63, 0x0, 0x0, 0x0, 0x0, 0xfe, 0xfb}, ieWithValue.Value)
-	assert.Equal(t, message.GetSet().GetRecords()[0], aggRecord.Record)
+	assert.Equal(t, message.GetSets()[0].GetRecords()[0], aggRecord.Record)
 }
 
 func TestAggregationProcess(t *testing.T) {
@@ -388,24 +393,24 @@ func TestAggregationProcess(t *testing.T) {
 		"10.0.0.1", "10.0.0.2", 6, 1234, 5678,
 	}
 	aggRecord := aggregationProcess.flowKeyRecordMap[flowKey]
-	assert.Equalf(t, aggRecord.Record, dataMsg.GetSet().GetRecords()[0], "records should be equal")
+	assert.Equalf(t, aggRecord.Record, dataMsg.GetSets()[0].GetRecords()[0], "records should be equal")
 }
 
 func TestAddOriginalExporterInfo(t *testing.T) {
 	// Test message with template set
 	message := createMsgwithTemplateSet(false)
-	err := addOriginalExporterInfo(message)
+	err := addOriginalExporterInfo(message, registry.Default())
 	assert.NoError(t, err)
-	record := message.GetSet().GetRecords()[0]
+	record := message.GetSets()[0].GetRecords()[0]
 	_, exist := record.GetInfoElementWithValue("originalExporterIPv4Address")
 	assert.Equal(t, true, exist)
 	_, exist = record.GetInfoElementWithValue("originalObservationDomainId")
 	assert.Equal(t, true, exist)
 	// Test message with data set
 	message = createDataMsgForSrc(t, false, false, false)
-	err = addOriginalExporterInfo(message)
+	err = addOriginalExporterInfo(message, registry.Default())
 	assert.NoError(t, err)
-	record = message.GetSet().GetRecords()[0]
+	record = message.GetSets()[0].GetRecords()[0]
 	ieWithValue, exist := record.GetInfoElementWithValue("originalExporterIPv4Address")
 	assert.Equal(t, true, exist)
 	assert.Equal(t, net.IP{0x7f, 0x0, 0x0, 0x1}, ieWithValue.Value)
@@ -424,29 +429,29 @@ func TestCorrelateRecordsForInterNodeFlow(t *testing.T) {
 	ap, _ := InitAggregationProcess(input)
 	// Test IPv4 fields.
 	// Test the scenario, where record1 is added first and then record2.
-	record1 := createDataMsgForSrc(t, false, false, false).GetSet().GetRecords()[0]
-	record2 := createDataMsgForDst(t, false, false, false).GetSet().GetRecords()[0]
+	record1 := createDataMsgForSrc(t, false, false, false).GetSets()[0].GetRecords()[0]
+	record2 := createDataMsgForDst(t, false, false, false).GetSets()[0].GetRecords()[0]
 	runCorrelationAndCheckResult(t, ap, record1, record2, false, false)
 	// Cleanup the flowKeyMap in aggregation process.
 	flowKey1, _ := getFlowKeyFromRecord(record1)
 	ap.DeleteFlowKeyFromMapWithLock(*flowKey1)
 	// Test the scenario, where record2 is added first and then record1.
-	record1 = createDataMsgForSrc(t, false, false, false).GetSet().GetRecords()[0]
-	record2 = createDataMsgForDst(t, false, false, false).GetSet().GetRecords()[0]
+	record1 = createDataMsgForSrc(t, false, false, false).GetSets()[0].GetRecords()[0]
+	record2 = createDataMsgForDst(t, false, false, false).GetSets()[0].GetRecords()[0]
 	runCorrelationAndCheckResult(t, ap, record2, record1, false, false)
 	// Cleanup the flowKeyMap in aggregation process.
 	ap.DeleteFlowKeyFromMapWithLock(*flowKey1)
 
 	// Test IPv6 fields.
 	// Test the scenario, where record1 is added first and then record2.
-	record1 = createDataMsgForSrc(t, true, false, false).GetSet().GetRecords()[0]
-	record2 = createDataMsgForDst(t, true, false, false).GetSet().GetRecords()[0]
+	record1 = createDataMsgForSrc(t, true, false, false).GetSets()[0].GetRecords()[0]
+	record2 = createDataMsgForDst(t, true, false, false).GetSets()[0].GetRecords()[0]
 	runCorrelationAndCheckResult(t, ap, record1, record2, true, false)
 	// Cleanup the flowKeyMap in aggregation process.
 	ap.DeleteFlowKeyFromMapWithLock(*flowKey1)
 	// Test the scenario, where record2 is added first and then record1.
-	record1 = createDataMsgForSrc(t, true, false, false).GetSet().GetRecords()[0]
-	record2 = createDataMsgForDst(t, true, false, false).GetSet().GetRecords()[0]
+	record1 = createDataMsgForSrc(t, true, false, false).GetSets()[0].GetRecords()[0]
+	record2 = createDataMsgForDst(t, true, false, false).GetSets()[0].GetRecords()[0]
 	runCorrelationAndCheckResult(t, ap, record2, record1, true, false)
 }
 
@@ -459,16 +464,45 @@ func TestCorrelateRecordsForIntraNodeFlow(t *testing.T) {
 	}
 	ap, _ := InitAggregationProcess(input)
 	// Test IPv4 fields.
-	record1 := createDataMsgForSrc(t, false, true, false).GetSet().GetRecords()[0]
+	record1 := createDataMsgForSrc(t, false, true, false).GetSets()[0].GetRecords()[0]
 	runCorrelationAndCheckResult(t, ap, record1, nil, false, true)
 	// Cleanup the flowKeyMap in aggregation process.
 	flowKey1, _ := getFlowKeyFromRecord(record1)
 	ap.DeleteFlowKeyFromMapWithLock(*flowKey1)
 	// Test IPv6 fields.
-	record1 = createDataMsgForSrc(t, true, true, false).GetSet().GetRecords()[0]
+	record1 = createDataMsgForSrc(t, true, true, false).GetSets()[0].GetRecords()[0]
 	runCorrelationAndCheckResult(t, ap, record1, nil, true, true)
 }
 
+func TestCorrelateRecordsWithMissingFields(t *testing.T) {
+	input := AggregationInput{
+		MessageChan:     make(chan *entities.Message),
+		WorkerNum:       2,
+		CorrelateFields: fields,
+	}
+	ap, _ := InitAggregationProcess(input)
+	podName := entities.NewInfoElement("destinationPodName", 103, entities.String, 55829, entities.VariableLength)
+	clusterIP := entities.NewInfoElement("destinationClusterIPv4", 106, entities.Ipv4Address, 55829, 4)
+	incomingRecord := entities.NewDataRecord(256)
+	for _, ie := range []*entities.InfoElementWithValue{
+		entities.NewInfoElementWithValue(podName, "pod2"),
+		entities.NewInfoElementWithValue(clusterIP, net.ParseIP("192.168.0.1")),
+	} {
+		_, err := incomingRecord.AddInfoElement(ie, false)
+		assert.NoError(t, err)
+	}
+	// Existing record does not have destinationClusterIPv4.
+	existingRecord := entities.NewDataRecord(256)
+	_, err := existingRecord.AddInfoElement(entities.NewInfoElementWithValue(podName, ""), false)
+	assert.NoError(t, err)
+	ap.correlateRecords(incomingRecord, existingRecord)
+	name, _, err := existingRecord.GetString("destinationPodName")
+	assert.NoError(t, err)
+	assert.Equal(t, "pod2", name)
+	_, exist := existingRecord.GetInfoElementWithValue("destinationClusterIPv4")
+	assert.False(t, exist)
+}
+
 func TestAggregateRecordsForInterNodeFlow(t *testing.T) {
 	messageChan := make(chan *entities.Message)
 	aggElements := &AggregationElements{
@@ -486,13 +520,52 @@ func TestAggregateRecordsForInterNodeFlow(t *testing.T) {
 	ap, _ := InitAggregationProcess(input)
 
 	// Test the scenario (added in order): srcRecord, dstRecord, record1_updated, record2_updated
-	srcRecord := createDataMsgForSrc(t, false, false, false).GetSet().GetRecords()[0]
-	dstRecord := createDataMsgForDst(t, false, false, false).GetSet().GetRecords()[0]
-	latestSrcRecord := createDataMsgForSrc(t, false, false, true).GetSet().GetRecords()[0]
-	latestDstRecord := createDataMsgForDst(t, false, false, true).GetSet().GetRecords()[0]
+	srcRecord := createDataMsgForSrc(t, false, false, false).GetSets()[0].GetRecords()[0]
+	dstRecord := createDataMsgForDst(t, false, false, false).GetSets()[0].GetRecords()[0]
+	latestSrcRecord := createDataMsgForSrc(t, false, false, true).GetSets()[0].GetRecords()[0]
+	latestDstRecord := createDataMsgForDst(t, false, false, true).GetSets()[0].GetRecords()[0]
 	runAggregationAndCheckResult(t, ap, srcRecord, dstRecord, latestSrcRecord, latestDstRecord, false)
 }
 
+func TestAggregateElementValue(t *testing.T) {
+	deltaCounter := entities.NewInfoElement("vendorBytes", 1, entities.Unsigned64, 12345, 8)
+	deltaCounter.Semantics = entities.DeltaCounter
+	totalCounter := entities.NewInfoElement("vendorTotalBytes", 2, entities.Unsigned32, 12345, 4)
+	totalCounter.Semantics = entities.TotalCounter
+	gauge := entities.NewInfoElement("vendorQueueLength", 3, entities.Unsigned16, 12345, 2)
+	gauge.Semantics = entities.SNMPGauge
+	// Semantics of IANA elements are given by the registry.
+	octetDeltaCount, err := registry.GetInfoElement("octetDeltaCount", registry.IANAEnterpriseID)
+	assert.NoError(t, err)
+	octetTotalCount, err := registry.GetInfoElement("octetTotalCount", registry.IANAEnterpriseID)
+	assert.NoError(t, err)
+	// Elements without semantics are replaced, regardless of their names.
+	vendorDeltaCount := entities.NewInfoElement("vendorPacketDeltaCount", 4, entities.Unsigned64, 12345, 8)
+
+	tests := []struct {
+		element       *entities.InfoElement
+		existingValue interface{}
+		incomingValue interface{}
+		expectedValue interface{}
+	}{
+		{deltaCounter, uint64(100), uint64(50), uint64(150)},
+		{totalCounter, uint32(100), uint32(150), uint32(150)},
+		{totalCounter, uint32(150), uint32(100), uint32(150)},
+		{gauge, uint16(100), uint16(50), uint16(50)},
+		{octetDeltaCount, uint64(100), uint64(50), uint64(150)},
+		{octetTotalCount, uint64(150), uint64(100), uint64(150)},
+		{octetTotalCount, uint64(100), uint64(150), uint64(150)},
+		{vendorDeltaCount, uint64(100), uint64(50), uint64(50)},
+	}
+	for _, test := range tests {
+		ieWithValue := entities.NewInfoElementWithValue(test.element, test.existingValue)
+		assert.Nil(t, aggregateElementValue(ieWithValue, test.incomingValue))
+		assert.Equal(t, test.expectedValue, ieWithValue.Value, "Value of %s is not aggregated correctly.", test.element.Name)
+	}
+	ieWithValue := entities.NewInfoElementWithValue(deltaCounter, "100")
+	assert.NotNil(t, aggregateElementValue(ieWithValue, uint64(50)), "Error should be returned for counter which is not unsigned integer.")
+}
+
 func TestDeleteFlowKeyFromMapWithLock(t *testing.T) {
 	messageChan := make(chan *entities.Message)
 	input := AggregationInput{
@@ -505,7 +578,7 @@ func TestDeleteFlowKeyFromMapWithLock(t *testing.T) {
 	flowKey1 := FlowKey{"10.0.0.1", "10.0.0.2", 6, 1234, 5678}
 	flowKey2 := FlowKey{"2001:0:3238:dfe1:63::fefb", "2001:0:3238:dfe1:63::fefc", 6, 1234, 5678}
 	aggFlowRecord := AggregationFlowRecord{
-		message.GetSet().GetRecords()[0],
+		message.GetSets()[0].GetRecords()[0],
 		true,
 		true,
 	}
@@ -529,7 +602,7 @@ func TestDeleteFlowKeyFromMapWithoutLock(t *testing.T) {
 	flowKey1 := FlowKey{"10.0.0.1", "10.0.0.2", 6, 1234, 5678}
 	flowKey2 := FlowKey{"2001:0:3238:dfe1:63::fefb", "2001:0:3238:dfe1:63::fefc", 6, 1234, 5678}
 	aggFlowRecord := AggregationFlowRecord{
-		message.GetSet().GetRecords()[0],
+		message.GetSets()[0].GetRecords()[0],
 		true,
 		true,
 	}
@@ -622,3 +695,28 @@ func runAggregationAndCheckResult(t *testing.T, ap *AggregationProcess, srcRecor
 		assert.Equalf(t, latestRecord.Value, ieWithValue.Value, "values should be equal for element %v", e)
 	}
 }
+
+func TestGetFlowKeyFromRecord(t *testing.T) {
+	newRecord := func(portType entities.IEDataType, portValue []byte) entities.Record {
+		record := entities.NewDataRecord(256)
+		for _, ie := range []*entities.InfoElementWithValue{
+			entities.NewInfoElementWithValue(entities.NewInfoElement("sourceTransportPort", 7, portType, 0, uint16(len(portValue))), bytes.NewBuffer(portValue)),
+			entities.NewInfoElementWithValue(entities.NewInfoElement("destinationTransportPort", 11, entities.Unsigned16, 0, 2), bytes.NewBuffer([]byte{0, 80})),
+			entities.NewInfoElementWithValue(entities.NewInfoElement("protocolIdentifier", 4, entities.Unsigned8, 0, 1), bytes.NewBuffer([]byte{6})),
+			entities.NewInfoElementWithValue(entities.NewInfoElement("sourceIPv4Address", 8, entities.Ipv4Address, 0, 4), bytes.NewBuffer([]byte{10, 0, 0, 1})),
+			entities.NewInfoElementWithValue(entities.NewInfoElement("destinationIPv4Address", 12, entities.Ipv4Address, 0, 4), bytes.NewBuffer([]byte{10, 0, 0, 2})),
+		} {
+			_, err := record.AddInfoElement(ie, true)
+			assert.NoError(t, err)
+		}
+		return record
+	}
+	// Ports of wider types are accepted if they are in range.
+	flowKey, err := getFlowKeyFromRecord(newRecord(entities.Unsigned32, []byte{0, 0, 0x1, 0xbb}))
+	assert.NoError(t, err)
+	assert.Equal(t, FlowKey{"10.0.0.1", "10.0.0.2", 6, 443, 80}, *flowKey)
+	_, err = getFlowKeyFromRecord(newRecord(entities.Unsigned32, []byte{0, 1, 0, 0}))
+	assert.Error(t, err)
+	_, err = getFlowKeyFromRecord(newRecord(entities.String, []byte("443")))
+	assert.Error(t, err)
+}
@@ -15,7 +15,8 @@
 package producer
 
 import (
-	"net"
+	"fmt"
+	"math"
 
 	"github.com/Shopify/sarama"
 	"github.com/golang/protobuf/proto"
@@ -37,87 +38,105 @@ type KafkaProducer struct {
 // convertIPFIXMsgToFlowMsgs converts data records in IPFIX message to flow messages
 // in given proto schema.
 func convertIPFIXMsgToFlowMsgs(msg *entities.Message) []*protobuf.FlowMessage {
-	set := msg.GetSet()
-	if set.GetSetType() == entities.Template {
-		return nil
+	flowMsgs := make([]*protobuf.FlowMessage, 0)
+	for _, set := range msg.GetSets() {
+		if set.GetSetType() == entities.Template || set.GetSetType() == entities.OptionsTemplate {
+			continue
+		}
+		flowMsgs = append(flowMsgs, convertDataSetToFlowMsgs(msg, set)...)
 	}
+	return flowMsgs
+}
 
+// convertDataSetToFlowMsgs converts the records in given data set to flow messages.
+func convertDataSetToFlowMsgs(msg *entities.Message, set entities.Set) []*protobuf.FlowMessage {
 	flowMsgs := make([]*protobuf.FlowMessage, 0)
 	for _, record := range set.GetRecords() {
+		// Options data records do not describe flows.
+		if record.GetScopeFieldCount() > 0 {
+			continue
+		}
 		flowMsg := &protobuf.FlowMessage{}
 		flowMsg.TimeReceived = msg.GetExportTime()
 		flowMsg.SequenceNumber = msg.GetSequenceNum()
 		flowMsg.ObsDomainID = msg.GetObsDomainID()
 		flowMsg.ExportAddress = msg.GetExportAddress()
 		for _, ie := range record.GetOrderedElementList() {
-			switch ie.Element.Name {
+			name := ie.Element.Name
+			var err error
+			switch name {
 			case "flowStartSeconds":
-				flowMsg.TimeFlowStartInSecs = ie.Value.(uint32)
+				flowMsg.TimeFlowStartInSecs, err = getUint32(record, name)
 			case "flowEndSeconds":
-				flowMsg.TimeFlowEndInSecs = ie.Value.(uint32)
+				flowMsg.TimeFlowEndInSecs, err = getUint32(record, name)
 			case "sourceIPv4Address", "sourceIPv6Address":
 				if flowMsg.SrcIP != "" {
 					klog.Warningf("Do not expect source IP: %v to be filled already", flowMsg.SrcIP)
 				}
-				flowMsg.SrcIP = ie.Value.(net.IP).String()
+				flowMsg.SrcIP, err = getIPString(record, name)
 			case "destinationIPv4Address", "destinationIPv6Address":
 				if flowMsg.DstIP != "" {
 					klog.Warningf("Do not expect destination IP: %v to be filled already", flowMsg.DstIP)
 				}
-				flowMsg.DstIP = ie.Value.(net.IP).String()
+				flowMsg.DstIP, err = getIPString(record, name)
 			case "sourceTransportPort":
-				flowMsg.SrcPort = uint32(ie.Value.(uint16))
+				flowMsg.SrcPort, err = getUint32(record, name)
 			case "destinationTransportPort":
-				flowMsg.DstPort = uint32(ie.Value.(uint16))
+				flowMsg.DstPort, err = getUint32(record, name)
 			case "protocolIdentifier":
-				flowMsg.Proto = uint32(ie.Value.(uint8))
+				flowMsg.Proto, err = getUint32(record, name)
 			case "packetTotalCount":
-				flowMsg.PacketsTotal = ie.Value.(uint64)
+				flowMsg.PacketsTotal, _, err = record.GetUint64(name)
 			case "octetTotalCount":
-				flowMsg.BytesTotal = ie.Value.(uint64)
+				flowMsg.BytesTotal, _, err = record.GetUint64(name)
 			case "packetDeltaCount":
-				flowMsg.PacketsDelta = ie.Value.(uint64)
+				flowMsg.PacketsDelta, _, err = record.GetUint64(name)
 			case "octetDeltaCount":
-				flowMsg.BytesDelta = ie.Value.(uint64)
+				flowMsg.BytesDelta, _, err = record.GetUint64(name)
 			case "reversePacketTotalCount":
-				flowMsg.ReversePacketsTotal = ie.Value.(uint64)
+				flowMsg.ReversePacketsTotal, _, err = record.GetUint64(name)
 			case "reverseOctetTotalCount":
-				flowMsg.ReverseBytesTotal = ie.Value.(uint64)
+				flowMsg.ReverseBytesTotal, _, err = record.GetUint64(name)
 			case "reversePacketDeltaCount":
-				flowMsg.ReversePacketsDelta = ie.Value.(uint64)
+				flowMsg.ReversePacketsDelta, _, err = record.GetUint64(name)
 			case "reverseOctetDeltaCount":
-				flowMsg.ReverseBytesDelta = ie.Value.(uint64)
+				flowMsg.ReverseBytesDelta, _, err = record.GetUint64(name)
 			case "sourcePodNamespace":
-				flowMsg.SrcPodNamespace = ie.Value.(string)
+				flowMsg.SrcPodNamespace, _, err = record.GetString(name)
 			case "sourcePodName":
-				flowMsg.SrcPodName = ie.Value.(string)
+				flowMsg.SrcPodName, _, err = record.GetString(name)
 			case "sourceNodeName":
-				flowMsg.SrcNodeName = ie.Value.(string)
+				flowMsg.SrcNodeName, _, err = record.GetString(name)
 			case "destinationPodNamespace":
-				flowMsg.DstPodNamespace = ie.Value.(string)
+				flowMsg.DstPodNamespace, _, err = record.GetString(name)
 			case "destinationPodName":
-				flowMsg.DstPodName = ie.Value.(string)
+				flowMsg.DstPodName, _, err = record.GetString(name)
 			case "destinationNodeName":
-				flowMsg.DstNodeName = ie.Value.(string)
+				flowMsg.DstNodeName, _, err = record.GetString(name)
 			case "destinationClusterIPv4", "destinationClusterIPv6":
 				if flowMsg.DstClusterIP != "" {
 					klog.Warningf("Do not expect destination cluster IP: %v to be filled already", flowMsg.DstClusterIP)
 				}
-				flowMsg.DstClusterIP = ie.Value.(net.IP).String()
+				flowMsg.DstClusterIP, err = getIPString(record, name)
 			case "destinationServicePort":
-				flowMsg.DstServicePort = uint32(ie.Value.(uint16))
+				flowMsg.DstServicePort, err = getUint32(record, name)
 			case "destinationServicePortName":
-				flowMsg.DstServicePortName = ie.Value.(string)
+				flowMsg.DstServicePortName, _, err = record.GetString(name)
 			case "ingressNetworkPolicyName":
-				flowMsg.IngressPolicyName = ie.Value.(string)
+				flowMsg.IngressPolicyName, _, err = record.GetString(name)
 			case "ingressNetworkPolicyNamespace":
-				flowMsg.IngressPolicyNamespace = ie.Value.(string)
+				flowMsg.IngressPolicyNamespace, _, err = record.GetString(name)
 			case "egressNetworkPolicyName":
-				flowMsg.EgressPolicyName = ie.Value.(string)
+				flowMsg.EgressPolicyName, _, err = record.GetString(name)
 			case "egressNetworkPolicyNamespace":
-				flowMsg.EgressPolicyNamespace = ie.Value.(string)
+				flowMsg.EgressPolicyNamespace, _, err = record.GetString(name)
 			default:
-				klog.Warningf("There is no field with name: %v in flow message (.proto schema)", ie.Element.Name)
+				klog.Warningf("There is no field with name: %v in flow message (.proto schema)", name)
+			}
+			// The field is left unset if the value of the element has an
+			// unexpected type, e.g. when the exporter uses another registry.
+			if err != nil {
+				klog.Warningf("Error when converting element to flow message field: %v", err)
 			}
 		}
 		flowMsgs = append(flowMsgs, flowMsg)
@@ -125,6 +144,27 @@ func convertIPFIXMsgToFlowMsgs(msg *entities.Message) []*protobuf.FlowMessage {
 	return flowMsgs
 }
 
+// getUint32 returns the value of the integer element as uint32.
+func getUint32(record entities.Record, name string) (uint32, error) {
+	value, _, err := record.GetUint64(name)
+	if err != nil {
+		return 0, err
+	}
+	if value > math.MaxUint32 {
+		return 0, fmt.Errorf("value %d of element %s overflows uint32", value, name)
+	}
+	return uint32(value), nil
+}
+
+// getIPString returns the value of the IP address element as string.
+func getIPString(record entities.Record, name string) (string, error) {
+	value, _, err := record.GetIP(name)
+	if err != nil {
+		return "", err
+	}
+	return value.String(), nil
+}
+
 func NewKafkaProducer(asyncProducer sarama.AsyncProducer, topic string) *KafkaProducer {
 	return &KafkaProducer{
 		producer: asyncProducer,
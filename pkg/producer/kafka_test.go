@@ -245,3 +245,28 @@ func TestKafkaProducer_Publish(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestConvertIPFIXMsgToFlowMsgs(t *testing.T) {
+	// Elements are decoded with the types of the exporter, which may differ
+	// from the types of the registry.
+	set := entities.NewSet(entities.Data, 256, true)
+	elements := []*entities.InfoElementWithValue{
+		entities.NewInfoElementWithValue(entities.NewInfoElement("sourceTransportPort", 7, entities.Unsigned32, 0, 4), bytes.NewBuffer([]byte{0, 0, 0x1, 0xbb})),
+		entities.NewInfoElementWithValue(entities.NewInfoElement("protocolIdentifier", 4, entities.String, 0, 3), bytes.NewBuffer([]byte("tcp"))),
+		entities.NewInfoElementWithValue(entities.NewInfoElement("packetDeltaCount", 2, entities.Unsigned32, 0, 4), bytes.NewBuffer([]byte{0, 0, 0, 10})),
+		entities.NewInfoElementWithValue(entities.NewInfoElement("sourcePodName", 101, entities.OctetArray, 56506, 4), bytes.NewBuffer([]byte("pod1"))),
+	}
+	if err := set.AddRecord(elements, 256); err != nil {
+		t.Fatalf("Error when adding elements to the record: %v", err)
+	}
+	msg := entities.NewMessage(true)
+	msg.AddSet(set)
+
+	flowMsgs := convertIPFIXMsgToFlowMsgs(msg)
+	assert.Equal(t, 1, len(flowMsgs))
+	assert.Equal(t, uint32(443), flowMsgs[0].SrcPort)
+	assert.Equal(t, uint64(10), flowMsgs[0].PacketsDelta)
+	// Fields of elements which cannot be converted are not set.
+	assert.Equal(t, uint32(0), flowMsgs[0].Proto)
+	assert.Equal(t, "", flowMsgs[0].SrcPodName)
+}
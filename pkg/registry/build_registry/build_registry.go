@@ -19,7 +19,10 @@ package main
 import (
 	"bufio"
 	"encoding/csv"
+	"encoding/xml"
+	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -34,71 +37,79 @@ import (
 	"github.com/vmware/go-ipfix/pkg/registry"
 )
 
+const ianaRegistryURL = "https://www.iana.org/assignments/ipfix/ipfix-information-elements.csv"
+
+var (
+	ianaFile   = flag.String("iana-file", "", "Path of a local copy of the IANA registry in CSV or XML format. The registry is downloaded from "+ianaRegistryURL+" if it is not given.")
+	antreaFile = flag.String("antrea-file", "", "Path of the Antrea registry CSV file. registry_antrea.csv is used if it is not given.")
+)
+
+// ieDefinition is an information element in the registry files.
+type ieDefinition struct {
+	name         string
+	elementID    string
+	dataType     string
+	semantics    string
+	units        string
+	ieRange      string
+	enterpriseID string
+}
+
+// xmlRecord is a record of the ipfix-information-elements registry in the IANA
+// XML format. Other fields of the record are ignored.
+type xmlRecord struct {
+	Name      string `xml:"name"`
+	DataType  string `xml:"dataType"`
+	Semantics string `xml:"dataTypeSemantics"`
+	ElementID string `xml:"elementId"`
+	Units     string `xml:"units"`
+	Range     string `xml:"range"`
+}
+
 func initIANARegistry() {
-	registryURL := "https://www.iana.org/assignments/ipfix/ipfix-information-elements.csv"
-	data, error := readCSVFromURL(registryURL)
+	var data []ieDefinition
+	var error error
+	if *ianaFile == "" {
+		data, error = readIANARegistryFromURL(ianaRegistryURL)
+	} else if strings.ToLower(filepath.Ext(*ianaFile)) == ".xml" {
+		data, error = readIANARegistryFromXMLFile(*ianaFile)
+	} else {
+		data, error = readRegistryFromCSVFile(*ianaFile)
+	}
 	if error != nil {
 		klog.Errorf("main: %v", error)
+		return
 	}
 	// get root of current package
 	_, base, _, _ := runtime.Caller(0)
 	basePath := filepath.Dir(base)
-	registryFileName := basePath + "/../registry_IANA.go"
-	var output *os.File
-	if output, error = os.Create(registryFileName); error != nil {
-		klog.Errorf("main: Cannot open output file %s", registryFileName)
-	}
-	headerPath := basePath + "/../../../license_templates/license_header.go.txt"
-	licenseHeader, err := ioutil.ReadFile(headerPath)
-	if err != nil {
-		klog.Error("Error in reading license header file")
-	}
-	writer := bufio.NewWriter(output)
-	fmt.Fprintf(writer, string(licenseHeader)+"\n\n")
-	fmt.Fprintf(writer,
-		`package registry
-
-import (
-	"github.com/vmware/go-ipfix/pkg/entities"
-)
-
-// AUTO GENERATED, DO NOT CHANGE
-
-func loadIANARegistry() {
-`)
-
-	for idx, row := range data {
-		// skip header and reserved line
-		if idx == 0 || idx == 1 {
-			continue
-		}
-
-		writer.WriteString("	registerInfoElement(*entities.NewInfoElement(")
-		parameters := generateIEString(row[1], row[0], row[2], "0")
-		fmt.Fprintf(writer, parameters)
-		writer.WriteString("), ")
-		fmt.Fprintf(writer, fmt.Sprint(registry.IANAEnterpriseID))
-		writer.WriteString(")\n")
-	}
-	writer.WriteString("}\n")
-	writer.Flush()
-	output.Close()
+	writeRegistry(basePath, basePath+"/../registry_IANA.go", "loadIANARegistry", data, registry.IANAEnterpriseID)
 }
 
 func initAntreaRegistry() {
 	// get root of current package
 	_, base, _, _ := runtime.Caller(0)
 	basePath := filepath.Dir(base)
-	fileName := basePath + "/../registry_antrea.csv"
-	data, error := readCSVFromFile(fileName)
+	fileName := *antreaFile
+	if fileName == "" {
+		fileName = basePath + "/../registry_antrea.csv"
+	}
+	data, error := readRegistryFromCSVFile(fileName)
 	if error != nil {
 		klog.Error(error)
+		return
 	}
-	registryFileName := basePath + "/../registry_antrea.go"
+	writeRegistry(basePath, basePath+"/../registry_antrea.go", "loadAntreaRegistry", data, registry.AntreaEnterpriseID)
+}
+
+func writeRegistry(basePath string, registryFileName string, funcName string, data []ieDefinition, enterpriseID uint32) {
 	var output *os.File
+	var error error
 	if output, error = os.Create(registryFileName); error != nil {
 		klog.Errorf("main: Cannot open output file %s", registryFileName)
+		return
 	}
+	defer output.Close()
 	headerPath := basePath + "/../../../license_templates/license_header.go.txt"
 	licenseHeader, err := ioutil.ReadFile(headerPath)
 	if err != nil {
@@ -115,69 +126,173 @@ import (
 
 // AUTO GENERATED, DO NOT CHANGE
 
-func loadAntreaRegistry() {
-`)
+func (r *Registry) %s() {
+`, funcName)
 
-	for idx, row := range data {
-		// skip header
-		if idx == 0 {
+	for _, ie := range data {
+		if ie.enterpriseID == "" {
+			ie.enterpriseID = "0"
+		}
+		parameters, ok := generateIEString(ie)
+		if !ok {
+			klog.V(2).Infof("Skipping element %q with elementID %q and data type %q", ie.name, ie.elementID, ie.dataType)
 			continue
 		}
-
-		writer.WriteString("	registerInfoElement(*entities.NewInfoElement(")
-		parameters := generateIEString(row[1], row[0], row[2], row[12])
-		fmt.Fprintf(writer, parameters)
-		writer.WriteString("), ")
-		fmt.Fprintf(writer, fmt.Sprint(registry.AntreaEnterpriseID))
-		writer.WriteString(")\n")
+		fmt.Fprintf(writer, "	r.registerInfoElement(%s, %d)\n", parameters, enterpriseID)
 	}
 	writer.WriteString("}\n")
 	writer.Flush()
-	output.Close()
 }
 
-func readCSVFromURL(url string) ([][]string, error) {
+func readIANARegistryFromURL(url string) ([]ieDefinition, error) {
 	response, err := http.Get(url)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
-	reader := csv.NewReader(response.Body)
-	data, err := reader.ReadAll()
+	return readRegistryFromCSV(response.Body)
+}
+
+func readRegistryFromCSVFile(name string) ([]ieDefinition, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readRegistryFromCSV(file)
+}
+
+// readRegistryFromCSV reads the registry in the format of the IANA registry
+// CSV file. Columns are found by the names in the header.
+func readRegistryFromCSV(reader io.Reader) ([]ieDefinition, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	rows, err := csvReader.ReadAll()
 	if err != nil {
 		return nil, err
 	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("registry CSV does not have a header")
+	}
+	columns := make(map[string]int)
+	for i, column := range rows[0] {
+		columns[strings.TrimSpace(column)] = i
+	}
+	data := make([]ieDefinition, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		getColumn := func(column string) string {
+			index, exist := columns[column]
+			if !exist || index >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[index])
+		}
+		data = append(data, ieDefinition{
+			name:         getColumn("Name"),
+			elementID:    getColumn("ElementID"),
+			dataType:     getColumn("Abstract Data Type"),
+			semantics:    getColumn("Data Type Semantics"),
+			units:        getColumn("Units"),
+			ieRange:      getColumn("Range"),
+			enterpriseID: getColumn("Enterprise ID"),
+		})
+	}
 	return data, nil
 }
 
-func readCSVFromFile(name string) ([][]string, error) {
+// readIANARegistryFromXMLFile reads the records of the ipfix-information-elements
+// registry from the IANA XML file.
+func readIANARegistryFromXMLFile(name string) ([]ieDefinition, error) {
 	file, err := os.Open(name)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
-	data, err := csv.NewReader(file).ReadAll()
-	if err != nil {
-		return nil, err
+	decoder := xml.NewDecoder(file)
+	registryIDs := make([]string, 0)
+	data := make([]ieDefinition, 0)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "registry":
+				var id string
+				for _, attr := range element.Attr {
+					if attr.Name.Local == "id" {
+						id = attr.Value
+					}
+				}
+				registryIDs = append(registryIDs, id)
+			case "record":
+				if len(registryIDs) == 0 || registryIDs[len(registryIDs)-1] != "ipfix-information-elements" {
+					continue
+				}
+				var record xmlRecord
+				if err := decoder.DecodeElement(&record, &element); err != nil {
+					return nil, err
+				}
+				data = append(data, ieDefinition{
+					name:      strings.TrimSpace(record.Name),
+					elementID: strings.TrimSpace(record.ElementID),
+					dataType:  strings.TrimSpace(record.DataType),
+					semantics: strings.TrimSpace(record.Semantics),
+					units:     strings.TrimSpace(record.Units),
+					ieRange:   strings.TrimSpace(record.Range),
+				})
+			}
+		case xml.EndElement:
+			if element.Name.Local == "registry" && len(registryIDs) > 0 {
+				registryIDs = registryIDs[:len(registryIDs)-1]
+			}
+		}
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("registry ipfix-information-elements is not found in %s", name)
 	}
 	return data, nil
 }
 
-func generateIEString(name string, elementid string, datatype string, enterpriseid string) string {
-	elementID, _ := strconv.ParseUint(elementid, 10, 16)
-	enterpriseID, _ := strconv.ParseUint(enterpriseid, 10, 16)
-	dataType := entities.IENameToType(datatype)
+// generateIEString returns the Go expression of the element. Reserved and
+// unassigned rows, which do not have a single element ID or a valid data type,
+// are not valid.
+func generateIEString(ie ieDefinition) (string, bool) {
+	elementID, err := strconv.ParseUint(ie.elementID, 10, 16)
+	if err != nil || ie.name == "" {
+		return "", false
+	}
+	enterpriseID, err := strconv.ParseUint(ie.enterpriseID, 10, 32)
+	if err != nil {
+		return "", false
+	}
+	dataType := entities.IENameToType(ie.dataType)
+	if !entities.IsValidDataType(dataType) {
+		return "", false
+	}
 	length := entities.InfoElementLength[dataType]
-	return fmt.Sprintf("\"%s\", %d, %v, %d, %d", name, uint16(elementID), dataType, uint16(enterpriseID), length)
+	semantics := entities.IENameToSemantics(ie.semantics)
+	units := entities.IENameToUnits(ie.units)
+	rangeBegin, rangeEnd, hasRange := entities.ParseIERange(ie.ieRange)
+	if semantics == entities.DefaultSemantics && units == entities.UnitsNone && !hasRange {
+		return fmt.Sprintf("*entities.NewInfoElement(\"%s\", %d, %v, %d, %d)", ie.name, uint16(elementID), dataType, uint32(enterpriseID), length), true
+	}
+	return fmt.Sprintf("entities.InfoElement{Name: \"%s\", ElementId: %d, DataType: %v, EnterpriseId: %d, Len: %d, Semantics: %d, Units: %d, RangeBegin: %d, RangeEnd: %d}",
+		ie.name, uint16(elementID), dataType, uint32(enterpriseID), length, semantics, units, rangeBegin, rangeEnd), true
 }
 
 func main() {
-	switch len(os.Args) {
-	case 1:
+	flag.Parse()
+	switch flag.NArg() {
+	case 0:
 		initIANARegistry()
 		initAntreaRegistry()
-	case 2:
-		switch strings.ToLower(os.Args[1]) {
+	case 1:
+		switch strings.ToLower(flag.Arg(0)) {
 		case "antrea":
 			initAntreaRegistry()
 		case "iana":
@@ -17,6 +17,7 @@ package registry
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/vmware/go-ipfix/pkg/entities"
 )
@@ -30,73 +31,225 @@ const (
 	IANAReversedEnterpriseID uint32 = 29305
 )
 
-var (
-	// globalRegistryByID shows mapping EnterpriseID -> Info Element ID -> Info Element
-	globalRegistryByID map[uint32]map[uint16]*entities.InfoElement
-	// globalRegistryByName shows mapping EnterpriseID -> Info Element name -> Info Element
-	globalRegistryByName map[uint32]map[string]*entities.InfoElement
-)
+// Registry stores Information Elements by enterprise ID. Registries are safe
+// for concurrent use, so that elements can be registered while they are looked
+// up by collecting, exporting and aggregation processes.
+type Registry struct {
+	// registryByID shows mapping EnterpriseID -> Info Element ID -> Info Element
+	registryByID map[uint32]map[uint16]*entities.InfoElement
+	// registryByName shows mapping EnterpriseID -> Info Element name -> Info Element
+	registryByName map[uint32]map[string]*entities.InfoElement
+	// mutex allows multiple readers or one writer at the same time
+	mutex sync.RWMutex
+}
 
-func LoadRegistry() {
-	globalRegistryByID = make(map[uint32]map[uint16]*entities.InfoElement)
-	globalRegistryByID[AntreaEnterpriseID] = make(map[uint16]*entities.InfoElement)
-	globalRegistryByID[IANAEnterpriseID] = make(map[uint16]*entities.InfoElement)
-	globalRegistryByID[IANAReversedEnterpriseID] = make(map[uint16]*entities.InfoElement)
+// defaultRegistry is the registry used by the package level functions.
+var defaultRegistry = New()
 
-	globalRegistryByName = make(map[uint32]map[string]*entities.InfoElement)
-	globalRegistryByName[AntreaEnterpriseID] = make(map[string]*entities.InfoElement)
-	globalRegistryByName[IANAEnterpriseID] = make(map[string]*entities.InfoElement)
-	globalRegistryByName[IANAReversedEnterpriseID] = make(map[string]*entities.InfoElement)
+// New returns an empty registry. Call LoadRegistry on the registry to load
+// IANA and Antrea Information Elements.
+func New() *Registry {
+	return &Registry{
+		registryByID:   make(map[uint32]map[uint16]*entities.InfoElement),
+		registryByName: make(map[uint32]map[string]*entities.InfoElement),
+	}
+}
 
-	loadIANARegistry()
-	loadAntreaRegistry()
+// Default returns the registry used by the package level functions.
+func Default() *Registry {
+	return defaultRegistry
 }
 
-func GetInfoElementFromID(elementID uint16, enterpriseID uint32) (*entities.InfoElement, error) {
-	if _, exist := globalRegistryByID[enterpriseID]; !exist {
+// LoadRegistry replaces the elements of the registry with IANA, IANA reverse
+// and Antrea Information Elements.
+func (r *Registry) LoadRegistry() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.registryByID = make(map[uint32]map[uint16]*entities.InfoElement)
+	r.registryByID[AntreaEnterpriseID] = make(map[uint16]*entities.InfoElement)
+	r.registryByID[IANAEnterpriseID] = make(map[uint16]*entities.InfoElement)
+	r.registryByID[IANAReversedEnterpriseID] = make(map[uint16]*entities.InfoElement)
+
+	r.registryByName = make(map[uint32]map[string]*entities.InfoElement)
+	r.registryByName[AntreaEnterpriseID] = make(map[string]*entities.InfoElement)
+	r.registryByName[IANAEnterpriseID] = make(map[string]*entities.InfoElement)
+	r.registryByName[IANAReversedEnterpriseID] = make(map[string]*entities.InfoElement)
+
+	r.loadIANARegistry()
+	r.loadAntreaRegistry()
+}
+
+// Register adds the element to the registry of its enterprise ID. Registering
+// an IANA element also registers its reverse element (RFC5103).
+func (r *Registry) Register(ie *entities.InfoElement) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.register(ie)
+}
+
+// registerAll registers the given elements while holding the lock once. The
+// elements are checked against the registry and against each other first, so
+// that no element is registered if any of them cannot be registered.
+func (r *Registry) registerAll(ies []*entities.InfoElement) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	ids := make(map[elementIDKey]bool)
+	names := make(map[elementNameKey]bool)
+	for _, ie := range ies {
+		if err := r.checkInfoElement(ie); err != nil {
+			return err
+		}
+		for _, element := range withReverseInfoElement(ie) {
+			idKey := elementIDKey{element.EnterpriseId, element.ElementId}
+			nameKey := elementNameKey{element.EnterpriseId, element.Name}
+			if ids[idKey] {
+				return fmt.Errorf("Information element with elementID %d in registry with EnterpriseID %d is defined more than once", element.ElementId, element.EnterpriseId)
+			}
+			if names[nameKey] {
+				return fmt.Errorf("Information element %s in registry with EnterpriseID %d is defined more than once", element.Name, element.EnterpriseId)
+			}
+			ids[idKey] = true
+			names[nameKey] = true
+		}
+	}
+	for _, ie := range ies {
+		if err := r.register(ie); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type elementIDKey struct {
+	enterpriseID uint32
+	elementID    uint16
+}
+
+type elementNameKey struct {
+	enterpriseID uint32
+	name         string
+}
+
+// register registers the element. The caller must hold the lock.
+func (r *Registry) register(ie *entities.InfoElement) error {
+	if _, exist := r.registryByName[ie.EnterpriseId]; !exist {
+		r.registryByID[ie.EnterpriseId] = make(map[uint16]*entities.InfoElement)
+		r.registryByName[ie.EnterpriseId] = make(map[string]*entities.InfoElement)
+	}
+	if ie.EnterpriseId == IANAEnterpriseID {
+		if _, exist := r.registryByName[IANAReversedEnterpriseID]; !exist {
+			r.registryByID[IANAReversedEnterpriseID] = make(map[uint16]*entities.InfoElement)
+			r.registryByName[IANAReversedEnterpriseID] = make(map[string]*entities.InfoElement)
+		}
+	}
+	return r.registerInfoElement(*ie, ie.EnterpriseId)
+}
+
+// Lookup returns the element with the given element ID and enterprise ID.
+func (r *Registry) Lookup(elementID uint16, enterpriseID uint32) (*entities.InfoElement, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if _, exist := r.registryByID[enterpriseID]; !exist {
 		return nil, fmt.Errorf("Registry with EnterpriseID %d is not supported.", enterpriseID)
 	}
-	if element, exist := globalRegistryByID[enterpriseID][elementID]; !exist {
+	if element, exist := r.registryByID[enterpriseID][elementID]; !exist {
 		return element, fmt.Errorf("Information Element with elementID %d in registry with enterpriseID %d cannot be found.", elementID, enterpriseID)
 	} else {
 		return element, nil
 	}
 }
 
-func GetInfoElement(name string, enterpriseID uint32) (*entities.InfoElement, error) {
-	if _, exist := globalRegistryByName[enterpriseID]; !exist {
+// LookupByName returns the element with the given name and enterprise ID.
+func (r *Registry) LookupByName(name string, enterpriseID uint32) (*entities.InfoElement, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if _, exist := r.registryByName[enterpriseID]; !exist {
 		return nil, fmt.Errorf("Registry with EnterpriseID %d is not supported.", enterpriseID)
 	}
-	if element, exist := globalRegistryByName[enterpriseID][name]; !exist {
+	if element, exist := r.registryByName[enterpriseID][name]; !exist {
 		return element, fmt.Errorf("Information Element with name %s in registry with enterpriseID %d cannot be found.", name, enterpriseID)
 	} else {
 		return element, nil
 	}
 }
 
-func registerInfoElement(ie entities.InfoElement, enterpriseID uint32) error {
-	if _, exist := globalRegistryByName[enterpriseID]; !exist {
+// LoadRegistry loads IANA and Antrea Information Elements into the default
+// registry.
+func LoadRegistry() {
+	defaultRegistry.LoadRegistry()
+}
+
+// GetInfoElementFromID looks up the element in the default registry.
+func GetInfoElementFromID(elementID uint16, enterpriseID uint32) (*entities.InfoElement, error) {
+	return defaultRegistry.Lookup(elementID, enterpriseID)
+}
+
+// GetInfoElement looks up the element by name in the default registry.
+func GetInfoElement(name string, enterpriseID uint32) (*entities.InfoElement, error) {
+	return defaultRegistry.LookupByName(name, enterpriseID)
+}
+
+// registerInfoElement registers the element without locking the registry, so
+// the caller must hold the lock.
+func (r *Registry) registerInfoElement(ie entities.InfoElement, enterpriseID uint32) error {
+	if _, exist := r.registryByName[enterpriseID]; !exist {
 		return fmt.Errorf("Registry with EnterpriseID %d is not supported.", ie.EnterpriseId)
-	} else if _, exist = globalRegistryByName[enterpriseID][ie.Name]; exist {
-		return fmt.Errorf("Information element %s in registry with EnterpriseID %d has already been registered", ie.Name, ie.EnterpriseId)
 	}
-	globalRegistryByID[ie.EnterpriseId][ie.ElementId] = &ie
-	globalRegistryByName[ie.EnterpriseId][ie.Name] = &ie
+	if err := r.checkInfoElement(&ie); err != nil {
+		return err
+	}
+	if ie.EnterpriseId == IANAEnterpriseID {
+		ie.Reversible = isReversible(ie.Name)
+	}
+	r.registryByID[ie.EnterpriseId][ie.ElementId] = &ie
+	r.registryByName[ie.EnterpriseId][ie.Name] = &ie
 
 	if ie.EnterpriseId == IANAEnterpriseID { // handle reverse information element for IANA registry
-		reverseIE, err := getIANAReverseInfoElement(ie.Name)
+		reverseIE, err := r.getIANAReverseInfoElement(ie.Name)
 		if err == nil { // the information element has reverse information element
-			globalRegistryByID[IANAReversedEnterpriseID][reverseIE.ElementId] = reverseIE
-			globalRegistryByName[IANAReversedEnterpriseID][reverseIE.Name] = reverseIE
+			r.registryByID[IANAReversedEnterpriseID][reverseIE.ElementId] = reverseIE
+			r.registryByName[IANAReversedEnterpriseID][reverseIE.Name] = reverseIE
 		}
 	}
 	return nil
 }
 
-func getIANAReverseInfoElement(name string) (*entities.InfoElement, error) {
+// checkInfoElement returns an error if the ID or the name of the element, or of
+// its reverse element for IANA elements, has already been registered. The
+// caller must hold the lock.
+func (r *Registry) checkInfoElement(ie *entities.InfoElement) error {
+	for _, element := range withReverseInfoElement(ie) {
+		if _, exist := r.registryByID[element.EnterpriseId][element.ElementId]; exist {
+			return fmt.Errorf("Information element with elementID %d in registry with EnterpriseID %d has already been registered", element.ElementId, element.EnterpriseId)
+		}
+		if _, exist := r.registryByName[element.EnterpriseId][element.Name]; exist {
+			return fmt.Errorf("Information element %s in registry with EnterpriseID %d has already been registered", element.Name, element.EnterpriseId)
+		}
+	}
+	return nil
+}
+
+// withReverseInfoElement returns the element, followed by its reverse element
+// if it is a reversible IANA element (RFC5103).
+func withReverseInfoElement(ie *entities.InfoElement) []*entities.InfoElement {
+	if ie.EnterpriseId != IANAEnterpriseID || !isReversible(ie.Name) {
+		return []*entities.InfoElement{ie}
+	}
+	return []*entities.InfoElement{ie, newIANAReverseInfoElement(ie)}
+}
+
+func newIANAReverseInfoElement(ie *entities.InfoElement) *entities.InfoElement {
+	reverseIE := *ie
+	reverseIE.Name = "reverse" + strings.Title(ie.Name)
+	reverseIE.EnterpriseId = IANAReversedEnterpriseID
+	reverseIE.Reversible = false
+	return &reverseIE
+}
+
+func (r *Registry) getIANAReverseInfoElement(name string) (*entities.InfoElement, error) {
 	var exist bool
 	var ie *entities.InfoElement
-	if ie, exist = globalRegistryByName[IANAEnterpriseID][name]; !exist {
+	if ie, exist = r.registryByName[IANAEnterpriseID][name]; !exist {
 		err := fmt.Errorf("IANA Registry: There is no information element with name %s", name)
 		return ie, err
 	}
@@ -104,8 +257,7 @@ func getIANAReverseInfoElement(name string) (*entities.InfoElement, error) {
 		err := fmt.Errorf("IANA Registry: The information element %s is not reverse element", name)
 		return ie, err
 	}
-	reverseName := "reverse" + strings.Title(ie.Name)
-	return entities.NewInfoElement(reverseName, ie.ElementId, ie.DataType, IANAReversedEnterpriseID, ie.Len), nil
+	return newIANAReverseInfoElement(ie), nil
 }
 
 // Non-reversible Information Elements follow Section 6.1 of RFC5103
@@ -20,471 +20,465 @@ import (
 
 // AUTO GENERATED, DO NOT CHANGE
 
-func loadIANARegistry() {
-	registerInfoElement(*entities.NewInfoElement("octetDeltaCount", 1, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("packetDeltaCount", 2, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("deltaFlowCount", 3, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("protocolIdentifier", 4, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("ipClassOfService", 5, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpControlBits", 6, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("sourceTransportPort", 7, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("sourceIPv4Address", 8, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("sourceIPv4PrefixLength", 9, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("ingressInterface", 10, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("destinationTransportPort", 11, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("destinationIPv4Address", 12, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("destinationIPv4PrefixLength", 13, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("egressInterface", 14, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("ipNextHopIPv4Address", 15, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpSourceAsNumber", 16, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpDestinationAsNumber", 17, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpNextHopIPv4Address", 18, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("postMCastPacketDeltaCount", 19, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("postMCastOctetDeltaCount", 20, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowEndSysUpTime", 21, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("flowStartSysUpTime", 22, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("postOctetDeltaCount", 23, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("postPacketDeltaCount", 24, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("minimumIpTotalLength", 25, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("maximumIpTotalLength", 26, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("sourceIPv6Address", 27, 19, 0, 16), 0)
-	registerInfoElement(*entities.NewInfoElement("destinationIPv6Address", 28, 19, 0, 16), 0)
-	registerInfoElement(*entities.NewInfoElement("sourceIPv6PrefixLength", 29, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("destinationIPv6PrefixLength", 30, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("flowLabelIPv6", 31, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("icmpTypeCodeIPv4", 32, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("igmpType", 33, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("samplingInterval", 34, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("samplingAlgorithm", 35, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("flowActiveTimeout", 36, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("flowIdleTimeout", 37, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("engineType", 38, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("engineId", 39, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("exportedOctetTotalCount", 40, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("exportedMessageTotalCount", 41, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("exportedFlowRecordTotalCount", 42, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("ipv4RouterSc", 43, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("sourceIPv4Prefix", 44, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("destinationIPv4Prefix", 45, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsTopLabelType", 46, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsTopLabelIPv4Address", 47, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("samplerId", 48, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("samplerMode", 49, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("samplerRandomInterval", 50, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("classId", 51, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("minimumTTL", 52, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("maximumTTL", 53, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("fragmentIdentification", 54, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("postIpClassOfService", 55, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("sourceMacAddress", 56, 12, 0, 6), 0)
-	registerInfoElement(*entities.NewInfoElement("postDestinationMacAddress", 57, 12, 0, 6), 0)
-	registerInfoElement(*entities.NewInfoElement("vlanId", 58, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("postVlanId", 59, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("ipVersion", 60, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("flowDirection", 61, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("ipNextHopIPv6Address", 62, 19, 0, 16), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpNextHopIPv6Address", 63, 19, 0, 16), 0)
-	registerInfoElement(*entities.NewInfoElement("ipv6ExtensionHeaders", 64, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("Assigned for NetFlow v9 compatibility", 0, 255, 0, 0), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsTopLabelStackSection", 70, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection2", 71, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection3", 72, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection4", 73, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection5", 74, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection6", 75, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection7", 76, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection8", 77, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection9", 78, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection10", 79, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("destinationMacAddress", 80, 12, 0, 6), 0)
-	registerInfoElement(*entities.NewInfoElement("postSourceMacAddress", 81, 12, 0, 6), 0)
-	registerInfoElement(*entities.NewInfoElement("interfaceName", 82, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("interfaceDescription", 83, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("samplerName", 84, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("octetTotalCount", 85, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("packetTotalCount", 86, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flagsAndSamplerId", 87, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("fragmentOffset", 88, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("forwardingStatus", 89, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsVpnRouteDistinguisher", 90, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsTopLabelPrefixLength", 91, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("srcTrafficIndex", 92, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("dstTrafficIndex", 93, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("applicationDescription", 94, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("applicationId", 95, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("applicationName", 96, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("Assigned for NetFlow v9 compatibility", 97, 255, 0, 0), 0)
-	registerInfoElement(*entities.NewInfoElement("postIpDiffServCodePoint", 98, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("multicastReplicationFactor", 99, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("className", 100, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("classificationEngineId", 101, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("layer2packetSectionOffset", 102, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("layer2packetSectionSize", 103, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("layer2packetSectionData", 104, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("Assigned for NetFlow v9 compatibility", 0, 255, 0, 0), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpNextAdjacentAsNumber", 128, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpPrevAdjacentAsNumber", 129, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("exporterIPv4Address", 130, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("exporterIPv6Address", 131, 19, 0, 16), 0)
-	registerInfoElement(*entities.NewInfoElement("droppedOctetDeltaCount", 132, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("droppedPacketDeltaCount", 133, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("droppedOctetTotalCount", 134, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("droppedPacketTotalCount", 135, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowEndReason", 136, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("commonPropertiesId", 137, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("observationPointId", 138, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("icmpTypeCodeIPv6", 139, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsTopLabelIPv6Address", 140, 19, 0, 16), 0)
-	registerInfoElement(*entities.NewInfoElement("lineCardId", 141, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("portId", 142, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("meteringProcessId", 143, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("exportingProcessId", 144, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("templateId", 145, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("wlanChannelId", 146, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("wlanSSID", 147, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("flowId", 148, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("observationDomainId", 149, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("flowStartSeconds", 150, 14, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("flowEndSeconds", 151, 14, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("flowStartMilliseconds", 152, 15, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowEndMilliseconds", 153, 15, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowStartMicroseconds", 154, 16, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowEndMicroseconds", 155, 16, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowStartNanoseconds", 156, 17, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowEndNanoseconds", 157, 17, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowStartDeltaMicroseconds", 158, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("flowEndDeltaMicroseconds", 159, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("systemInitTimeMilliseconds", 160, 15, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowDurationMilliseconds", 161, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("flowDurationMicroseconds", 162, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("observedFlowTotalCount", 163, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("ignoredPacketTotalCount", 164, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("ignoredOctetTotalCount", 165, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("notSentFlowTotalCount", 166, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("notSentPacketTotalCount", 167, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("notSentOctetTotalCount", 168, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("destinationIPv6Prefix", 169, 19, 0, 16), 0)
-	registerInfoElement(*entities.NewInfoElement("sourceIPv6Prefix", 170, 19, 0, 16), 0)
-	registerInfoElement(*entities.NewInfoElement("postOctetTotalCount", 171, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("postPacketTotalCount", 172, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowKeyIndicator", 173, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("postMCastPacketTotalCount", 174, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("postMCastOctetTotalCount", 175, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("icmpTypeIPv4", 176, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("icmpCodeIPv4", 177, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("icmpTypeIPv6", 178, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("icmpCodeIPv6", 179, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("udpSourcePort", 180, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("udpDestinationPort", 181, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpSourcePort", 182, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpDestinationPort", 183, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpSequenceNumber", 184, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpAcknowledgementNumber", 185, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpWindowSize", 186, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpUrgentPointer", 187, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpHeaderLength", 188, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("ipHeaderLength", 189, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("totalLengthIPv4", 190, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("payloadLengthIPv6", 191, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("ipTTL", 192, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("nextHeaderIPv6", 193, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsPayloadLength", 194, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("ipDiffServCodePoint", 195, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("ipPrecedence", 196, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("fragmentFlags", 197, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("octetDeltaSumOfSquares", 198, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("octetTotalSumOfSquares", 199, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsTopLabelTTL", 200, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsLabelStackLength", 201, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsLabelStackDepth", 202, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsTopLabelExp", 203, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("ipPayloadLength", 204, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("udpMessageLength", 205, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("isMulticast", 206, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("ipv4IHL", 207, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("ipv4Options", 208, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpOptions", 209, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("paddingOctets", 210, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("collectorIPv4Address", 211, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("collectorIPv6Address", 212, 19, 0, 16), 0)
-	registerInfoElement(*entities.NewInfoElement("exportInterface", 213, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("exportProtocolVersion", 214, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("exportTransportProtocol", 215, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("collectorTransportPort", 216, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("exporterTransportPort", 217, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpSynTotalCount", 218, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpFinTotalCount", 219, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpRstTotalCount", 220, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpPshTotalCount", 221, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpAckTotalCount", 222, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpUrgTotalCount", 223, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("ipTotalLength", 224, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("postNATSourceIPv4Address", 225, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("postNATDestinationIPv4Address", 226, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("postNAPTSourceTransportPort", 227, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("postNAPTDestinationTransportPort", 228, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("natOriginatingAddressRealm", 229, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("natEvent", 230, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("initiatorOctets", 231, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("responderOctets", 232, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("firewallEvent", 233, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("ingressVRFID", 234, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("egressVRFID", 235, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("VRFname", 236, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("postMplsTopLabelExp", 237, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("tcpWindowScale", 238, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("biflowDirection", 239, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("ethernetHeaderLength", 240, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("ethernetPayloadLength", 241, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("ethernetTotalLength", 242, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("dot1qVlanId", 243, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("dot1qPriority", 244, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("dot1qCustomerVlanId", 245, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("dot1qCustomerPriority", 246, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("metroEvcId", 247, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("metroEvcType", 248, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("pseudoWireId", 249, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("pseudoWireType", 250, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("pseudoWireControlWord", 251, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("ingressPhysicalInterface", 252, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("egressPhysicalInterface", 253, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("postDot1qVlanId", 254, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("postDot1qCustomerVlanId", 255, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("ethernetType", 256, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("postIpPrecedence", 257, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("collectionTimeMilliseconds", 258, 15, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("exportSctpStreamId", 259, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("maxExportSeconds", 260, 14, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("maxFlowEndSeconds", 261, 14, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("messageMD5Checksum", 262, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("messageScope", 263, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("minExportSeconds", 264, 14, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("minFlowStartSeconds", 265, 14, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("opaqueOctets", 266, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("sessionScope", 267, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("maxFlowEndMicroseconds", 268, 16, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("maxFlowEndMilliseconds", 269, 15, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("maxFlowEndNanoseconds", 270, 17, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("minFlowStartMicroseconds", 271, 16, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("minFlowStartMilliseconds", 272, 15, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("minFlowStartNanoseconds", 273, 17, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("collectorCertificate", 274, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("exporterCertificate", 275, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("dataRecordsReliability", 276, 11, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("observationPointType", 277, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("newConnectionDeltaCount", 278, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("connectionSumDurationSeconds", 279, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("connectionTransactionId", 280, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("postNATSourceIPv6Address", 281, 19, 0, 16), 0)
-	registerInfoElement(*entities.NewInfoElement("postNATDestinationIPv6Address", 282, 19, 0, 16), 0)
-	registerInfoElement(*entities.NewInfoElement("natPoolId", 283, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("natPoolName", 284, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("anonymizationFlags", 285, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("anonymizationTechnique", 286, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("informationElementIndex", 287, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("p2pTechnology", 288, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("tunnelTechnology", 289, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("encryptedTechnology", 290, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("basicList", 291, 20, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("subTemplateList", 292, 21, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("subTemplateMultiList", 293, 22, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpValidityState", 294, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("IPSecSPI", 295, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("greKey", 296, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("natType", 297, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("initiatorPackets", 298, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("responderPackets", 299, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("observationDomainName", 300, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("selectionSequenceId", 301, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("selectorId", 302, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("informationElementId", 303, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("selectorAlgorithm", 304, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("samplingPacketInterval", 305, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("samplingPacketSpace", 306, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("samplingTimeInterval", 307, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("samplingTimeSpace", 308, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("samplingSize", 309, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("samplingPopulation", 310, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("samplingProbability", 311, 10, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("dataLinkFrameSize", 312, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("ipHeaderPacketSection", 313, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("ipPayloadPacketSection", 314, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("dataLinkFrameSection", 315, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection", 316, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mplsPayloadPacketSection", 317, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("selectorIdTotalPktsObserved", 318, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("selectorIdTotalPktsSelected", 319, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("absoluteError", 320, 10, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("relativeError", 321, 10, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("observationTimeSeconds", 322, 14, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("observationTimeMilliseconds", 323, 15, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("observationTimeMicroseconds", 324, 16, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("observationTimeNanoseconds", 325, 17, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("digestHashValue", 326, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("hashIPPayloadOffset", 327, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("hashIPPayloadSize", 328, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("hashOutputRangeMin", 329, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("hashOutputRangeMax", 330, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("hashSelectedRangeMin", 331, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("hashSelectedRangeMax", 332, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("hashDigestOutput", 333, 11, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("hashInitialiserValue", 334, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("selectorName", 335, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("upperCILimit", 336, 10, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("lowerCILimit", 337, 10, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("confidenceLevel", 338, 10, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("informationElementDataType", 339, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("informationElementDescription", 340, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("informationElementName", 341, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("informationElementRangeBegin", 342, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("informationElementRangeEnd", 343, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("informationElementSemantics", 344, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("informationElementUnits", 345, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("privateEnterpriseNumber", 346, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("virtualStationInterfaceId", 347, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("virtualStationInterfaceName", 348, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("virtualStationUUID", 349, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("virtualStationName", 350, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("layer2SegmentId", 351, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("layer2OctetDeltaCount", 352, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("layer2OctetTotalCount", 353, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("ingressUnicastPacketTotalCount", 354, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("ingressMulticastPacketTotalCount", 355, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("ingressBroadcastPacketTotalCount", 356, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("egressUnicastPacketTotalCount", 357, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("egressBroadcastPacketTotalCount", 358, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("monitoringIntervalStartMilliSeconds", 359, 15, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("monitoringIntervalEndMilliSeconds", 360, 15, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("portRangeStart", 361, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("portRangeEnd", 362, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("portRangeStepSize", 363, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("portRangeNumPorts", 364, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("staMacAddress", 365, 12, 0, 6), 0)
-	registerInfoElement(*entities.NewInfoElement("staIPv4Address", 366, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("wtpMacAddress", 367, 12, 0, 6), 0)
-	registerInfoElement(*entities.NewInfoElement("ingressInterfaceType", 368, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("egressInterfaceType", 369, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("rtpSequenceNumber", 370, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("userName", 371, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("applicationCategoryName", 372, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("applicationSubCategoryName", 373, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("applicationGroupName", 374, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("originalFlowsPresent", 375, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("originalFlowsInitiated", 376, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("originalFlowsCompleted", 377, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("distinctCountOfSourceIPAddress", 378, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("distinctCountOfDestinationIPAddress", 379, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("distinctCountOfSourceIPv4Address", 380, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("distinctCountOfDestinationIPv4Address", 381, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("distinctCountOfSourceIPv6Address", 382, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("distinctCountOfDestinationIPv6Address", 383, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("valueDistributionMethod", 384, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("rfc3550JitterMilliseconds", 385, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("rfc3550JitterMicroseconds", 386, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("rfc3550JitterNanoseconds", 387, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("dot1qDEI", 388, 11, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("dot1qCustomerDEI", 389, 11, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("flowSelectorAlgorithm", 390, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("flowSelectedOctetDeltaCount", 391, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowSelectedPacketDeltaCount", 392, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowSelectedFlowDeltaCount", 393, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("selectorIDTotalFlowsObserved", 394, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("selectorIDTotalFlowsSelected", 395, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("samplingFlowInterval", 396, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("samplingFlowSpacing", 397, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowSamplingTimeInterval", 398, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("flowSamplingTimeSpacing", 399, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("hashFlowDomain", 400, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("transportOctetDeltaCount", 401, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("transportPacketDeltaCount", 402, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("originalExporterIPv4Address", 403, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("originalExporterIPv6Address", 404, 19, 0, 16), 0)
-	registerInfoElement(*entities.NewInfoElement("originalObservationDomainId", 405, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("intermediateProcessId", 406, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("ignoredDataRecordTotalCount", 407, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("dataLinkFrameType", 408, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("sectionOffset", 409, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("sectionExportedOctets", 410, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("dot1qServiceInstanceTag", 411, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("dot1qServiceInstanceId", 412, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("dot1qServiceInstancePriority", 413, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("dot1qCustomerSourceMacAddress", 414, 12, 0, 6), 0)
-	registerInfoElement(*entities.NewInfoElement("dot1qCustomerDestinationMacAddress", 415, 12, 0, 6), 0)
-	registerInfoElement(*entities.NewInfoElement("", 416, 255, 0, 0), 0)
-	registerInfoElement(*entities.NewInfoElement("postLayer2OctetDeltaCount", 417, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("postMCastLayer2OctetDeltaCount", 418, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("", 419, 255, 0, 0), 0)
-	registerInfoElement(*entities.NewInfoElement("postLayer2OctetTotalCount", 420, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("postMCastLayer2OctetTotalCount", 421, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("minimumLayer2TotalLength", 422, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("maximumLayer2TotalLength", 423, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("droppedLayer2OctetDeltaCount", 424, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("droppedLayer2OctetTotalCount", 425, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("ignoredLayer2OctetTotalCount", 426, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("notSentLayer2OctetTotalCount", 427, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("layer2OctetDeltaSumOfSquares", 428, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("layer2OctetTotalSumOfSquares", 429, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("layer2FrameDeltaCount", 430, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("layer2FrameTotalCount", 431, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("pseudoWireDestinationIPv4Address", 432, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("ignoredLayer2FrameTotalCount", 433, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectValueInteger", 434, 7, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectValueOctetString", 435, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectValueOID", 436, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectValueBits", 437, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectValueIPAddress", 438, 18, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectValueCounter", 439, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectValueGauge", 440, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectValueTimeTicks", 441, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectValueUnsigned", 442, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectValueTable", 443, 21, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectValueRow", 444, 21, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectIdentifier", 445, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mibSubIdentifier", 446, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("mibIndexIndicator", 447, 4, 0, 8), 0)
-	registerInfoElement(*entities.NewInfoElement("mibCaptureTimeSemantics", 448, 1, 0, 1), 0)
-	registerInfoElement(*entities.NewInfoElement("mibContextEngineID", 449, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mibContextName", 450, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectName", 451, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectDescription", 452, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mibObjectSyntax", 453, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mibModuleName", 454, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mobileIMSI", 455, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("mobileMSISDN", 456, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("httpStatusCode", 457, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("sourceTransportPortsLimit", 458, 2, 0, 2), 0)
-	registerInfoElement(*entities.NewInfoElement("httpRequestMethod", 459, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("httpRequestHost", 460, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("httpRequestTarget", 461, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("httpMessageVersion", 462, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("natInstanceID", 463, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("internalAddressRealm", 464, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("externalAddressRealm", 465, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("natQuotaExceededEvent", 466, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("natThresholdEvent", 467, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("httpUserAgent", 468, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("httpContentType", 469, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("httpReasonPhrase", 470, 13, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("maxSessionEntries", 471, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("maxBIBEntries", 472, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("maxEntriesPerUser", 473, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("maxSubscribers", 474, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("maxFragmentsPendingReassembly", 475, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("addressPoolHighThreshold", 476, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("addressPoolLowThreshold", 477, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("addressPortMappingHighThreshold", 478, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("addressPortMappingLowThreshold", 479, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("addressPortMappingPerUserHighThreshold", 480, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("globalAddressMappingHighThreshold", 481, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("vpnIdentifier", 482, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpCommunity", 483, 3, 0, 4), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpSourceCommunityList", 484, 20, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpDestinationCommunityList", 485, 20, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpExtendedCommunity", 486, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpSourceExtendedCommunityList", 487, 20, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpDestinationExtendedCommunityList", 488, 20, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpLargeCommunity", 489, 0, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpSourceLargeCommunityList", 490, 20, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("bgpDestinationLargeCommunityList", 491, 20, 0, 65535), 0)
-	registerInfoElement(*entities.NewInfoElement("Unassigned", 0, 255, 0, 0), 0)
+func (r *Registry) loadIANARegistry() {
+	r.registerInfoElement(entities.InfoElement{Name: "octetDeltaCount", ElementId: 1, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "packetDeltaCount", ElementId: 2, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "deltaFlowCount", ElementId: 3, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 4, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "protocolIdentifier", ElementId: 4, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ipClassOfService", ElementId: 5, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "tcpControlBits", ElementId: 6, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 5, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "sourceTransportPort", ElementId: 7, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("sourceIPv4Address", 8, 18, 0, 4), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "sourceIPv4PrefixLength", ElementId: 9, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 0, Units: 1, RangeBegin: 0, RangeEnd: 32}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ingressInterface", ElementId: 10, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "destinationTransportPort", ElementId: 11, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("destinationIPv4Address", 12, 18, 0, 4), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "destinationIPv4PrefixLength", ElementId: 13, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 0, Units: 1, RangeBegin: 0, RangeEnd: 32}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "egressInterface", ElementId: 14, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("ipNextHopIPv4Address", 15, 18, 0, 4), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "bgpSourceAsNumber", ElementId: 16, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "bgpDestinationAsNumber", ElementId: 17, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("bgpNextHopIPv4Address", 18, 18, 0, 4), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postMCastPacketDeltaCount", ElementId: 19, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postMCastOctetDeltaCount", ElementId: 20, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowEndSysUpTime", ElementId: 21, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 6, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowStartSysUpTime", ElementId: 22, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 6, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postOctetDeltaCount", ElementId: 23, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postPacketDeltaCount", ElementId: 24, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "minimumIpTotalLength", ElementId: 25, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "maximumIpTotalLength", ElementId: 26, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("sourceIPv6Address", 27, 19, 0, 16), 0)
+	r.registerInfoElement(*entities.NewInfoElement("destinationIPv6Address", 28, 19, 0, 16), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "sourceIPv6PrefixLength", ElementId: 29, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 0, Units: 1, RangeBegin: 0, RangeEnd: 128}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "destinationIPv6PrefixLength", ElementId: 30, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 0, Units: 1, RangeBegin: 0, RangeEnd: 128}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowLabelIPv6", ElementId: 31, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "icmpTypeCodeIPv4", ElementId: 32, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "igmpType", ElementId: 33, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplingInterval", ElementId: 34, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 1, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplingAlgorithm", ElementId: 35, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowActiveTimeout", ElementId: 36, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 0, Units: 5, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowIdleTimeout", ElementId: 37, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 0, Units: 5, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "engineType", ElementId: 38, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "engineId", ElementId: 39, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "exportedOctetTotalCount", ElementId: 40, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "exportedMessageTotalCount", ElementId: 41, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 10, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "exportedFlowRecordTotalCount", ElementId: 42, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 4, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("ipv4RouterSc", 43, 18, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("sourceIPv4Prefix", 44, 18, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("destinationIPv4Prefix", 45, 18, 0, 4), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "mplsTopLabelType", ElementId: 46, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsTopLabelIPv4Address", 47, 18, 0, 4), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplerId", ElementId: 48, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplerMode", ElementId: 49, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplerRandomInterval", ElementId: 50, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "classId", ElementId: 51, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "minimumTTL", ElementId: 52, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 0, Units: 11, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "maximumTTL", ElementId: 53, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 0, Units: 11, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "fragmentIdentification", ElementId: 54, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postIpClassOfService", ElementId: 55, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("sourceMacAddress", 56, 12, 0, 6), 0)
+	r.registerInfoElement(*entities.NewInfoElement("postDestinationMacAddress", 57, 12, 0, 6), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "vlanId", ElementId: 58, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postVlanId", ElementId: 59, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ipVersion", ElementId: 60, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowDirection", ElementId: 61, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("ipNextHopIPv6Address", 62, 19, 0, 16), 0)
+	r.registerInfoElement(*entities.NewInfoElement("bgpNextHopIPv6Address", 63, 19, 0, 16), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ipv6ExtensionHeaders", ElementId: 64, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 5, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsTopLabelStackSection", 70, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection2", 71, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection3", 72, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection4", 73, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection5", 74, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection6", 75, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection7", 76, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection8", 77, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection9", 78, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection10", 79, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("destinationMacAddress", 80, 12, 0, 6), 0)
+	r.registerInfoElement(*entities.NewInfoElement("postSourceMacAddress", 81, 12, 0, 6), 0)
+	r.registerInfoElement(*entities.NewInfoElement("interfaceName", 82, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("interfaceDescription", 83, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("samplerName", 84, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "octetTotalCount", ElementId: 85, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "packetTotalCount", ElementId: 86, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flagsAndSamplerId", ElementId: 87, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "fragmentOffset", ElementId: 88, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "forwardingStatus", ElementId: 89, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsVpnRouteDistinguisher", 90, 0, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "mplsTopLabelPrefixLength", ElementId: 91, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 0, Units: 1, RangeBegin: 0, RangeEnd: 32}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "srcTrafficIndex", ElementId: 92, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "dstTrafficIndex", ElementId: 93, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("applicationDescription", 94, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("applicationId", 95, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("applicationName", 96, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postIpDiffServCodePoint", ElementId: 98, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 63}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "multicastReplicationFactor", ElementId: 99, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("className", 100, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "classificationEngineId", ElementId: 101, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "layer2packetSectionOffset", ElementId: 102, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "layer2packetSectionSize", ElementId: 103, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("layer2packetSectionData", 104, 0, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "bgpNextAdjacentAsNumber", ElementId: 128, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "bgpPrevAdjacentAsNumber", ElementId: 129, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("exporterIPv4Address", 130, 18, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("exporterIPv6Address", 131, 19, 0, 16), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "droppedOctetDeltaCount", ElementId: 132, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "droppedPacketDeltaCount", ElementId: 133, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "droppedOctetTotalCount", ElementId: 134, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "droppedPacketTotalCount", ElementId: 135, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowEndReason", ElementId: 136, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "commonPropertiesId", ElementId: 137, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "observationPointId", ElementId: 138, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "icmpTypeCodeIPv6", ElementId: 139, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsTopLabelIPv6Address", 140, 19, 0, 16), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "lineCardId", ElementId: 141, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "portId", ElementId: 142, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "meteringProcessId", ElementId: 143, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "exportingProcessId", ElementId: 144, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "templateId", ElementId: 145, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "wlanChannelId", ElementId: 146, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("wlanSSID", 147, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowId", ElementId: 148, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "observationDomainId", ElementId: 149, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowStartSeconds", ElementId: 150, DataType: 14, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 5, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowEndSeconds", ElementId: 151, DataType: 14, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 5, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowStartMilliseconds", ElementId: 152, DataType: 15, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 6, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowEndMilliseconds", ElementId: 153, DataType: 15, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 6, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowStartMicroseconds", ElementId: 154, DataType: 16, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 7, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowEndMicroseconds", ElementId: 155, DataType: 16, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 7, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowStartNanoseconds", ElementId: 156, DataType: 17, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 8, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowEndNanoseconds", ElementId: 157, DataType: 17, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 8, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowStartDeltaMicroseconds", ElementId: 158, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 7, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowEndDeltaMicroseconds", ElementId: 159, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 7, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "systemInitTimeMilliseconds", ElementId: 160, DataType: 15, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 6, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowDurationMilliseconds", ElementId: 161, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 6, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowDurationMicroseconds", ElementId: 162, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 7, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "observedFlowTotalCount", ElementId: 163, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 4, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ignoredPacketTotalCount", ElementId: 164, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ignoredOctetTotalCount", ElementId: 165, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "notSentFlowTotalCount", ElementId: 166, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 4, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "notSentPacketTotalCount", ElementId: 167, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "notSentOctetTotalCount", ElementId: 168, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("destinationIPv6Prefix", 169, 19, 0, 16), 0)
+	r.registerInfoElement(*entities.NewInfoElement("sourceIPv6Prefix", 170, 19, 0, 16), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postOctetTotalCount", ElementId: 171, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postPacketTotalCount", ElementId: 172, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowKeyIndicator", ElementId: 173, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 5, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postMCastPacketTotalCount", ElementId: 174, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postMCastOctetTotalCount", ElementId: 175, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "icmpTypeIPv4", ElementId: 176, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "icmpCodeIPv4", ElementId: 177, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "icmpTypeIPv6", ElementId: 178, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "icmpCodeIPv6", ElementId: 179, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "udpSourcePort", ElementId: 180, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "udpDestinationPort", ElementId: 181, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "tcpSourcePort", ElementId: 182, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "tcpDestinationPort", ElementId: 183, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("tcpSequenceNumber", 184, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("tcpAcknowledgementNumber", 185, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("tcpWindowSize", 186, 2, 0, 2), 0)
+	r.registerInfoElement(*entities.NewInfoElement("tcpUrgentPointer", 187, 2, 0, 2), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "tcpHeaderLength", ElementId: 188, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ipHeaderLength", ElementId: 189, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "totalLengthIPv4", ElementId: 190, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "payloadLengthIPv6", ElementId: 191, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ipTTL", ElementId: 192, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 0, Units: 11, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("nextHeaderIPv6", 193, 1, 0, 1), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "mplsPayloadLength", ElementId: 194, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ipDiffServCodePoint", ElementId: 195, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 63}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ipPrecedence", ElementId: 196, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 7}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "fragmentFlags", ElementId: 197, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 5, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "octetDeltaSumOfSquares", ElementId: 198, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "octetTotalSumOfSquares", ElementId: 199, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "mplsTopLabelTTL", ElementId: 200, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 0, Units: 11, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "mplsLabelStackLength", ElementId: 201, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsLabelStackDepth", 202, 3, 0, 4), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "mplsTopLabelExp", ElementId: 203, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 5, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ipPayloadLength", ElementId: 204, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "udpMessageLength", ElementId: 205, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "isMulticast", ElementId: 206, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 5, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ipv4IHL", ElementId: 207, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 0, Units: 9, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ipv4Options", ElementId: 208, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 5, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "tcpOptions", ElementId: 209, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 5, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("paddingOctets", 210, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("collectorIPv4Address", 211, 18, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("collectorIPv6Address", 212, 19, 0, 16), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "exportInterface", ElementId: 213, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "exportProtocolVersion", ElementId: 214, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "exportTransportProtocol", ElementId: 215, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "collectorTransportPort", ElementId: 216, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "exporterTransportPort", ElementId: 217, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "tcpSynTotalCount", ElementId: 218, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "tcpFinTotalCount", ElementId: 219, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "tcpRstTotalCount", ElementId: 220, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "tcpPshTotalCount", ElementId: 221, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "tcpAckTotalCount", ElementId: 222, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "tcpUrgTotalCount", ElementId: 223, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ipTotalLength", ElementId: 224, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("postNATSourceIPv4Address", 225, 18, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("postNATDestinationIPv4Address", 226, 18, 0, 4), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postNAPTSourceTransportPort", ElementId: 227, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postNAPTDestinationTransportPort", ElementId: 228, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "natOriginatingAddressRealm", ElementId: 229, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "natEvent", ElementId: 230, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "initiatorOctets", ElementId: 231, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "responderOctets", ElementId: 232, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "firewallEvent", ElementId: 233, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ingressVRFID", ElementId: 234, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "egressVRFID", ElementId: 235, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("VRFname", 236, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postMplsTopLabelExp", ElementId: 237, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 5, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("tcpWindowScale", 238, 2, 0, 2), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "biflowDirection", ElementId: 239, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ethernetHeaderLength", ElementId: 240, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ethernetPayloadLength", ElementId: 241, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ethernetTotalLength", ElementId: 242, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "dot1qVlanId", ElementId: 243, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "dot1qPriority", ElementId: 244, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "dot1qCustomerVlanId", ElementId: 245, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "dot1qCustomerPriority", ElementId: 246, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("metroEvcId", 247, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "metroEvcType", ElementId: 248, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "pseudoWireId", ElementId: 249, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "pseudoWireType", ElementId: 250, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("pseudoWireControlWord", 251, 3, 0, 4), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ingressPhysicalInterface", ElementId: 252, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "egressPhysicalInterface", ElementId: 253, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postDot1qVlanId", ElementId: 254, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postDot1qCustomerVlanId", ElementId: 255, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ethernetType", ElementId: 256, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postIpPrecedence", ElementId: 257, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 7}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "collectionTimeMilliseconds", ElementId: 258, DataType: 15, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 6, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "exportSctpStreamId", ElementId: 259, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "maxExportSeconds", ElementId: 260, DataType: 14, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 5, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "maxFlowEndSeconds", ElementId: 261, DataType: 14, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 5, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("messageMD5Checksum", 262, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("messageScope", 263, 1, 0, 1), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "minExportSeconds", ElementId: 264, DataType: 14, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 5, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "minFlowStartSeconds", ElementId: 265, DataType: 14, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 5, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("opaqueOctets", 266, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("sessionScope", 267, 1, 0, 1), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "maxFlowEndMicroseconds", ElementId: 268, DataType: 16, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 7, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "maxFlowEndMilliseconds", ElementId: 269, DataType: 15, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 6, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "maxFlowEndNanoseconds", ElementId: 270, DataType: 17, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 8, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "minFlowStartMicroseconds", ElementId: 271, DataType: 16, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 7, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "minFlowStartMilliseconds", ElementId: 272, DataType: 15, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 6, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "minFlowStartNanoseconds", ElementId: 273, DataType: 17, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 8, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("collectorCertificate", 274, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("exporterCertificate", 275, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("dataRecordsReliability", 276, 11, 0, 1), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "observationPointType", ElementId: 277, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "newConnectionDeltaCount", ElementId: 278, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 3, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "connectionSumDurationSeconds", ElementId: 279, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 5, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "connectionTransactionId", ElementId: 280, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("postNATSourceIPv6Address", 281, 19, 0, 16), 0)
+	r.registerInfoElement(*entities.NewInfoElement("postNATDestinationIPv6Address", 282, 19, 0, 16), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "natPoolId", ElementId: 283, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("natPoolName", 284, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "anonymizationFlags", ElementId: 285, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 5, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "anonymizationTechnique", ElementId: 286, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "informationElementIndex", ElementId: 287, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("p2pTechnology", 288, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("tunnelTechnology", 289, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("encryptedTechnology", 290, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "basicList", ElementId: 291, DataType: 20, EnterpriseId: 0, Len: 65535, Semantics: 6, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "subTemplateList", ElementId: 292, DataType: 21, EnterpriseId: 0, Len: 65535, Semantics: 6, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "subTemplateMultiList", ElementId: 293, DataType: 22, EnterpriseId: 0, Len: 65535, Semantics: 6, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "bgpValidityState", ElementId: 294, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "IPSecSPI", ElementId: 295, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "greKey", ElementId: 296, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "natType", ElementId: 297, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "initiatorPackets", ElementId: 298, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "responderPackets", ElementId: 299, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("observationDomainName", 300, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "selectionSequenceId", ElementId: 301, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "selectorId", ElementId: 302, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "informationElementId", ElementId: 303, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "selectorAlgorithm", ElementId: 304, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplingPacketInterval", ElementId: 305, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 1, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplingPacketSpace", ElementId: 306, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 1, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplingTimeInterval", ElementId: 307, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 1, Units: 7, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplingTimeSpace", ElementId: 308, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 1, Units: 7, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplingSize", ElementId: 309, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 1, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplingPopulation", ElementId: 310, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 1, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplingProbability", ElementId: 311, DataType: 10, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "dataLinkFrameSize", ElementId: 312, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("ipHeaderPacketSection", 313, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("ipPayloadPacketSection", 314, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("dataLinkFrameSection", 315, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsLabelStackSection", 316, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mplsPayloadPacketSection", 317, 0, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "selectorIdTotalPktsObserved", ElementId: 318, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "selectorIdTotalPktsSelected", ElementId: 319, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "absoluteError", ElementId: 320, DataType: 10, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 15, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "relativeError", ElementId: 321, DataType: 10, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "observationTimeSeconds", ElementId: 322, DataType: 14, EnterpriseId: 0, Len: 4, Semantics: 0, Units: 5, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "observationTimeMilliseconds", ElementId: 323, DataType: 15, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 6, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "observationTimeMicroseconds", ElementId: 324, DataType: 16, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 7, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "observationTimeNanoseconds", ElementId: 325, DataType: 17, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 8, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "digestHashValue", ElementId: 326, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "hashIPPayloadOffset", ElementId: 327, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "hashIPPayloadSize", ElementId: 328, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "hashOutputRangeMin", ElementId: 329, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "hashOutputRangeMax", ElementId: 330, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "hashSelectedRangeMin", ElementId: 331, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "hashSelectedRangeMax", ElementId: 332, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("hashDigestOutput", 333, 11, 0, 1), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "hashInitialiserValue", ElementId: 334, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("selectorName", 335, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "upperCILimit", ElementId: 336, DataType: 10, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "lowerCILimit", ElementId: 337, DataType: 10, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "confidenceLevel", ElementId: 338, DataType: 10, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("informationElementDataType", 339, 1, 0, 1), 0)
+	r.registerInfoElement(*entities.NewInfoElement("informationElementDescription", 340, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("informationElementName", 341, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "informationElementRangeBegin", ElementId: 342, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "informationElementRangeEnd", ElementId: 343, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("informationElementSemantics", 344, 1, 0, 1), 0)
+	r.registerInfoElement(*entities.NewInfoElement("informationElementUnits", 345, 2, 0, 2), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "privateEnterpriseNumber", ElementId: 346, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("virtualStationInterfaceId", 347, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("virtualStationInterfaceName", 348, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("virtualStationUUID", 349, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("virtualStationName", 350, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "layer2SegmentId", ElementId: 351, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "layer2OctetDeltaCount", ElementId: 352, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "layer2OctetTotalCount", ElementId: 353, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ingressUnicastPacketTotalCount", ElementId: 354, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ingressMulticastPacketTotalCount", ElementId: 355, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ingressBroadcastPacketTotalCount", ElementId: 356, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "egressUnicastPacketTotalCount", ElementId: 357, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "egressBroadcastPacketTotalCount", ElementId: 358, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "monitoringIntervalStartMilliSeconds", ElementId: 359, DataType: 15, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 6, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "monitoringIntervalEndMilliSeconds", ElementId: 360, DataType: 15, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 6, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("portRangeStart", 361, 2, 0, 2), 0)
+	r.registerInfoElement(*entities.NewInfoElement("portRangeEnd", 362, 2, 0, 2), 0)
+	r.registerInfoElement(*entities.NewInfoElement("portRangeStepSize", 363, 2, 0, 2), 0)
+	r.registerInfoElement(*entities.NewInfoElement("portRangeNumPorts", 364, 2, 0, 2), 0)
+	r.registerInfoElement(*entities.NewInfoElement("staMacAddress", 365, 12, 0, 6), 0)
+	r.registerInfoElement(*entities.NewInfoElement("staIPv4Address", 366, 18, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("wtpMacAddress", 367, 12, 0, 6), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ingressInterfaceType", ElementId: 368, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "egressInterfaceType", ElementId: 369, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("rtpSequenceNumber", 370, 2, 0, 2), 0)
+	r.registerInfoElement(*entities.NewInfoElement("userName", 371, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("applicationCategoryName", 372, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("applicationSubCategoryName", 373, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("applicationGroupName", 374, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "originalFlowsPresent", ElementId: 375, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 4, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "originalFlowsInitiated", ElementId: 376, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 4, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "originalFlowsCompleted", ElementId: 377, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 4, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "distinctCountOfSourceIPAddress", ElementId: 378, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "distinctCountOfDestinationIPAddress", ElementId: 379, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "distinctCountOfSourceIPv4Address", ElementId: 380, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 2, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "distinctCountOfDestinationIPv4Address", ElementId: 381, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 2, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "distinctCountOfSourceIPv6Address", ElementId: 382, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "distinctCountOfDestinationIPv6Address", ElementId: 383, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "valueDistributionMethod", ElementId: 384, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "rfc3550JitterMilliseconds", ElementId: 385, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 1, Units: 6, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "rfc3550JitterMicroseconds", ElementId: 386, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 1, Units: 7, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "rfc3550JitterNanoseconds", ElementId: 387, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 1, Units: 8, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("dot1qDEI", 388, 11, 0, 1), 0)
+	r.registerInfoElement(*entities.NewInfoElement("dot1qCustomerDEI", 389, 11, 0, 1), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowSelectorAlgorithm", ElementId: 390, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowSelectedOctetDeltaCount", ElementId: 391, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowSelectedPacketDeltaCount", ElementId: 392, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowSelectedFlowDeltaCount", ElementId: 393, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 4, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "selectorIDTotalFlowsObserved", ElementId: 394, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 4, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "selectorIDTotalFlowsSelected", ElementId: 395, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 4, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplingFlowInterval", ElementId: 396, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 4, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "samplingFlowSpacing", ElementId: 397, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 4, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowSamplingTimeInterval", ElementId: 398, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 7, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "flowSamplingTimeSpacing", ElementId: 399, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 1, Units: 7, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "hashFlowDomain", ElementId: 400, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "transportOctetDeltaCount", ElementId: 401, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "transportPacketDeltaCount", ElementId: 402, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 3, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("originalExporterIPv4Address", 403, 18, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("originalExporterIPv6Address", 404, 19, 0, 16), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "originalObservationDomainId", ElementId: 405, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "intermediateProcessId", ElementId: 406, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ignoredDataRecordTotalCount", ElementId: 407, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "dataLinkFrameType", ElementId: 408, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 5, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "sectionOffset", ElementId: 409, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "sectionExportedOctets", ElementId: 410, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 1, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("dot1qServiceInstanceTag", 411, 0, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "dot1qServiceInstanceId", ElementId: 412, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "dot1qServiceInstancePriority", ElementId: 413, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("dot1qCustomerSourceMacAddress", 414, 12, 0, 6), 0)
+	r.registerInfoElement(*entities.NewInfoElement("dot1qCustomerDestinationMacAddress", 415, 12, 0, 6), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postLayer2OctetDeltaCount", ElementId: 417, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postMCastLayer2OctetDeltaCount", ElementId: 418, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postLayer2OctetTotalCount", ElementId: 420, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "postMCastLayer2OctetTotalCount", ElementId: 421, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "minimumLayer2TotalLength", ElementId: 422, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "maximumLayer2TotalLength", ElementId: 423, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 0, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "droppedLayer2OctetDeltaCount", ElementId: 424, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "droppedLayer2OctetTotalCount", ElementId: 425, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ignoredLayer2OctetTotalCount", ElementId: 426, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "notSentLayer2OctetTotalCount", ElementId: 427, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "layer2OctetDeltaSumOfSquares", ElementId: 428, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "layer2OctetTotalSumOfSquares", ElementId: 429, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "layer2FrameDeltaCount", ElementId: 430, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 3, Units: 13, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "layer2FrameTotalCount", ElementId: 431, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 13, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("pseudoWireDestinationIPv4Address", 432, 18, 0, 4), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "ignoredLayer2FrameTotalCount", ElementId: 433, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 2, Units: 13, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibObjectValueInteger", 434, 7, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibObjectValueOctetString", 435, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibObjectValueOID", 436, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibObjectValueBits", 437, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibObjectValueIPAddress", 438, 18, 0, 4), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "mibObjectValueCounter", ElementId: 439, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 7, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "mibObjectValueGauge", ElementId: 440, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 8, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibObjectValueTimeTicks", 441, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibObjectValueUnsigned", 442, 3, 0, 4), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "mibObjectValueTable", ElementId: 443, DataType: 21, EnterpriseId: 0, Len: 65535, Semantics: 6, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "mibObjectValueRow", ElementId: 444, DataType: 21, EnterpriseId: 0, Len: 65535, Semantics: 6, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibObjectIdentifier", 445, 0, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "mibSubIdentifier", ElementId: 446, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "mibIndexIndicator", ElementId: 447, DataType: 4, EnterpriseId: 0, Len: 8, Semantics: 5, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "mibCaptureTimeSemantics", ElementId: 448, DataType: 1, EnterpriseId: 0, Len: 1, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibContextEngineID", 449, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibContextName", 450, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibObjectName", 451, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibObjectDescription", 452, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibObjectSyntax", 453, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mibModuleName", 454, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mobileIMSI", 455, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("mobileMSISDN", 456, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "httpStatusCode", ElementId: 457, DataType: 2, EnterpriseId: 0, Len: 2, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("sourceTransportPortsLimit", 458, 2, 0, 2), 0)
+	r.registerInfoElement(*entities.NewInfoElement("httpRequestMethod", 459, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("httpRequestHost", 460, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("httpRequestTarget", 461, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("httpMessageVersion", 462, 13, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "natInstanceID", ElementId: 463, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("internalAddressRealm", 464, 0, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("externalAddressRealm", 465, 0, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "natQuotaExceededEvent", ElementId: 466, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "natThresholdEvent", ElementId: 467, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("httpUserAgent", 468, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("httpContentType", 469, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("httpReasonPhrase", 470, 13, 0, 65535), 0)
+	r.registerInfoElement(*entities.NewInfoElement("maxSessionEntries", 471, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("maxBIBEntries", 472, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("maxEntriesPerUser", 473, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("maxSubscribers", 474, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("maxFragmentsPendingReassembly", 475, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("addressPoolHighThreshold", 476, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("addressPoolLowThreshold", 477, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("addressPortMappingHighThreshold", 478, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("addressPortMappingLowThreshold", 479, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("addressPortMappingPerUserHighThreshold", 480, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("globalAddressMappingHighThreshold", 481, 3, 0, 4), 0)
+	r.registerInfoElement(*entities.NewInfoElement("vpnIdentifier", 482, 0, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "bgpCommunity", ElementId: 483, DataType: 3, EnterpriseId: 0, Len: 4, Semantics: 4, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "bgpSourceCommunityList", ElementId: 484, DataType: 20, EnterpriseId: 0, Len: 65535, Semantics: 6, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "bgpDestinationCommunityList", ElementId: 485, DataType: 20, EnterpriseId: 0, Len: 65535, Semantics: 6, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("bgpExtendedCommunity", 486, 0, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "bgpSourceExtendedCommunityList", ElementId: 487, DataType: 20, EnterpriseId: 0, Len: 65535, Semantics: 6, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "bgpDestinationExtendedCommunityList", ElementId: 488, DataType: 20, EnterpriseId: 0, Len: 65535, Semantics: 6, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(*entities.NewInfoElement("bgpLargeCommunity", 489, 0, 0, 65535), 0)
+	r.registerInfoElement(entities.InfoElement{Name: "bgpSourceLargeCommunityList", ElementId: 490, DataType: 20, EnterpriseId: 0, Len: 65535, Semantics: 6, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
+	r.registerInfoElement(entities.InfoElement{Name: "bgpDestinationLargeCommunityList", ElementId: 491, DataType: 20, EnterpriseId: 0, Len: 65535, Semantics: 6, Units: 0, RangeBegin: 0, RangeEnd: 0}, 0)
 }
@@ -20,41 +20,41 @@ import (
 
 // AUTO GENERATED, DO NOT CHANGE
 
-func loadAntreaRegistry() {
-	registerInfoElement(*entities.NewInfoElement("sourcePodNamespace", 100, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("sourcePodName", 101, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("destinationPodNamespace", 102, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("destinationPodName", 103, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("sourceNodeName", 104, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("destinationNodeName", 105, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("destinationClusterIPv4", 106, 18, 56506, 4), 56506)
-	registerInfoElement(*entities.NewInfoElement("destinationClusterIPv6", 107, 19, 56506, 16), 56506)
-	registerInfoElement(*entities.NewInfoElement("destinationServicePort", 108, 2, 56506, 2), 56506)
-	registerInfoElement(*entities.NewInfoElement("destinationServicePortName", 109, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("ingressNetworkPolicyName", 110, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("ingressNetworkPolicyNamespace", 111, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("egressNetworkPolicyName", 112, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("egressNetworkPolicyNamespace", 113, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("ingressNetworkPolicyUID", 114, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("ingressNetworkPolicyType", 115, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("ingressNetworkPolicyRulePriority", 116, 2, 56506, 2), 56506)
-	registerInfoElement(*entities.NewInfoElement("egressNetworkPolicyUID", 117, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("egressNetworkPolicyType", 118, 13, 56506, 65535), 56506)
-	registerInfoElement(*entities.NewInfoElement("egressNetworkPolicyRulePriority", 119, 2, 56506, 2), 56506)
-	registerInfoElement(*entities.NewInfoElement("packetTotalCountFromSourceNode", 120, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("octetTotalCountFromSourceNode", 121, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("packetDeltaCountFromSourceNode", 122, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("octetDeltaCountFromSourceNode", 123, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("reversePacketTotalCountFromSourceNode", 124, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("reverseOctetTotalCountFromSourceNode", 125, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("reversePacketDeltaCountFromSourceNode", 126, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("reverseOctetDeltaCountFromSourceNode", 127, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("packetTotalCountFromDestinationNode", 128, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("octetTotalCountFromDestinationNode", 129, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("packetDeltaCountFromDestinationNode", 130, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("octetDeltaCountFromDestinationNode", 131, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("reversePacketTotalCountFromDestinationNode", 132, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("reverseOctetTotalCountFromDestinationNode", 133, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("reversePacketDeltaCountFromDestinationNode", 134, 4, 56506, 8), 56506)
-	registerInfoElement(*entities.NewInfoElement("reverseOctetDeltaCountFromDestinationNode", 135, 4, 56506, 8), 56506)
+func (r *Registry) loadAntreaRegistry() {
+	r.registerInfoElement(*entities.NewInfoElement("sourcePodNamespace", 100, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("sourcePodName", 101, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("destinationPodNamespace", 102, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("destinationPodName", 103, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("sourceNodeName", 104, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("destinationNodeName", 105, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("destinationClusterIPv4", 106, 18, 56506, 4), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("destinationClusterIPv6", 107, 19, 56506, 16), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("destinationServicePort", 108, 2, 56506, 2), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("destinationServicePortName", 109, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("ingressNetworkPolicyName", 110, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("ingressNetworkPolicyNamespace", 111, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("egressNetworkPolicyName", 112, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("egressNetworkPolicyNamespace", 113, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("ingressNetworkPolicyUID", 114, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("ingressNetworkPolicyType", 115, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("ingressNetworkPolicyRulePriority", 116, 2, 56506, 2), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("egressNetworkPolicyUID", 117, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("egressNetworkPolicyType", 118, 13, 56506, 65535), 56506)
+	r.registerInfoElement(*entities.NewInfoElement("egressNetworkPolicyRulePriority", 119, 2, 56506, 2), 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "packetTotalCountFromSourceNode", ElementId: 120, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "octetTotalCountFromSourceNode", ElementId: 121, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "packetDeltaCountFromSourceNode", ElementId: 122, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 3, Units: 3, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "octetDeltaCountFromSourceNode", ElementId: 123, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "reversePacketTotalCountFromSourceNode", ElementId: 124, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "reverseOctetTotalCountFromSourceNode", ElementId: 125, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "reversePacketDeltaCountFromSourceNode", ElementId: 126, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 3, Units: 3, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "reverseOctetDeltaCountFromSourceNode", ElementId: 127, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "packetTotalCountFromDestinationNode", ElementId: 128, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "octetTotalCountFromDestinationNode", ElementId: 129, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "packetDeltaCountFromDestinationNode", ElementId: 130, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 3, Units: 3, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "octetDeltaCountFromDestinationNode", ElementId: 131, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "reversePacketTotalCountFromDestinationNode", ElementId: 132, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 2, Units: 3, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "reverseOctetTotalCountFromDestinationNode", ElementId: 133, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 2, Units: 2, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "reversePacketDeltaCountFromDestinationNode", ElementId: 134, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 3, Units: 3, RangeBegin: 0, RangeEnd: 0}, 56506)
+	r.registerInfoElement(entities.InfoElement{Name: "reverseOctetDeltaCountFromDestinationNode", ElementId: 135, DataType: 4, EnterpriseId: 56506, Len: 8, Semantics: 3, Units: 2, RangeBegin: 0, RangeEnd: 0}, 56506)
 }
@@ -0,0 +1,192 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+)
+
+// Column names of the registry CSV format, which is the format of the IANA
+// registry and registry_antrea.csv. Other columns are ignored.
+const (
+	csvElementIDColumn    = "ElementID"
+	csvNameColumn         = "Name"
+	csvDataTypeColumn     = "Abstract Data Type"
+	csvEnterpriseIDColumn = "Enterprise ID"
+	// Optional columns
+	csvSemanticsColumn = "Data Type Semantics"
+	csvUnitsColumn     = "Units"
+	csvRangeColumn     = "Range"
+)
+
+// yamlInfoElement is an Information Element in the registry YAML format, e.g.
+//
+//   - elementId: 100
+//     name: sourcePodNamespace
+//     dataType: string
+//     enterpriseId: 56506
+//
+// Length is optional and is the length of the data type if it is not given.
+// Semantics, units and range are optional, and use the names of the IANA
+// registry, e.g. "deltaCounter", "octets" and "0-255".
+type yamlInfoElement struct {
+	ElementID    uint16 `yaml:"elementId"`
+	Name         string `yaml:"name"`
+	DataType     string `yaml:"dataType"`
+	EnterpriseID uint32 `yaml:"enterpriseId"`
+	Length       uint16 `yaml:"length"`
+	Semantics    string `yaml:"semantics"`
+	Units        string `yaml:"units"`
+	Range        string `yaml:"range"`
+}
+
+// LoadFile registers the Information Elements defined in the given file into
+// the default registry. See Registry.LoadFile for the supported formats.
+func LoadFile(path string) error {
+	return defaultRegistry.LoadFile(path)
+}
+
+// LoadFile registers the Information Elements defined in the given file. Files
+// with extension .csv are read in the registry CSV format, and files with
+// extension .yaml or .yml are read in the registry YAML format.
+func (r *Registry) LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		err = r.LoadCSV(file)
+	case ".yaml", ".yml":
+		err = r.LoadYAML(file)
+	default:
+		return fmt.Errorf("registry file %s is not a CSV or YAML file", path)
+	}
+	if err != nil {
+		return fmt.Errorf("error when loading registry file %s: %v", path, err)
+	}
+	return nil
+}
+
+// LoadCSV registers the Information Elements defined in the registry CSV
+// format. The first row is the header, which must contain the ElementID, Name,
+// Abstract Data Type and Enterprise ID columns. The Data Type Semantics, Units
+// and Range columns are optional. No element is registered if a row is not
+// valid, or has the ID or the name of another element.
+func (r *Registry) LoadCSV(reader io.Reader) error {
+	csvReader := csv.NewReader(reader)
+	// Rows of the IANA registry and registry_antrea.csv have trailing commas.
+	csvReader.FieldsPerRecord = -1
+	rows, err := csvReader.ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("registry CSV does not have a header")
+	}
+	columns := make(map[string]int)
+	for i, column := range rows[0] {
+		columns[strings.TrimSpace(column)] = i
+	}
+	for _, column := range []string{csvElementIDColumn, csvNameColumn, csvDataTypeColumn, csvEnterpriseIDColumn} {
+		if _, exist := columns[column]; !exist {
+			return fmt.Errorf("registry CSV does not have column %s", column)
+		}
+	}
+	ies := make([]*entities.InfoElement, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		getColumn := func(column string) string {
+			index, exist := columns[column]
+			if !exist || index >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[index])
+		}
+		elementID, err := strconv.ParseUint(getColumn(csvElementIDColumn), 10, 16)
+		if err != nil {
+			return fmt.Errorf("row %d has invalid element ID: %v", i+2, err)
+		}
+		enterpriseID, err := strconv.ParseUint(getColumn(csvEnterpriseIDColumn), 10, 32)
+		if err != nil {
+			return fmt.Errorf("row %d has invalid enterprise ID: %v", i+2, err)
+		}
+		ie, err := newInfoElement(getColumn(csvNameColumn), uint16(elementID), getColumn(csvDataTypeColumn), uint32(enterpriseID), 0)
+		if err != nil {
+			return fmt.Errorf("row %d is not valid: %v", i+2, err)
+		}
+		setInfoElementMetadata(ie, getColumn(csvSemanticsColumn), getColumn(csvUnitsColumn), getColumn(csvRangeColumn))
+		ies = append(ies, ie)
+	}
+	return r.registerAll(ies)
+}
+
+// LoadYAML registers the Information Elements defined in the registry YAML
+// format, which is a list of elements. No element is registered if an element
+// is not valid, or has the ID or the name of another element.
+func (r *Registry) LoadYAML(reader io.Reader) error {
+	var elements []yamlInfoElement
+	if err := yaml.NewDecoder(reader).Decode(&elements); err != nil && err != io.EOF {
+		return err
+	}
+	ies := make([]*entities.InfoElement, 0, len(elements))
+	for i, element := range elements {
+		ie, err := newInfoElement(element.Name, element.ElementID, element.DataType, element.EnterpriseID, element.Length)
+		if err != nil {
+			return fmt.Errorf("element %d is not valid: %v", i, err)
+		}
+		setInfoElementMetadata(ie, element.Semantics, element.Units, element.Range)
+		ies = append(ies, ie)
+	}
+	return r.registerAll(ies)
+}
+
+// newInfoElement returns an element with the given data type name. If length
+// is 0, the element has the length of the data type.
+func newInfoElement(name string, elementID uint16, dataTypeName string, enterpriseID uint32, length uint16) (*entities.InfoElement, error) {
+	if name == "" {
+		return nil, fmt.Errorf("element %d with enterprise ID %d does not have a name", elementID, enterpriseID)
+	}
+	dataType := entities.IENameToType(dataTypeName)
+	if !entities.IsValidDataType(dataType) {
+		return nil, fmt.Errorf("data type %s of element %s is not valid", dataTypeName, name)
+	}
+	if length == 0 {
+		length = entities.InfoElementLength[dataType]
+	} else if !entities.IsValidElementLength(dataType, length) {
+		return nil, fmt.Errorf("length %d of element %s is not valid for data type %s", length, name, dataTypeName)
+	}
+	return entities.NewInfoElement(name, elementID, dataType, enterpriseID, length), nil
+}
+
+// setInfoElementMetadata sets the semantics, units and range of the element
+// from their names in the IANA registry. Unknown names are ignored.
+func setInfoElementMetadata(ie *entities.InfoElement, semantics, units, ieRange string) {
+	ie.Semantics = entities.IENameToSemantics(semantics)
+	ie.Units = entities.IENameToUnits(units)
+	if begin, end, ok := entities.ParseIERange(ieRange); ok {
+		ie.RangeBegin, ie.RangeEnd = begin, end
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+)
+
+func TestLoadCSV(t *testing.T) {
+	// registry_antrea.csv is loaded to the same elements as the generated
+	// Antrea registry.
+	r := New()
+	assert.Nil(t, r.LoadFile("registry_antrea.csv"))
+	generated := New()
+	generated.LoadRegistry()
+	for name, expectedIE := range generated.registryByName[AntreaEnterpriseID] {
+		ie, err := r.LookupByName(name, AntreaEnterpriseID)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedIE, ie)
+	}
+
+	csvData := "ElementID,Name,Abstract Data Type,Enterprise ID\n57590,L7_PROTO,unsigned16,35632\n"
+	r = New()
+	assert.Nil(t, r.LoadCSV(strings.NewReader(csvData)))
+	ie, err := r.Lookup(57590, 35632)
+	assert.Nil(t, err)
+	assert.Equal(t, entities.NewInfoElement("L7_PROTO", 57590, entities.Unsigned16, 35632, 2), ie)
+
+	// Semantics, units and range are optional columns.
+	csvData = "ElementID,Name,Abstract Data Type,Data Type Semantics,Units,Range,Enterprise ID\n1,vendorBytes,unsigned64,deltaCounter,octets,,9\n2,vendorPriority,unsigned8,quantity,,0-7,9\n"
+	r = New()
+	assert.Nil(t, r.LoadCSV(strings.NewReader(csvData)))
+	ie, err = r.Lookup(1, 9)
+	assert.Nil(t, err)
+	assert.Equal(t, entities.DeltaCounter, ie.Semantics)
+	assert.Equal(t, entities.UnitsOctets, ie.Units)
+	ie, err = r.Lookup(2, 9)
+	assert.Nil(t, err)
+	assert.Equal(t, entities.Quantity, ie.Semantics)
+	assert.Equal(t, uint64(0), ie.RangeBegin)
+	assert.Equal(t, uint64(7), ie.RangeEnd)
+
+	for _, invalidData := range []string{
+		"",
+		"ElementID,Name,Abstract Data Type\n57590,L7_PROTO,unsigned16\n",
+		"ElementID,Name,Abstract Data Type,Enterprise ID\nabc,L7_PROTO,unsigned16,35632\n",
+		"ElementID,Name,Abstract Data Type,Enterprise ID\n57590,L7_PROTO,unsigned128,35632\n",
+		"ElementID,Name,Abstract Data Type,Enterprise ID\n57590,,unsigned16,35632\n",
+	} {
+		assert.NotNil(t, New().LoadCSV(strings.NewReader(invalidData)), "Error should be returned for registry CSV %q", invalidData)
+	}
+	// Valid rows are not registered if another row is not valid.
+	csvData = "ElementID,Name,Abstract Data Type,Enterprise ID\n1,vendorBytes,unsigned64,9\n2,vendorPriority,unsigned128,9\n"
+	r = New()
+	assert.NotNil(t, r.LoadCSV(strings.NewReader(csvData)))
+	_, err = r.Lookup(1, 9)
+	assert.NotNil(t, err, "Element should not be registered if registry CSV is not valid.")
+	// Registry is unchanged if the last row has the ID or the name of an
+	// element of another row or of the registry.
+	header := "ElementID,Name,Abstract Data Type,Enterprise ID\n"
+	r = New()
+	assert.Nil(t, r.LoadCSV(strings.NewReader(header+"1,vendorBytes,unsigned64,9\n")))
+	for _, lastRow := range []string{
+		"1,vendorPriority,unsigned8,9",
+		"3,vendorBytes,unsigned8,9",
+		"2,vendorFlags,unsigned8,9",
+		"3,vendorPackets,unsigned64,9",
+	} {
+		csvData = header + "2,vendorPackets,unsigned64,9\n" + lastRow + "\n"
+		assert.NotNil(t, r.LoadCSV(strings.NewReader(csvData)), "Error should be returned for registry CSV %q", csvData)
+		assert.Equal(t, 1, len(r.registryByID[9]), "Registry should be unchanged if registry CSV is not valid.")
+		assert.Equal(t, 1, len(r.registryByName[9]), "Registry should be unchanged if registry CSV is not valid.")
+		ie, err = r.Lookup(1, 9)
+		assert.Nil(t, err)
+		assert.Equal(t, "vendorBytes", ie.Name)
+	}
+}
+
+func TestLoadYAML(t *testing.T) {
+	yamlData := `
+- elementId: 57590
+  name: L7_PROTO
+  dataType: unsigned16
+  enterpriseId: 35632
+- elementId: 1
+  name: ciscoCounter
+  dataType: unsigned64
+  enterpriseId: 9
+  length: 4
+  semantics: totalCounter
+  units: packets
+`
+	r := New()
+	assert.Nil(t, r.LoadYAML(strings.NewReader(yamlData)))
+	ie, err := r.LookupByName("L7_PROTO", 35632)
+	assert.Nil(t, err)
+	assert.Equal(t, entities.NewInfoElement("L7_PROTO", 57590, entities.Unsigned16, 35632, 2), ie)
+	ie, err = r.Lookup(1, 9)
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(4), ie.Len, "Length given in the registry should be used.")
+	assert.Equal(t, entities.TotalCounter, ie.Semantics)
+	assert.Equal(t, entities.UnitsPackets, ie.Units)
+	// Element is already registered
+	assert.NotNil(t, r.LoadYAML(strings.NewReader(yamlData)))
+	// Invalid length for the data type
+	r = New()
+	assert.NotNil(t, r.LoadYAML(strings.NewReader("- {elementId: 2, name: port, dataType: unsigned16, enterpriseId: 9}\n- {elementId: 1, name: address, dataType: ipv4Address, enterpriseId: 9, length: 2}")))
+	_, err = r.Lookup(2, 9)
+	assert.NotNil(t, err, "Element should not be registered if registry YAML is not valid.")
+	// Not a list of elements
+	assert.NotNil(t, New().LoadYAML(strings.NewReader("elementId: 1")))
+}
+
+func TestLoadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registry")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	yamlFile := filepath.Join(dir, "vmware.yml")
+	assert.Nil(t, ioutil.WriteFile(yamlFile, []byte("- {elementId: 900, name: vmwareTenantId, dataType: unsigned64, enterpriseId: 6876}"), 0644))
+	r := New()
+	assert.Nil(t, r.LoadFile(yamlFile))
+	ie, err := r.Lookup(900, 6876)
+	assert.Nil(t, err)
+	assert.Equal(t, "vmwareTenantId", ie.Name)
+
+	textFile := filepath.Join(dir, "vmware.txt")
+	assert.Nil(t, ioutil.WriteFile(textFile, []byte{}, 0644))
+	assert.NotNil(t, r.LoadFile(textFile), "Error should be returned for file which is not CSV or YAML.")
+	assert.NotNil(t, r.LoadFile(filepath.Join(dir, "missing.csv")))
+}
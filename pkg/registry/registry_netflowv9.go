@@ -0,0 +1,160 @@
+// Copyright 2020 VMware, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+
+	"github.com/vmware/go-ipfix/pkg/entities"
+)
+
+// netflowV9FieldTypes shows mapping NetFlow v9 field type -> IANA Information
+// Element name. The field types are defined in Section 8 of RFC3954.
+var netflowV9FieldTypes = map[uint16]string{
+	1:  "octetDeltaCount",              // IN_BYTES
+	2:  "packetDeltaCount",             // IN_PKTS
+	3:  "deltaFlowCount",               // FLOWS
+	4:  "protocolIdentifier",           // PROTOCOL
+	5:  "ipClassOfService",             // TOS
+	6:  "tcpControlBits",               // TCP_FLAGS
+	7:  "sourceTransportPort",          // L4_SRC_PORT
+	8:  "sourceIPv4Address",            // IPV4_SRC_ADDR
+	9:  "sourceIPv4PrefixLength",       // SRC_MASK
+	10: "ingressInterface",             // INPUT_SNMP
+	11: "destinationTransportPort",     // L4_DST_PORT
+	12: "destinationIPv4Address",       // IPV4_DST_ADDR
+	13: "destinationIPv4PrefixLength",  // DST_MASK
+	14: "egressInterface",              // OUTPUT_SNMP
+	15: "ipNextHopIPv4Address",         // IPV4_NEXT_HOP
+	16: "bgpSourceAsNumber",            // SRC_AS
+	17: "bgpDestinationAsNumber",       // DST_AS
+	18: "bgpNextHopIPv4Address",        // BGP_IPV4_NEXT_HOP
+	19: "postMCastPacketDeltaCount",    // MUL_DST_PKTS
+	20: "postMCastOctetDeltaCount",     // MUL_DST_BYTES
+	21: "flowEndSysUpTime",             // LAST_SWITCHED
+	22: "flowStartSysUpTime",           // FIRST_SWITCHED
+	23: "postOctetDeltaCount",          // OUT_BYTES
+	24: "postPacketDeltaCount",         // OUT_PKTS
+	25: "minimumIpTotalLength",         // MIN_PKT_LNGTH
+	26: "maximumIpTotalLength",         // MAX_PKT_LNGTH
+	27: "sourceIPv6Address",            // IPV6_SRC_ADDR
+	28: "destinationIPv6Address",       // IPV6_DST_ADDR
+	29: "sourceIPv6PrefixLength",       // IPV6_SRC_MASK
+	30: "destinationIPv6PrefixLength",  // IPV6_DST_MASK
+	31: "flowLabelIPv6",                // IPV6_FLOW_LABEL
+	32: "icmpTypeCodeIPv4",             // ICMP_TYPE
+	33: "igmpType",                     // MUL_IGMP_TYPE
+	34: "samplingInterval",             // SAMPLING_INTERVAL
+	35: "samplingAlgorithm",            // SAMPLING_ALGORITHM
+	36: "flowActiveTimeout",            // FLOW_ACTIVE_TIMEOUT
+	37: "flowIdleTimeout",              // FLOW_INACTIVE_TIMEOUT
+	38: "engineType",                   // ENGINE_TYPE
+	39: "engineId",                     // ENGINE_ID
+	40: "exportedOctetTotalCount",      // TOTAL_BYTES_EXP
+	41: "exportedMessageTotalCount",    // TOTAL_PKTS_EXP
+	42: "exportedFlowRecordTotalCount", // TOTAL_FLOWS_EXP
+	44: "sourceIPv4Prefix",             // IPV4_SRC_PREFIX
+	45: "destinationIPv4Prefix",        // IPV4_DST_PREFIX
+	46: "mplsTopLabelType",             // MPLS_TOP_LABEL_TYPE
+	47: "mplsTopLabelIPv4Address",      // MPLS_TOP_LABEL_IP_ADDR
+	48: "samplerId",                    // FLOW_SAMPLER_ID
+	49: "samplerMode",                  // FLOW_SAMPLER_MODE
+	50: "samplerRandomInterval",        // FLOW_SAMPLER_RANDOM_INTERVAL
+	52: "minimumTTL",                   // MIN_TTL
+	53: "maximumTTL",                   // MAX_TTL
+	54: "fragmentIdentification",       // IPV4_IDENT
+	55: "postIpClassOfService",         // DST_TOS
+	56: "sourceMacAddress",             // IN_SRC_MAC
+	57: "postDestinationMacAddress",    // OUT_DST_MAC
+	58: "vlanId",                       // SRC_VLAN
+	59: "postVlanId",                   // DST_VLAN
+	60: "ipVersion",                    // IP_PROTOCOL_VERSION
+	61: "flowDirection",                // DIRECTION
+	62: "ipNextHopIPv6Address",         // IPV6_NEXT_HOP
+	63: "bgpNextHopIPv6Address",        // BGP_IPV6_NEXT_HOP
+	64: "ipv6ExtensionHeaders",         // IPV6_OPTION_HEADERS
+	70: "mplsTopLabelStackSection",     // MPLS_LABEL_1
+	71: "mplsLabelStackSection2",       // MPLS_LABEL_2
+	72: "mplsLabelStackSection3",       // MPLS_LABEL_3
+	73: "mplsLabelStackSection4",       // MPLS_LABEL_4
+	74: "mplsLabelStackSection5",       // MPLS_LABEL_5
+	75: "mplsLabelStackSection6",       // MPLS_LABEL_6
+	76: "mplsLabelStackSection7",       // MPLS_LABEL_7
+	77: "mplsLabelStackSection8",       // MPLS_LABEL_8
+	78: "mplsLabelStackSection9",       // MPLS_LABEL_9
+	79: "mplsLabelStackSection10",      // MPLS_LABEL_10
+	80: "destinationMacAddress",        // IN_DST_MAC
+	81: "postSourceMacAddress",         // OUT_SRC_MAC
+	82: "interfaceName",                // IF_NAME
+	83: "interfaceDescription",         // IF_DESC
+	84: "samplerName",                  // SAMPLER_NAME
+	85: "octetTotalCount",              // IN_PERMANENT_BYTES
+	86: "packetTotalCount",             // IN_PERMANENT_PKTS
+	88: "fragmentOffset",               // FRAGMENT_OFFSET
+	89: "forwardingStatus",             // FORWARDING_STATUS
+}
+
+// maxNetflowV9IANAFieldType is the maximum NetFlow v9 field type, which has
+// the same ID as the IANA Information Element. Higher field types may be
+// vendor specific.
+const maxNetflowV9IANAFieldType = 127
+
+// netflowV9ScopeFieldTypes shows mapping NetFlow v9 options scope field type ->
+// IANA Information Element name. The scope field types are defined in Section
+// 6.1 of RFC3954.
+var netflowV9ScopeFieldTypes = map[uint16]string{
+	1: "exportingProcessId", // System
+	2: "ingressInterface",   // Interface
+	3: "lineCardId",         // Line Card
+	4: "meteringProcessId",  // Cache
+	5: "templateId",         // Template
+}
+
+// GetNetflowV9InfoElement returns the IANA Information Element in the default
+// registry corresponding to the given NetFlow v9 field type.
+func GetNetflowV9InfoElement(fieldType uint16) (*entities.InfoElement, error) {
+	return defaultRegistry.LookupNetflowV9(fieldType)
+}
+
+// GetNetflowV9ScopeInfoElement returns the IANA Information Element in the
+// default registry corresponding to the given NetFlow v9 options scope field
+// type.
+func GetNetflowV9ScopeInfoElement(scopeFieldType uint16) (*entities.InfoElement, error) {
+	return defaultRegistry.LookupNetflowV9Scope(scopeFieldType)
+}
+
+// LookupNetflowV9 returns the IANA Information Element corresponding to the
+// given NetFlow v9 field type. Field types up to 127 which are not listed in
+// RFC3954 are identical to the IANA element IDs, so they are looked up by ID.
+func (r *Registry) LookupNetflowV9(fieldType uint16) (*entities.InfoElement, error) {
+	name, exist := netflowV9FieldTypes[fieldType]
+	if exist {
+		return r.LookupByName(name, IANAEnterpriseID)
+	}
+	if fieldType > maxNetflowV9IANAFieldType {
+		return nil, fmt.Errorf("NetFlow v9 field type %d is not supported.", fieldType)
+	}
+	return r.Lookup(fieldType, IANAEnterpriseID)
+}
+
+// LookupNetflowV9Scope returns the IANA Information Element corresponding to
+// the given NetFlow v9 options scope field type.
+func (r *Registry) LookupNetflowV9Scope(scopeFieldType uint16) (*entities.InfoElement, error) {
+	name, exist := netflowV9ScopeFieldTypes[scopeFieldType]
+	if !exist {
+		return nil, fmt.Errorf("NetFlow v9 scope field type %d is not supported.", scopeFieldType)
+	}
+	return r.LookupByName(name, IANAEnterpriseID)
+}
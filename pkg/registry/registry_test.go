@@ -15,6 +15,8 @@
 package registry
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,15 +25,15 @@ import (
 )
 
 func TestLoadRegistry(t *testing.T) {
-	assert.Equal(t, 0, len(globalRegistryByID))
-	assert.Equal(t, 0, len(globalRegistryByName))
+	assert.Equal(t, 0, len(defaultRegistry.registryByID))
+	assert.Equal(t, 0, len(defaultRegistry.registryByName))
 	LoadRegistry()
-	assert.NotEmpty(t, globalRegistryByName[IANAEnterpriseID])
-	assert.NotEmpty(t, globalRegistryByName[AntreaEnterpriseID])
-	assert.NotEmpty(t, globalRegistryByName[IANAReversedEnterpriseID])
-	assert.NotEmpty(t, globalRegistryByID[IANAEnterpriseID])
-	assert.NotEmpty(t, globalRegistryByID[AntreaEnterpriseID])
-	assert.NotEmpty(t, globalRegistryByID[IANAReversedEnterpriseID])
+	assert.NotEmpty(t, defaultRegistry.registryByName[IANAEnterpriseID])
+	assert.NotEmpty(t, defaultRegistry.registryByName[AntreaEnterpriseID])
+	assert.NotEmpty(t, defaultRegistry.registryByName[IANAReversedEnterpriseID])
+	assert.NotEmpty(t, defaultRegistry.registryByID[IANAEnterpriseID])
+	assert.NotEmpty(t, defaultRegistry.registryByID[AntreaEnterpriseID])
+	assert.NotEmpty(t, defaultRegistry.registryByID[IANAReversedEnterpriseID])
 }
 
 func TestGetInfoElement(t *testing.T) {
@@ -49,15 +51,28 @@ func TestGetInfoElement(t *testing.T) {
 func TestGetIANAReverseIE(t *testing.T) {
 	LoadRegistry()
 	// InfoElement does not exist in the registry
-	_, error := getIANAReverseInfoElement("sourcePodName")
+	_, error := defaultRegistry.getIANAReverseInfoElement("sourcePodName")
 	assert.NotEqual(t, nil, error, "GetIANAReverseIE should return error when ie does not exist.")
 	// InfoElement is not reversible
-	_, error = getIANAReverseInfoElement("flowKeyIndicator")
+	_, error = defaultRegistry.getIANAReverseInfoElement("flowKeyIndicator")
 	assert.NotEqual(t, nil, error, "GetIANAReverseIE should return error when ie is not reversible.")
 	// reverse InfoElement exists
-	reverseIE, _ := getIANAReverseInfoElement("deltaFlowCount")
+	reverseIE, _ := defaultRegistry.getIANAReverseInfoElement("deltaFlowCount")
 	assert.Equal(t, "reverseDeltaFlowCount", reverseIE.Name, "GetIANAReverseIE does not return correct reverse ie.")
 	assert.Equal(t, IANAReversedEnterpriseID, reverseIE.EnterpriseId, "GetIANAReverseIE does not return correct reverse ie.")
+	// Reversibility is kept in the registry
+	ie, _ := GetInfoElement("deltaFlowCount", IANAEnterpriseID)
+	assert.True(t, ie.Reversible)
+	assert.False(t, reverseIE.Reversible)
+	ie, _ = GetInfoElement("flowKeyIndicator", IANAEnterpriseID)
+	assert.False(t, ie.Reversible)
+	// Metadata of the Antrea registry
+	ie, _ = GetInfoElement("octetDeltaCountFromSourceNode", AntreaEnterpriseID)
+	assert.Equal(t, entities.DeltaCounter, ie.Semantics)
+	assert.Equal(t, entities.UnitsOctets, ie.Units)
+	ie, _ = GetInfoElement("packetTotalCountFromSourceNode", AntreaEnterpriseID)
+	assert.Equal(t, entities.TotalCounter, ie.Semantics)
+	assert.Equal(t, entities.UnitsPackets, ie.Units)
 }
 
 func TestGetInfoElementFromID(t *testing.T) {
@@ -78,3 +93,84 @@ func TestGetInfoElementFromID(t *testing.T) {
 	assert.Equal(t, "destinationNodeName", ie.Name, "TestGetInfoElementFromID does not return correct Antrea ie.")
 	assert.Equal(t, AntreaEnterpriseID, ie.EnterpriseId, "TestGetInfoElementFromID does not return correct Antrea ie.")
 }
+
+func TestRegistry(t *testing.T) {
+	r := New()
+	_, err := r.Lookup(1, IANAEnterpriseID)
+	assert.NotNil(t, err, "Lookup should return error for empty registry.")
+
+	vendorIE := entities.NewInfoElement("vendorCounter", 1, entities.Unsigned64, 12345, 8)
+	assert.Nil(t, r.Register(vendorIE))
+	assert.NotNil(t, r.Register(vendorIE), "Register should return error for element which is already registered.")
+	assert.NotNil(t, r.Register(entities.NewInfoElement("vendorPackets", 1, entities.Unsigned64, 12345, 8)), "Register should return error for element ID which is already registered.")
+	ie, err := r.Lookup(1, 12345)
+	assert.Nil(t, err)
+	assert.Equal(t, vendorIE, ie)
+	ie, err = r.LookupByName("vendorCounter", 12345)
+	assert.Nil(t, err)
+	assert.Equal(t, vendorIE, ie)
+	// Reverse element is registered with IANA element.
+	assert.Nil(t, r.Register(entities.NewInfoElement("octetDeltaCount", 1, entities.Unsigned64, IANAEnterpriseID, 8)))
+	ie, err = r.LookupByName("reverseOctetDeltaCount", IANAReversedEnterpriseID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(1), ie.ElementId)
+
+	// Registries are independent of each other and the default registry.
+	LoadRegistry()
+	_, err = GetInfoElement("vendorCounter", 12345)
+	assert.NotNil(t, err)
+	_, err = New().LookupByName("vendorCounter", 12345)
+	assert.NotNil(t, err)
+	_, err = r.LookupByName("ingressInterface", IANAEnterpriseID)
+	assert.NotNil(t, err)
+	r.LoadRegistry()
+	_, err = r.LookupByName("ingressInterface", IANAEnterpriseID)
+	assert.Nil(t, err)
+	assert.Equal(t, defaultRegistry, Default())
+}
+
+func TestRegistryConcurrentAccess(t *testing.T) {
+	r := New()
+	r.LoadRegistry()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(id uint16) {
+			defer wg.Done()
+			assert.Nil(t, r.Register(entities.NewInfoElement(fmt.Sprintf("vendorElement%d", id), id, entities.Unsigned32, 12345, 4)))
+		}(uint16(i))
+		go func() {
+			defer wg.Done()
+			_, err := r.Lookup(1, IANAEnterpriseID)
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+	for i := 0; i < 10; i++ {
+		_, err := r.Lookup(uint16(i), 12345)
+		assert.Nil(t, err)
+	}
+}
+
+func TestGetNetflowV9InfoElement(t *testing.T) {
+	LoadRegistry()
+	for fieldType, name := range netflowV9FieldTypes {
+		ie, err := GetNetflowV9InfoElement(fieldType)
+		assert.Nil(t, err, "NetFlow v9 field type %d should be mapped to an existing IE.", fieldType)
+		assert.Equal(t, name, ie.Name)
+	}
+	for scopeFieldType := range netflowV9ScopeFieldTypes {
+		_, err := GetNetflowV9ScopeInfoElement(scopeFieldType)
+		assert.Nil(t, err, "NetFlow v9 scope field type %d should be mapped to an existing IE.", scopeFieldType)
+	}
+	// Field types which are not listed are looked up in the IANA registry.
+	ie, err := GetNetflowV9InfoElement(95)
+	assert.Nil(t, err)
+	assert.Equal(t, "applicationId", ie.Name)
+	_, err = GetNetflowV9InfoElement(105)
+	assert.NotNil(t, err, "GetNetflowV9InfoElement should return error for field type which is not in the IANA registry.")
+	_, err = GetNetflowV9InfoElement(225)
+	assert.NotNil(t, err, "GetNetflowV9InfoElement should return error for vendor specific field type.")
+	_, err = GetNetflowV9ScopeInfoElement(6)
+	assert.NotNil(t, err, "GetNetflowV9ScopeInfoElement should return error for unsupported scope field type.")
+}
@@ -164,7 +164,7 @@ func TestCollectorToIntermediate(t *testing.T) {
 		case "flowEndSeconds":
 			assert.Equal(t, uint32(1257895000), element.Value)
 		case "packetTotalCount":
-			assert.Equal(t, uint64(400), element.Value)
+			assert.Equal(t, uint64(1000), element.Value)
 		case "packetDeltaCount":
 			assert.Equal(t, uint64(700), element.Value)
 		case "destinationClusterIPv4":
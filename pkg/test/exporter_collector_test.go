@@ -19,6 +19,7 @@ package test
 import (
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -234,6 +235,129 @@ func TestDTLSTransport(t *testing.T) {
 	testExporterToCollector(address, false, true, t)
 }
 
+func TestTypeInformationTCPTransport(t *testing.T) {
+	address, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Error(err)
+	}
+	// The element is only known by the exporter, which describes it to the
+	// collector with type information.
+	vendorElement := entities.NewInfoElement("vendorCounter", 1, entities.Unsigned32, 12345, 4)
+	vendorElement.Semantics = entities.DeltaCounter
+	exporterRegistry := registry.New()
+	exporterRegistry.LoadRegistry()
+	if err = exporterRegistry.Register(vendorElement); err != nil {
+		t.Fatalf("Got error when registering element: %v", err)
+	}
+	cp, _ := collector.InitCollectingProcess(collector.CollectorInput{Address: address, MaxBufferSize: 1024})
+	go cp.Start()
+	go func() {
+		waitForCollectorReady(t, cp)
+		export, err := exporter.InitExportingProcess(exporter.ExporterInput{
+			CollectorAddr:       cp.GetAddress(),
+			ObservationDomainID: 1,
+			Registry:            exporterRegistry,
+			SendTypeInformation: true,
+		})
+		if err != nil {
+			t.Errorf("Got error when connecting to %s", cp.GetAddress().String())
+			return
+		}
+		sourceAddress, _ := exporterRegistry.LookupByName("sourceIPv4Address", registry.IANAEnterpriseID)
+		templateID := export.NewTemplateID()
+		templateSet := entities.NewSet(entities.Template, templateID, false)
+		templateSet.AddRecord([]*entities.InfoElementWithValue{entities.NewInfoElementWithValue(sourceAddress, nil), entities.NewInfoElementWithValue(vendorElement, nil)}, templateID)
+		if _, err = export.SendSet(templateSet); err != nil {
+			t.Errorf("Got error when sending record: %v", err)
+			return
+		}
+		dataSet := entities.NewSet(entities.Data, templateID, false)
+		dataSet.AddRecord([]*entities.InfoElementWithValue{entities.NewInfoElementWithValue(sourceAddress, net.ParseIP("10.0.0.1")), entities.NewInfoElementWithValue(vendorElement, uint32(42))}, templateID)
+		if _, err = export.SendSet(dataSet); err != nil {
+			t.Errorf("Got error when sending record: %v", err)
+			return
+		}
+		export.CloseConnToCollector()
+	}()
+
+	// Type information template and record are received before the template
+	// and data record.
+	messages := make([]*entities.Message, 0)
+	for message := range cp.GetMsgChan() {
+		messages = append(messages, message)
+		if len(messages) == 4 {
+			cp.CloseMsgChan()
+		}
+	}
+	cp.Stop()
+	assert.Equal(t, entities.OptionsTemplate, messages[0].GetSets()[0].GetSetType())
+	typeInfoRecord := messages[1].GetSets()[0].GetRecords()[0]
+	name, exist := typeInfoRecord.GetInfoElementWithValue("informationElementName")
+	assert.True(t, exist)
+	assert.Equal(t, "vendorCounter", name.Value)
+	templateElements := messages[2].GetSets()[0].GetRecords()[0].GetOrderedElementList()
+	assert.Equal(t, "vendorCounter", templateElements[1].Element.Name, "Element should be learned from type information.")
+	assert.Equal(t, entities.DeltaCounter, templateElements[1].Element.Semantics, "Semantics should be learned from type information.")
+	ie, exist := messages[3].GetSets()[0].GetRecords()[0].GetInfoElementWithValue("vendorCounter")
+	assert.True(t, exist)
+	assert.Equal(t, uint32(42), ie.Value)
+}
+
+func TestBufferedExportTCPTransport(t *testing.T) {
+	address, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Error(err)
+	}
+	cp, _ := collector.InitCollectingProcess(collector.CollectorInput{Address: address, MaxBufferSize: 1024})
+	go cp.Start()
+	go func() {
+		waitForCollectorReady(t, cp)
+		export, err := exporter.InitExportingProcess(exporter.ExporterInput{
+			CollectorAddr:       cp.GetAddress(),
+			ObservationDomainID: 1,
+			EnableBuffering:     true,
+			MaxDelay:            time.Hour,
+		})
+		if err != nil {
+			t.Errorf("Got error when connecting to %s", cp.GetAddress().String())
+			return
+		}
+		sourceAddress, _ := registry.GetInfoElement("sourceIPv4Address", registry.IANAEnterpriseID)
+		protocol, _ := registry.GetInfoElement("protocolIdentifier", registry.IANAEnterpriseID)
+		templateID := export.NewTemplateID()
+		templateSet := entities.NewSet(entities.Template, templateID, false)
+		templateSet.AddRecord([]*entities.InfoElementWithValue{entities.NewInfoElementWithValue(sourceAddress, nil), entities.NewInfoElementWithValue(protocol, nil)}, templateID)
+		if _, err = export.SendSet(templateSet); err != nil {
+			t.Errorf("Got error when sending record: %v", err)
+			return
+		}
+		for i := 0; i < 2; i++ {
+			dataSet := entities.NewSet(entities.Data, templateID, false)
+			dataSet.AddRecord([]*entities.InfoElementWithValue{entities.NewInfoElementWithValue(sourceAddress, net.ParseIP("10.0.0.1")), entities.NewInfoElementWithValue(protocol, uint8(6))}, templateID)
+			if _, err = export.SendSet(dataSet); err != nil {
+				t.Errorf("Got error when sending record: %v", err)
+				return
+			}
+		}
+		// Buffered sets are sent when the connection is closed.
+		export.CloseConnToCollector()
+	}()
+
+	// Template set and data sets are received in one message with padding.
+	message := <-cp.GetMsgChan()
+	cp.Stop()
+	assert.Equal(t, 3, len(message.GetSets()))
+	assert.Equal(t, entities.Template, message.GetSets()[0].GetSetType())
+	for _, dataSet := range message.GetSets()[1:] {
+		assert.Equal(t, entities.Data, dataSet.GetSetType())
+		assert.Equal(t, uint32(1), dataSet.GetNumberOfRecords())
+		ie, exist := dataSet.GetRecords()[0].GetInfoElementWithValue("protocolIdentifier")
+		assert.True(t, exist)
+		assert.Equal(t, uint8(6), ie.Value)
+	}
+	assert.Equal(t, uint32(0), message.GetSequenceNum(), "No data records should be sent before the message.")
+}
+
 func testExporterToCollector(address net.Addr, isMultipleRecord bool, isEncrypted bool, t *testing.T) {
 	// Initialize collecting process
 	messages := make([]*entities.Message, 0)
@@ -311,7 +435,7 @@ func testExporterToCollector(address net.Addr, isMultipleRecord bool, isEncrypte
 
 	assert.Equal(t, uint16(10), templateMsg.GetVersion(), "Version of flow record (template) should be 10.")
 	assert.Equal(t, uint32(1), templateMsg.GetObsDomainID(), "ObsDomainID (template) should be 1.")
-	templateSet := templateMsg.GetSet()
+	templateSet := templateMsg.GetSets()[0]
 	templateElements := templateSet.GetRecords()[0].GetOrderedElementList()
 	assert.Equal(t, len(templateElements), len(fields)+len(antreaFields)+len(reverseFields))
 	assert.Equal(t, uint32(0), templateElements[0].Element.EnterpriseId, "Template record is not stored correctly.")
@@ -323,7 +447,7 @@ func testExporterToCollector(address net.Addr, isMultipleRecord bool, isEncrypte
 	dataMsg := messages[1]
 	assert.Equal(t, uint16(10), dataMsg.GetVersion(), "Version of flow record (template) should be 10.")
 	assert.Equal(t, uint32(1), dataMsg.GetObsDomainID(), "ObsDomainID (template) should be 1.")
-	dataSet := dataMsg.GetSet()
+	dataSet := dataMsg.GetSets()[0]
 	record := dataSet.GetRecords()[0]
 	for _, name := range fields {
 		element, exist := record.GetInfoElementWithValue(name)